@@ -0,0 +1,185 @@
+// Copyright 2017 The TCell Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use file except in compliance with the License.
+// You may obtain a copy of the license at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package asciicast
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/thyth/tcell"
+	"github.com/thyth/tcell/vt"
+)
+
+// ErrBadRecording is returned when a recording cannot be parsed.
+var ErrBadRecording = errors.New("malformed asciicast recording")
+
+// Event is a single event of a recording.
+type Event struct {
+	// Time is the time of the event, relative to the start of the
+	// recording.
+	Time time.Duration
+
+	// Type is Output, Input or Resize.
+	Type string
+
+	// Data is the text written or typed, or the new size of the
+	// terminal as "WxH".
+	Data string
+}
+
+// Recording is a recorded session.
+type Recording struct {
+	Header Header
+	Events []Event
+}
+
+// ReadRecording reads a recording in asciicast v2 format.
+func ReadRecording(r io.Reader) (*Recording, error) {
+	rec := &Recording{}
+	sc := bufio.NewScanner(r)
+	sc.Buffer(nil, 16*1024*1024)
+	if !sc.Scan() {
+		if e := sc.Err(); e != nil {
+			return nil, e
+		}
+		return nil, ErrBadRecording
+	}
+	if e := json.Unmarshal(sc.Bytes(), &rec.Header); e != nil {
+		return nil, ErrBadRecording
+	}
+	if rec.Header.Version != 2 {
+		return nil, ErrBadRecording
+	}
+	for sc.Scan() {
+		line := sc.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		var raw []interface{}
+		if e := json.Unmarshal(line, &raw); e != nil || len(raw) != 3 {
+			return nil, ErrBadRecording
+		}
+		t, ok1 := raw[0].(float64)
+		kind, ok2 := raw[1].(string)
+		data, ok3 := raw[2].(string)
+		if !ok1 || !ok2 || !ok3 {
+			return nil, ErrBadRecording
+		}
+		rec.Events = append(rec.Events, Event{
+			Time: time.Duration(t * float64(time.Second)),
+			Type: kind,
+			Data: data,
+		})
+	}
+	if e := sc.Err(); e != nil {
+		return nil, e
+	}
+	return rec, nil
+}
+
+// RawRecording makes a recording from the raw output sent to a terminal
+// of the given size, for example everything written to a quasi screen.
+// All of the output happens at the start of the recording.
+func RawRecording(b []byte, w, h int) *Recording {
+	return &Recording{
+		Header: Header{Version: 2, Width: w, Height: h},
+		Events: []Event{{Type: Output, Data: string(b)}},
+	}
+}
+
+// Player replays a recording, to find out what the terminal showed at any
+// point during it.  The output is interpreted by a vt.Terminal, and the
+// screen contents are reported in the same form as those of a
+// SimulationScreen.
+type Player struct {
+	rec  *Recording
+	term *vt.Terminal
+	next int
+	at   time.Duration
+}
+
+// NewPlayer returns a Player positioned at the start of the recording,
+// before any events have been replayed.
+func NewPlayer(rec *Recording) *Player {
+	p := &Player{rec: rec}
+	p.rewind()
+	return p
+}
+
+func (p *Player) rewind() {
+	p.term = vt.NewTerminal(p.rec.Header.Width, p.rec.Header.Height)
+	p.next = 0
+	p.at = 0
+}
+
+// Seek replays the events up to and including the given time.  Seeking
+// backwards replays the recording again from the start.
+func (p *Player) Seek(at time.Duration) {
+	if at < p.at {
+		p.rewind()
+	}
+	p.at = at
+	for ; p.next < len(p.rec.Events); p.next++ {
+		ev := &p.rec.Events[p.next]
+		if ev.Time > at {
+			break
+		}
+		switch ev.Type {
+		case Output:
+			p.term.Write([]byte(ev.Data))
+		case Resize:
+			var w, h int
+			if n, _ := fmt.Sscanf(ev.Data, "%dx%d", &w, &h); n == 2 &&
+				w > 0 && h > 0 {
+				p.term.Resize(w, h)
+			}
+		}
+	}
+}
+
+// End replays the whole recording.
+func (p *Player) End() {
+	p.Seek(p.Duration())
+}
+
+// Duration returns the time of the last event of the recording.
+func (p *Player) Duration() time.Duration {
+	if n := len(p.rec.Events); n > 0 {
+		return p.rec.Events[n-1].Time
+	}
+	return 0
+}
+
+// GetContents returns the cells the terminal showed at the current time,
+// along with its width and height, just as SimulationScreen does.
+func (p *Player) GetContents() ([]tcell.SimCell, int, int) {
+	return p.term.GetContents()
+}
+
+// GetCursor returns the position of the cursor, and whether it was
+// visible.
+func (p *Player) GetCursor() (int, int, bool) {
+	return p.term.Cursor()
+}
+
+// Terminal returns the emulated terminal, for examining details of its
+// state beyond the screen contents.
+func (p *Player) Terminal() *vt.Terminal {
+	return p.term
+}
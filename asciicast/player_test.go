@@ -0,0 +1,145 @@
+// Copyright 2017 The TCell Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use file except in compliance with the License.
+// You may obtain a copy of the license at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package asciicast
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/thyth/tcell"
+)
+
+// playText returns the text of n cells of the player's screen.
+func playText(p *Player, x, y, n int) string {
+	cells, w, _ := p.GetContents()
+	str := ""
+	for i := 0; i < n; i++ {
+		str += string(cells[y*w+x+i].Runes)
+	}
+	return str
+}
+
+func TestPlayer(t *testing.T) {
+
+	Convey("Replaying a quasi screen", t, func() {
+		buf := &bytes.Buffer{}
+		r, e := NewRecorder(buf, Header{Width: 20, Height: 5})
+		So(e, ShouldBeNil)
+		pr, pw := io.Pipe()
+		defer pw.Close()
+		out := &bytes.Buffer{}
+		qs, e := tcell.NewQuasiScreenWithOptions(pr,
+			r.Writer(nopWriteCloser{out}),
+			tcell.QuasiScreenOptions{Term: "xterm", Width: 20, Height: 5})
+		So(e, ShouldBeNil)
+		So(qs.Init(), ShouldBeNil)
+
+		st := tcell.StyleDefault.Foreground(tcell.ColorMaroon).
+			Background(tcell.ColorNavy).Bold(true)
+		for i, c := range "hello" {
+			qs.SetContent(2+i, 1, c, nil, st)
+		}
+		qs.SetContent(0, 3, '世', nil, tcell.StyleDefault)
+		qs.SetContent(2, 3, tcell.RuneHLine, nil, tcell.StyleDefault)
+		qs.ShowCursor(4, 4)
+		qs.Show()
+		shown := out.String()
+		qs.Fini()
+		So(r.Close(), ShouldBeNil)
+
+		rec, e := ReadRecording(strings.NewReader(buf.String()))
+		So(e, ShouldBeNil)
+		p := NewPlayer(rec)
+		Convey("Nothing is shown before the start", func() {
+			cells, w, h := p.GetContents()
+			So(w, ShouldEqual, 20)
+			So(h, ShouldEqual, 5)
+			So(len(cells), ShouldEqual, 100)
+			So(playText(p, 0, 0, 20), ShouldEqual, strings.Repeat(" ", 20))
+		})
+
+		Convey("The content drawn is shown", func() {
+			// Fini clears the screen, so stop just before it.
+			p = NewPlayer(RawRecording([]byte(shown), 20, 5))
+			p.End()
+			So(playText(p, 2, 1, 5), ShouldEqual, "hello")
+			cells, w, _ := p.GetContents()
+			So(cells[1*w+2].Style, ShouldResemble, st)
+			So(cells[1*w+1].Style, ShouldResemble, tcell.StyleDefault)
+			So(string(cells[3*w].Bytes), ShouldEqual, "世")
+			So(cells[3*w+1].Runes, ShouldBeNil)
+			So(playText(p, 2, 3, 1), ShouldEqual, string(tcell.RuneHLine))
+			x, y, visible := p.GetCursor()
+			So(x, ShouldEqual, 4)
+			So(y, ShouldEqual, 4)
+			So(visible, ShouldBeTrue)
+		})
+
+		Convey("The original screen is restored at the end", func() {
+			p.End()
+			So(playText(p, 2, 1, 5), ShouldEqual, "     ")
+		})
+	})
+
+	Convey("Replaying over time", t, func() {
+		rec := &Recording{
+			Header: Header{Version: 2, Width: 10, Height: 3},
+			Events: []Event{
+				{Time: 0, Type: Output, Data: "one\r\n"},
+				{Time: time.Second, Type: Output, Data: "\x1b[31mtwo"},
+				{Time: 2 * time.Second, Type: Resize, Data: "5x2"},
+				{Time: 3 * time.Second, Type: Output, Data: "\x1b[H\x1b[2J"},
+			},
+		}
+		p := NewPlayer(rec)
+		So(p.Duration(), ShouldEqual, 3*time.Second)
+
+		p.Seek(500 * time.Millisecond)
+		So(playText(p, 0, 0, 3), ShouldEqual, "one")
+		So(playText(p, 0, 1, 3), ShouldEqual, "   ")
+
+		p.Seek(2 * time.Second)
+		_, w, h := p.GetContents()
+		So(w, ShouldEqual, 5)
+		So(h, ShouldEqual, 2)
+		So(playText(p, 0, 1, 3), ShouldEqual, "two")
+		cells, _, _ := p.GetContents()
+		fg, _, _ := cells[w].Style.Decompose()
+		So(fg, ShouldEqual, tcell.ColorMaroon)
+
+		p.End()
+		So(playText(p, 0, 0, 5), ShouldEqual, "     ")
+
+		// Seeking backwards starts again.
+		p.Seek(time.Second)
+		_, w, _ = p.GetContents()
+		So(w, ShouldEqual, 10)
+		So(playText(p, 0, 1, 3), ShouldEqual, "two")
+	})
+
+	Convey("Bad recordings are rejected", t, func() {
+		_, e := ReadRecording(strings.NewReader(""))
+		So(e, ShouldEqual, ErrBadRecording)
+		_, e = ReadRecording(strings.NewReader("{\"version\": 1}\n"))
+		So(e, ShouldEqual, ErrBadRecording)
+		_, e = ReadRecording(strings.NewReader(
+			"{\"version\": 2}\n[1, \"o\"]\n"))
+		So(e, ShouldEqual, ErrBadRecording)
+	})
+}
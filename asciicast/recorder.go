@@ -0,0 +1,282 @@
+// Copyright 2017 The TCell Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use file except in compliance with the License.
+// You may obtain a copy of the license at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package asciicast records terminal sessions in the asciicast v2 format
+// used by asciinema.
+//
+// A recording is a header line, holding a JSON object that describes the
+// terminal, followed by one line per event, each a JSON array of the time
+// in seconds since the start of the recording, the event type ("o" for
+// output, "i" for input and "r" for a window resize) and the event data.
+//
+// The usual way to record a quasi screen is to wrap the streams given to
+// it with the recorder's Writer and Reader, and the screen itself with
+// the recorder's Screen, so that window size changes are captured too.
+//
+// Recordings can also be played back with a Player, which interprets the
+// output the way a terminal would, so that tests can check what a remote
+// user actually saw at any point of a session.
+package asciicast
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/thyth/tcell"
+)
+
+// Header describes the terminal that a recording was made on.
+type Header struct {
+	// Version is the format version, which is always 2.
+	Version int `json:"version"`
+
+	// Width and Height are the initial size of the terminal, in
+	// character cells.
+	Width  int `json:"width"`
+	Height int `json:"height"`
+
+	// Timestamp is the time the recording started, in seconds since
+	// the Unix epoch.  If zero, the current time is used.
+	Timestamp int64 `json:"timestamp,omitempty"`
+
+	// Title is an optional title for the recording.
+	Title string `json:"title,omitempty"`
+
+	// Env holds selected environment variables of the session, usually
+	// TERM and SHELL.
+	Env map[string]string `json:"env,omitempty"`
+}
+
+// Event types.
+const (
+	Output = "o"
+	Input  = "i"
+	Resize = "r"
+)
+
+// Recorder writes an asciicast recording.  It is safe for concurrent
+// use.  Once writing the recording has failed, nothing more is recorded,
+// and the error is returned by every subsequent call.
+type Recorder struct {
+	w      io.Writer
+	start  time.Time
+	last   time.Duration
+	width  int
+	height int
+	err    error
+
+	// Partial UTF-8 sequences left over from the end of a write, since
+	// the event data has to be valid UTF-8.
+	opend []byte
+	ipend []byte
+
+	sync.Mutex
+}
+
+// NewRecorder writes the header to w, and returns a Recorder that writes
+// the events that follow.
+func NewRecorder(w io.Writer, hdr Header) (*Recorder, error) {
+	start := time.Now()
+	hdr.Version = 2
+	if hdr.Timestamp == 0 {
+		hdr.Timestamp = start.Unix()
+	}
+	b, e := json.Marshal(&hdr)
+	if e != nil {
+		return nil, e
+	}
+	if _, e = w.Write(append(b, '\n')); e != nil {
+		return nil, e
+	}
+	return &Recorder{
+		w:      w,
+		start:  start,
+		width:  hdr.Width,
+		height: hdr.Height,
+	}, nil
+}
+
+// Output records data written to the terminal.
+func (r *Recorder) Output(b []byte) error {
+	r.Lock()
+	defer r.Unlock()
+	r.opend = r.text(time.Now(), Output, r.opend, b)
+	return r.err
+}
+
+// Input records data typed at the terminal.
+func (r *Recorder) Input(b []byte) error {
+	r.Lock()
+	defer r.Unlock()
+	r.ipend = r.text(time.Now(), Input, r.ipend, b)
+	return r.err
+}
+
+// Resize records a change in the size of the terminal that happened at
+// the given time.  Changes to the size the terminal already has are
+// ignored.
+func (r *Recorder) Resize(when time.Time, w, h int) error {
+	r.Lock()
+	defer r.Unlock()
+	if w != r.width || h != r.height {
+		r.width, r.height = w, h
+		r.event(when, Resize, fmt.Sprintf("%dx%d", w, h))
+	}
+	return r.err
+}
+
+// Close records any partial characters that are still held back, and
+// closes the underlying writer if it is an io.Closer.
+func (r *Recorder) Close() error {
+	r.Lock()
+	defer r.Unlock()
+	now := time.Now()
+	if len(r.opend) != 0 {
+		r.event(now, Output, string(r.opend))
+	}
+	if len(r.ipend) != 0 {
+		r.event(now, Input, string(r.ipend))
+	}
+	r.opend, r.ipend = nil, nil
+	if c, ok := r.w.(io.Closer); ok {
+		if e := c.Close(); e != nil && r.err == nil {
+			r.err = e
+		}
+	}
+	return r.err
+}
+
+// text records data as an event, holding back an incomplete character at
+// the end, which is returned to be prepended to the next data.  The
+// caller must hold the lock.
+func (r *Recorder) text(when time.Time, kind string, pend, b []byte) []byte {
+	if len(pend) != 0 {
+		b = append(pend, b...)
+	}
+	n := len(b)
+	// Look for the start of the last character, which is at most
+	// utf8.UTFMax bytes from the end.
+	for i := len(b) - 1; i >= 0 && i >= len(b)-utf8.UTFMax; i-- {
+		if utf8.RuneStart(b[i]) {
+			if !utf8.FullRune(b[i:]) {
+				n = i
+			}
+			break
+		}
+	}
+	if n > 0 {
+		r.event(when, kind, string(b[:n]))
+	}
+	if n == len(b) {
+		return nil
+	}
+	return append([]byte(nil), b[n:]...)
+}
+
+// event writes a single event.  Times never go backwards, even if an
+// event is recorded later than it happened.  The caller must hold the
+// lock.
+func (r *Recorder) event(when time.Time, kind string, data string) {
+	if r.err != nil {
+		return
+	}
+	t := when.Sub(r.start)
+	if t < r.last {
+		t = r.last
+	}
+	r.last = t
+
+	// Invalid UTF-8 is replaced by the JSON encoder.
+	d, e := json.Marshal(data)
+	if e != nil {
+		r.err = e
+		return
+	}
+	line := make([]byte, 0, len(d)+32)
+	line = append(line, '[')
+	line = strconv.AppendFloat(line, t.Seconds(), 'f', 6, 64)
+	line = append(line, ", \""...)
+	line = append(line, kind...)
+	line = append(line, "\", "...)
+	line = append(line, d...)
+	line = append(line, "]\n"...)
+	_, r.err = r.w.Write(line)
+}
+
+// Writer returns a stream that passes everything written to it on to out,
+// recording it as output.  Failures to record do not affect the stream;
+// they are reported by Close on the Recorder.  Closing the stream closes
+// out, but not the Recorder.
+func (r *Recorder) Writer(out io.WriteCloser) io.WriteCloser {
+	return &recWriter{out, r}
+}
+
+// Reader returns a stream that passes on everything read from in,
+// recording it as input.  Closing the stream closes in, but not the
+// Recorder.
+func (r *Recorder) Reader(in io.ReadCloser) io.ReadCloser {
+	return &recReader{in, r}
+}
+
+// Screen returns a Screen that behaves exactly like s, except that every
+// EventResize that the application receives from it is recorded, with the
+// time at which the resize happened.
+func (r *Recorder) Screen(s tcell.Screen) tcell.Screen {
+	return &recScreen{s, r}
+}
+
+type recWriter struct {
+	io.WriteCloser
+	r *Recorder
+}
+
+func (w *recWriter) Write(b []byte) (int, error) {
+	n, e := w.WriteCloser.Write(b)
+	if n > 0 {
+		w.r.Output(b[:n])
+	}
+	return n, e
+}
+
+type recReader struct {
+	io.ReadCloser
+	r *Recorder
+}
+
+func (rd *recReader) Read(b []byte) (int, error) {
+	n, e := rd.ReadCloser.Read(b)
+	if n > 0 {
+		rd.r.Input(b[:n])
+	}
+	return n, e
+}
+
+type recScreen struct {
+	tcell.Screen
+	r *Recorder
+}
+
+func (s *recScreen) PollEvent() tcell.Event {
+	ev := s.Screen.PollEvent()
+	if rev, ok := ev.(*tcell.EventResize); ok {
+		w, h := rev.Size()
+		s.r.Resize(rev.When(), w, h)
+	}
+	return ev
+}
@@ -0,0 +1,200 @@
+// Copyright 2017 The TCell Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use file except in compliance with the License.
+// You may obtain a copy of the license at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package asciicast
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/thyth/tcell"
+)
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error {
+	return nil
+}
+
+type failWriter struct {
+	n int
+}
+
+func (w *failWriter) Write(b []byte) (int, error) {
+	if w.n == 0 {
+		return 0, errors.New("disk full")
+	}
+	w.n--
+	return len(b), nil
+}
+
+type recEvent struct {
+	t    float64
+	kind string
+	data string
+}
+
+// parseRecording splits a recording into its header and events.
+func parseRecording(s string) (Header, []recEvent) {
+	var hdr Header
+	var evs []recEvent
+	lines := strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+	So(json.Unmarshal([]byte(lines[0]), &hdr), ShouldBeNil)
+	for _, line := range lines[1:] {
+		var raw []interface{}
+		So(json.Unmarshal([]byte(line), &raw), ShouldBeNil)
+		So(len(raw), ShouldEqual, 3)
+		evs = append(evs, recEvent{
+			t:    raw[0].(float64),
+			kind: raw[1].(string),
+			data: raw[2].(string),
+		})
+	}
+	return hdr, evs
+}
+
+func TestRecorder(t *testing.T) {
+
+	Convey("Recording sessions", t, func() {
+		buf := &bytes.Buffer{}
+		r, e := NewRecorder(buf, Header{
+			Width:  80,
+			Height: 24,
+			Env:    map[string]string{"TERM": "xterm"},
+		})
+		So(e, ShouldBeNil)
+
+		Convey("The header describes the terminal", func() {
+			So(r.Close(), ShouldBeNil)
+			hdr, evs := parseRecording(buf.String())
+			So(hdr.Version, ShouldEqual, 2)
+			So(hdr.Width, ShouldEqual, 80)
+			So(hdr.Height, ShouldEqual, 24)
+			So(hdr.Timestamp, ShouldBeGreaterThan, 0)
+			So(hdr.Env["TERM"], ShouldEqual, "xterm")
+			So(evs, ShouldBeEmpty)
+		})
+
+		Convey("Output and input are recorded", func() {
+			out := &bytes.Buffer{}
+			w := r.Writer(nopWriteCloser{out})
+			rd := r.Reader(ioutil.NopCloser(strings.NewReader("ls\r")))
+
+			io.WriteString(w, "hello\x1b[m")
+			b, _ := ioutil.ReadAll(rd)
+			So(string(b), ShouldEqual, "ls\r")
+			// A character split across writes is kept whole.
+			w.Write([]byte{0xc3})
+			w.Write([]byte{0xa9, '!'})
+			So(out.String(), ShouldEqual, "hello\x1b[mé!")
+
+			So(r.Close(), ShouldBeNil)
+			_, evs := parseRecording(buf.String())
+			So(len(evs), ShouldEqual, 3)
+			So(evs[0].kind, ShouldEqual, "o")
+			So(evs[0].data, ShouldEqual, "hello\x1b[m")
+			So(evs[1].kind, ShouldEqual, "i")
+			So(evs[1].data, ShouldEqual, "ls\r")
+			So(evs[2].kind, ShouldEqual, "o")
+			So(evs[2].data, ShouldEqual, "é!")
+			for i := 1; i < len(evs); i++ {
+				So(evs[i].t, ShouldBeGreaterThanOrEqualTo, evs[i-1].t)
+			}
+		})
+
+		Convey("Resizes seen by the application are recorded", func() {
+			sim := tcell.NewSimulationScreen("")
+			So(sim.Init(), ShouldBeNil)
+			Reset(sim.Fini)
+			s := r.Screen(sim)
+
+			sim.PostEvent(tcell.NewEventResize(80, 24))
+			sim.PostEvent(tcell.NewEventResize(100, 30))
+			for i := 0; i < 2; i++ {
+				_, ok := s.PollEvent().(*tcell.EventResize)
+				So(ok, ShouldBeTrue)
+			}
+
+			So(r.Close(), ShouldBeNil)
+			_, evs := parseRecording(buf.String())
+			So(len(evs), ShouldEqual, 1)
+			So(evs[0].kind, ShouldEqual, "r")
+			So(evs[0].data, ShouldEqual, "100x30")
+		})
+
+		Convey("A quasi screen can be recorded", func() {
+			pr, pw := io.Pipe()
+			Reset(func() {
+				pw.Close()
+			})
+			out := &bytes.Buffer{}
+			qs, e := tcell.NewQuasiScreenWithOptions(r.Reader(pr),
+				r.Writer(nopWriteCloser{out}),
+				tcell.QuasiScreenOptions{Term: "xterm"})
+			So(e, ShouldBeNil)
+			s := r.Screen(qs)
+			So(s.Init(), ShouldBeNil)
+			s.SetContent(0, 0, 'Z', nil, tcell.StyleDefault)
+			s.Show()
+			io.WriteString(pw, "q")
+			ev, ok := s.PollEvent().(*tcell.EventKey)
+			So(ok, ShouldBeTrue)
+			So(ev.Rune(), ShouldEqual, 'q')
+			s.Fini()
+
+			So(r.Close(), ShouldBeNil)
+			_, evs := parseRecording(buf.String())
+			output := ""
+			input := ""
+			for _, ev := range evs {
+				switch ev.kind {
+				case "o":
+					output += ev.data
+				case "i":
+					input += ev.data
+				}
+			}
+			So(output, ShouldEqual, out.String())
+			So(output, ShouldContainSubstring, "Z")
+			So(input, ShouldEqual, "q")
+		})
+	})
+
+	Convey("Recording failures are reported", t, func() {
+		fw := &failWriter{n: 1}
+		r, e := NewRecorder(fw, Header{Width: 80, Height: 24})
+		So(e, ShouldBeNil)
+		So(r.Output([]byte("a")), ShouldNotBeNil)
+		So(r.Resize(time.Now(), 10, 10), ShouldNotBeNil)
+		So(r.Close(), ShouldNotBeNil)
+
+		// The stream being recorded is not affected.
+		out := &bytes.Buffer{}
+		n, e := r.Writer(nopWriteCloser{out}).Write([]byte("abc"))
+		So(n, ShouldEqual, 3)
+		So(e, ShouldBeNil)
+
+		_, e = NewRecorder(&failWriter{}, Header{})
+		So(e, ShouldNotBeNil)
+	})
+}
@@ -0,0 +1,43 @@
+// Copyright 2017 The TCell Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use file except in compliance with the License.
+// You may obtain a copy of the license at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tcell
+
+import (
+	"time"
+)
+
+// EventClipboard is sent with the contents of the terminal's clipboard, in
+// reply to GetClipboard.  Terminals that do not allow the clipboard to be
+// read just never reply, so applications should not wait for one.  Nor
+// is there a reply when the contents come to more than a megabyte.
+type EventClipboard struct {
+	t    time.Time
+	data []byte
+}
+
+// NewEventClipboard creates an EventClipboard with the given contents.
+func NewEventClipboard(data []byte) *EventClipboard {
+	return &EventClipboard{t: time.Now(), data: data}
+}
+
+// When returns the time when this event was created.
+func (ev *EventClipboard) When() time.Time {
+	return ev.t
+}
+
+// Data returns the contents of the clipboard.
+func (ev *EventClipboard) Data() []byte {
+	return ev.data
+}
@@ -36,6 +36,10 @@ type cScreen struct {
 	style Style
 	clear bool
 	fini  bool
+	focus bool
+
+	otitle []uint16 // the title before SetTitle, for Fini to put back
+	titled bool
 
 	w int
 	h int
@@ -114,6 +118,8 @@ var (
 	procSetConsoleWindowInfo       = k32.NewProc("SetConsoleWindowInfo")
 	procSetConsoleScreenBufferSize = k32.NewProc("SetConsoleScreenBufferSize")
 	procSetConsoleTextAttribute    = k32.NewProc("SetConsoleTextAttribute")
+	procGetConsoleTitle            = k32.NewProc("GetConsoleTitleW")
+	procSetConsoleTitle            = k32.NewProc("SetConsoleTitleW")
 )
 
 const (
@@ -190,6 +196,70 @@ func (s *cScreen) DisableMouse() {
 	s.setInMode(modeResizeEn)
 }
 
+// EnablePaste does nothing, as the console has no way of telling pasted
+// text from typed text.
+func (s *cScreen) EnablePaste() {}
+
+func (s *cScreen) DisablePaste() {}
+
+// EnableFocus only affects whether focus events are posted, as the console
+// always reports them.
+func (s *cScreen) EnableFocus() {
+	s.Lock()
+	s.focus = true
+	s.Unlock()
+}
+
+func (s *cScreen) SetTitle(title string) {
+	s.Lock()
+	if !s.titled {
+		buf := make([]uint16, 1024)
+		n, _, _ := procGetConsoleTitle.Call(
+			uintptr(unsafe.Pointer(&buf[0])),
+			uintptr(len(buf)))
+		s.otitle = buf[:n]
+		s.titled = true
+	}
+	var runes []rune
+	for _, r := range title {
+		if r >= ' ' {
+			runes = append(runes, r)
+		}
+	}
+	s.setTitle(utf16.Encode(runes))
+	s.Unlock()
+}
+
+func (s *cScreen) setTitle(title []uint16) {
+	title = append(title[:len(title):len(title)], 0)
+	procSetConsoleTitle.Call(uintptr(unsafe.Pointer(&title[0])))
+}
+
+// Beep writes a bell character, which the console sounds.
+func (s *cScreen) Beep() {
+	s.Lock()
+	bell := []uint16{'\a'}
+	nw := uint32(len(bell))
+	syscall.WriteConsole(s.out, &bell[0], nw, &nw, nil)
+	s.Unlock()
+}
+
+// Flash rings the bell instead, as the console has no visual bell.
+func (s *cScreen) Flash() {
+	s.Beep()
+}
+
+// SetClipboard does nothing, as the console has no clipboard of its own.
+func (s *cScreen) SetClipboard(data []byte) {}
+
+func (s *cScreen) GetClipboard() {}
+
+func (s *cScreen) DisableFocus() {
+	s.Lock()
+	s.focus = false
+	s.Unlock()
+}
+
 func (s *cScreen) Fini() {
 	s.Lock()
 	s.style = StyleDefault
@@ -207,6 +277,12 @@ func (s *cScreen) Fini() {
 	procSetConsoleTextAttribute.Call(
 		uintptr(s.out),
 		uintptr(s.mapStyle(StyleDefault)))
+	s.Lock()
+	if s.titled {
+		s.setTitle(s.otitle)
+		s.titled = false
+	}
+	s.Unlock()
 
 	close(s.quit)
 	procSetEvent.Call(uintptr(s.cancelflag))
@@ -311,7 +387,7 @@ const (
 	mouseEvent  uint16 = 2
 	resizeEvent uint16 = 4
 	menuEvent   uint16 = 8  // don't use
-	focusEvent  uint16 = 16 // don't use
+	focusEvent  uint16 = 16
 )
 
 type mouseRecord struct {
@@ -600,6 +676,14 @@ func (s *cScreen) getConsoleInput() error {
 			rrec.y = geti16(rec.data[2:])
 			s.PostEvent(NewEventResize(int(rrec.x), int(rrec.y)))
 
+		case focusEvent:
+			s.Lock()
+			focus := s.focus
+			s.Unlock()
+			if focus {
+				s.PostEvent(NewEventFocus(geti32(rec.data[0:]) != 0))
+			}
+
 		default:
 		}
 	default:
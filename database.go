@@ -5,793 +5,972 @@ package tcell
 
 func init() {
 	AddTerminfo(&Terminfo{
-		Name:        "adm3a",
-		Columns:     80,
-		Lines:       24,
-		Bell:        "\a",
-		Clear:       "\x1a$<1/>",
-		PadChar:     "\x00",
-		SetCursor:   "\x1b=%p1%' '%+%c%p2%' '%+%c",
-		CursorBack1: "\b",
-		CursorUp1:   "\v",
-		KeyUp:       "\v",
-		KeyDown:     "\n",
-		KeyRight:    "\f",
-		KeyLeft:     "\b",
+		Name:           "adm3a",
+		Columns:        80,
+		Lines:          24,
+		Bell:           "\a",
+		Clear:          "\x1a$<1/>",
+		PadChar:        "\x00",
+		SetCursor:      "\x1b=%p1%' '%+%c%p2%' '%+%c",
+		CursorBack1:    "\b",
+		CursorUp1:      "\v",
+		CursorDown1:    "\n",
+		CursorForward1: "\f",
+		CursorHome:     "\x1e",
+		CarriageReturn: "\r",
+		KeyUp:          "\v",
+		KeyDown:        "\n",
+		KeyRight:       "\f",
+		KeyLeft:        "\b",
 	})
 	AddTerminfo(&Terminfo{
-		Name:         "aixterm",
-		Columns:      80,
-		Lines:        25,
-		Colors:       8,
-		Bell:         "\a",
-		Clear:        "\x1b[H\x1b[J",
-		AttrOff:      "\x1b[0;10m\x1b(B",
-		Underline:    "\x1b[4m",
-		Bold:         "\x1b[1m",
-		Reverse:      "\x1b[7m",
-		SetFg:        "\x1b[3%p1%dm",
-		SetBg:        "\x1b[4%p1%dm",
-		SetFgBg:      "\x1b[3%p1%d;4%p2%dm",
-		PadChar:      "\x00",
-		AltChars:     "jjkkllmmnnqqttuuvvwwxx",
-		SetCursor:    "\x1b[%i%p1%d;%p2%dH",
-		CursorBack1:  "\b",
-		CursorUp1:    "\x1b[A",
-		KeyUp:        "\x1b[A",
-		KeyDown:      "\x1b[B",
-		KeyRight:     "\x1b[C",
-		KeyLeft:      "\x1b[D",
-		KeyInsert:    "\x1b[139q",
-		KeyDelete:    "\x1b[P",
-		KeyBackspace: "\b",
-		KeyHome:      "\x1b[H",
-		KeyEnd:       "\x1b[146q",
-		KeyPgUp:      "\x1b[150q",
-		KeyPgDn:      "\x1b[154q",
-		KeyF1:        "\x1b[001q",
-		KeyF2:        "\x1b[002q",
-		KeyF3:        "\x1b[003q",
-		KeyF4:        "\x1b[004q",
-		KeyF5:        "\x1b[005q",
-		KeyF6:        "\x1b[006q",
-		KeyF7:        "\x1b[007q",
-		KeyF8:        "\x1b[008q",
-		KeyF9:        "\x1b[009q",
-		KeyF10:       "\x1b[010q",
-		KeyF11:       "\x1b[011q",
-		KeyF12:       "\x1b[012q",
-		KeyF13:       "\x1b[013q",
-		KeyF14:       "\x1b[014q",
-		KeyF15:       "\x1b[015q",
-		KeyF16:       "\x1b[016q",
-		KeyF17:       "\x1b[017q",
-		KeyF18:       "\x1b[018q",
-		KeyF19:       "\x1b[019q",
-		KeyF20:       "\x1b[020q",
-		KeyF21:       "\x1b[021q",
-		KeyF22:       "\x1b[022q",
-		KeyF23:       "\x1b[023q",
-		KeyF24:       "\x1b[024q",
-		KeyF25:       "\x1b[025q",
-		KeyF26:       "\x1b[026q",
-		KeyF27:       "\x1b[027q",
-		KeyF28:       "\x1b[028q",
-		KeyF29:       "\x1b[029q",
-		KeyF30:       "\x1b[030q",
-		KeyF31:       "\x1b[031q",
-		KeyF32:       "\x1b[032q",
-		KeyF33:       "\x1b[033q",
-		KeyF34:       "\x1b[034q",
-		KeyF35:       "\x1b[035q",
-		KeyF36:       "\x1b[036q",
-		KeyClear:     "\x1b[144q",
-		KeyBacktab:   "\x1b[Z",
+		Name:           "aixterm",
+		Columns:        80,
+		Lines:          25,
+		Colors:         8,
+		Bell:           "\a",
+		Clear:          "\x1b[H\x1b[J",
+		AttrOff:        "\x1b[0;10m\x1b(B",
+		Underline:      "\x1b[4m",
+		Bold:           "\x1b[1m",
+		Reverse:        "\x1b[7m",
+		SetFg:          "\x1b[3%p1%dm",
+		SetBg:          "\x1b[4%p1%dm",
+		SetFgBg:        "\x1b[3%p1%d;4%p2%dm",
+		PadChar:        "\x00",
+		AltChars:       "jjkkllmmnnqqttuuvvwwxx",
+		SetCursor:      "\x1b[%i%p1%d;%p2%dH",
+		CursorBack1:    "\b",
+		CursorUp1:      "\x1b[A",
+		CursorDown1:    "\n",
+		CursorForward1: "\x1b[C",
+		CursorBack:     "\x1b[%p1%dD",
+		CursorUp:       "\x1b[%p1%dA",
+		CursorDown:     "\x1b[%p1%dB",
+		CursorForward:  "\x1b[%p1%dC",
+		CursorHome:     "\x1b[H",
+		CarriageReturn: "\r",
+		ColumnAddress:  "\x1b[%i%p1%dG",
+		ClearToEOL:     "\x1b[K",
+		EraseChars:     "\x1b[%p1%dX",
+		ExitUnderline:  "\x1b[m",
+		ResetFgBg:      "\x1b[32m\x1b[40m",
+		KeyUp:          "\x1b[A",
+		KeyDown:        "\x1b[B",
+		KeyRight:       "\x1b[C",
+		KeyLeft:        "\x1b[D",
+		KeyInsert:      "\x1b[139q",
+		KeyDelete:      "\x1b[P",
+		KeyBackspace:   "\b",
+		KeyHome:        "\x1b[H",
+		KeyEnd:         "\x1b[146q",
+		KeyPgUp:        "\x1b[150q",
+		KeyPgDn:        "\x1b[154q",
+		KeyF1:          "\x1b[001q",
+		KeyF2:          "\x1b[002q",
+		KeyF3:          "\x1b[003q",
+		KeyF4:          "\x1b[004q",
+		KeyF5:          "\x1b[005q",
+		KeyF6:          "\x1b[006q",
+		KeyF7:          "\x1b[007q",
+		KeyF8:          "\x1b[008q",
+		KeyF9:          "\x1b[009q",
+		KeyF10:         "\x1b[010q",
+		KeyF11:         "\x1b[011q",
+		KeyF12:         "\x1b[012q",
+		KeyF13:         "\x1b[013q",
+		KeyF14:         "\x1b[014q",
+		KeyF15:         "\x1b[015q",
+		KeyF16:         "\x1b[016q",
+		KeyF17:         "\x1b[017q",
+		KeyF18:         "\x1b[018q",
+		KeyF19:         "\x1b[019q",
+		KeyF20:         "\x1b[020q",
+		KeyF21:         "\x1b[021q",
+		KeyF22:         "\x1b[022q",
+		KeyF23:         "\x1b[023q",
+		KeyF24:         "\x1b[024q",
+		KeyF25:         "\x1b[025q",
+		KeyF26:         "\x1b[026q",
+		KeyF27:         "\x1b[027q",
+		KeyF28:         "\x1b[028q",
+		KeyF29:         "\x1b[029q",
+		KeyF30:         "\x1b[030q",
+		KeyF31:         "\x1b[031q",
+		KeyF32:         "\x1b[032q",
+		KeyF33:         "\x1b[033q",
+		KeyF34:         "\x1b[034q",
+		KeyF35:         "\x1b[035q",
+		KeyF36:         "\x1b[036q",
+		KeyClear:       "\x1b[144q",
+		KeyBacktab:     "\x1b[Z",
 	})
 	AddTerminfo(&Terminfo{
-		Name:         "ansi",
-		Columns:      80,
-		Lines:        24,
-		Colors:       8,
-		Bell:         "\a",
-		Clear:        "\x1b[H\x1b[J",
-		AttrOff:      "\x1b[0;10m",
-		Underline:    "\x1b[4m",
-		Bold:         "\x1b[1m",
-		Blink:        "\x1b[5m",
-		Reverse:      "\x1b[7m",
-		SetFg:        "\x1b[3%p1%dm",
-		SetBg:        "\x1b[4%p1%dm",
-		SetFgBg:      "\x1b[3%p1%d;4%p2%dm",
-		PadChar:      "\x00",
-		AltChars:     "+\x10,\x11-\x18.\x190\xdb`\x04a\xb1f\xf8g\xf1h\xb0j\xd9k\xbfl\xdam\xc0n\xc5o~p\xc4q\xc4r\xc4s_t\xc3u\xb4v\xc1w\xc2x\xb3y\xf3z\xf2{\xe3|\xd8}\x9c~\xfe",
-		EnterAcs:     "\x1b[11m",
-		ExitAcs:      "\x1b[10m",
-		SetCursor:    "\x1b[%i%p1%d;%p2%dH",
-		CursorBack1:  "\x1b[D",
-		CursorUp1:    "\x1b[A",
-		KeyUp:        "\x1b[A",
-		KeyDown:      "\x1b[B",
-		KeyRight:     "\x1b[C",
-		KeyLeft:      "\x1b[D",
-		KeyInsert:    "\x1b[L",
-		KeyBackspace: "\b",
-		KeyHome:      "\x1b[H",
-		KeyBacktab:   "\x1b[Z",
+		Name:           "ansi",
+		Columns:        80,
+		Lines:          24,
+		Colors:         8,
+		Bell:           "\a",
+		Clear:          "\x1b[H\x1b[J",
+		AttrOff:        "\x1b[0;10m",
+		Underline:      "\x1b[4m",
+		Bold:           "\x1b[1m",
+		Blink:          "\x1b[5m",
+		Reverse:        "\x1b[7m",
+		SetFg:          "\x1b[3%p1%dm",
+		SetBg:          "\x1b[4%p1%dm",
+		SetFgBg:        "\x1b[3%p1%d;4%p2%dm",
+		PadChar:        "\x00",
+		AltChars:       "+\x10,\x11-\x18.\x190\xdb`\x04a\xb1f\xf8g\xf1h\xb0j\xd9k\xbfl\xdam\xc0n\xc5o~p\xc4q\xc4r\xc4s_t\xc3u\xb4v\xc1w\xc2x\xb3y\xf3z\xf2{\xe3|\xd8}\x9c~\xfe",
+		EnterAcs:       "\x1b[11m",
+		ExitAcs:        "\x1b[10m",
+		SetCursor:      "\x1b[%i%p1%d;%p2%dH",
+		CursorBack1:    "\x1b[D",
+		CursorUp1:      "\x1b[A",
+		CursorDown1:    "\x1b[B",
+		CursorForward1: "\x1b[C",
+		CursorBack:     "\x1b[%p1%dD",
+		CursorUp:       "\x1b[%p1%dA",
+		CursorDown:     "\x1b[%p1%dB",
+		CursorForward:  "\x1b[%p1%dC",
+		CursorHome:     "\x1b[H",
+		CarriageReturn: "\r",
+		ColumnAddress:  "\x1b[%i%p1%dG",
+		RowAddress:     "\x1b[%i%p1%dd",
+		ClearToEOL:     "\x1b[K",
+		EraseChars:     "\x1b[%p1%dX",
+		RepeatChar:     "%p1%c\x1b[%p2%{1}%-%db",
+		ExitUnderline:  "\x1b[m",
+		ResetFgBg:      "\x1b[39;49m",
+		KeyUp:          "\x1b[A",
+		KeyDown:        "\x1b[B",
+		KeyRight:       "\x1b[C",
+		KeyLeft:        "\x1b[D",
+		KeyInsert:      "\x1b[L",
+		KeyBackspace:   "\b",
+		KeyHome:        "\x1b[H",
+		KeyBacktab:     "\x1b[Z",
 	})
 	AddTerminfo(&Terminfo{
-		Name:         "aterm",
-		Columns:      80,
-		Lines:        24,
-		Colors:       8,
-		Bell:         "\a",
-		Clear:        "\x1b[H\x1b[2J",
-		EnterCA:      "\x1b7\x1b[?47h",
-		ExitCA:       "\x1b[2J\x1b[?47l\x1b8",
-		ShowCursor:   "\x1b[?25h",
-		HideCursor:   "\x1b[?25l",
-		AttrOff:      "\x1b[m\x0f",
-		Underline:    "\x1b[4m",
-		Bold:         "\x1b[1m",
-		Blink:        "\x1b[5m",
-		Reverse:      "\x1b[7m",
-		EnterKeypad:  "\x1b=",
-		ExitKeypad:   "\x1b>",
-		SetFg:        "\x1b[3%p1%dm",
-		SetBg:        "\x1b[4%p1%dm",
-		SetFgBg:      "\x1b[3%p1%d;4%p2%dm",
-		PadChar:      "\x00",
-		AltChars:     "``aaffggjjkkllmmnnooppqqrrssttuuvvwwxxyyzz{{||}}~~",
-		EnterAcs:     "\x0e",
-		ExitAcs:      "\x0f",
-		EnableAcs:    "\x1b(B\x1b)0",
-		Mouse:        "\x1b[M",
-		MouseMode:    "%?%p1%{1}%=%t%'h'%Pa%e%'l'%Pa%;\x1b[?1000%ga%c\x1b[?1002%ga%c\x1b[?1003%ga%c\x1b[?1006%ga%c",
-		SetCursor:    "\x1b[%i%p1%d;%p2%dH",
-		CursorBack1:  "\b",
-		CursorUp1:    "\x1b[A",
-		KeyUp:        "\x1b[A",
-		KeyDown:      "\x1b[B",
-		KeyRight:     "\x1b[C",
-		KeyLeft:      "\x1b[D",
-		KeyInsert:    "\x1b[2~",
-		KeyDelete:    "\x1b[3~",
-		KeyBackspace: "\u007f",
-		KeyHome:      "\x1b[7~",
-		KeyEnd:       "\x1b[8~",
-		KeyPgUp:      "\x1b[5~",
-		KeyPgDn:      "\x1b[6~",
-		KeyF1:        "\x1bOP",
-		KeyF2:        "\x1bOQ",
-		KeyF3:        "\x1bOR",
-		KeyF4:        "\x1bOS",
-		KeyF5:        "\x1b[15~",
-		KeyF6:        "\x1b[17~",
-		KeyF7:        "\x1b[18~",
-		KeyF8:        "\x1b[19~",
-		KeyF9:        "\x1b[20~",
-		KeyF10:       "\x1b[21~",
-		KeyF11:       "\x1b[23~",
-		KeyF12:       "\x1b[24~",
-		KeyF13:       "\x1b[25~",
-		KeyF14:       "\x1b[26~",
-		KeyF15:       "\x1b[28~",
-		KeyF16:       "\x1b[29~",
-		KeyF17:       "\x1b[31~",
-		KeyF18:       "\x1b[32~",
-		KeyF19:       "\x1b[33~",
-		KeyF20:       "\x1b[34~",
-		KeyF21:       "\x1b[23$",
-		KeyF22:       "\x1b[24$",
-		KeyF23:       "\x1b[11^",
-		KeyF24:       "\x1b[12^",
-		KeyF25:       "\x1b[13^",
-		KeyF26:       "\x1b[14^",
-		KeyF27:       "\x1b[15^",
-		KeyF28:       "\x1b[17^",
-		KeyF29:       "\x1b[18^",
-		KeyF30:       "\x1b[19^",
-		KeyF31:       "\x1b[20^",
-		KeyF32:       "\x1b[21^",
-		KeyF33:       "\x1b[23^",
-		KeyF34:       "\x1b[24^",
-		KeyF35:       "\x1b[25^",
-		KeyF36:       "\x1b[26^",
-		KeyF37:       "\x1b[28^",
-		KeyF38:       "\x1b[29^",
-		KeyF39:       "\x1b[31^",
-		KeyF40:       "\x1b[32^",
-		KeyF41:       "\x1b[33^",
-		KeyF42:       "\x1b[34^",
-		KeyF43:       "\x1b[23@",
-		KeyF44:       "\x1b[24@",
-		KeyBacktab:   "\x1b[Z",
-		KeyShfLeft:   "\x1b[d",
-		KeyShfRight:  "\x1b[c",
-		KeyShfUp:     "\x1b[a",
-		KeyShfDown:   "\x1b[b",
-		KeyCtrlLeft:  "\x1b[Od",
-		KeyCtrlRight: "\x1b[Oc",
-		KeyCtrlUp:    "\x1b[Oa",
-		KeyCtrlDown:  "\x1b[Ob",
-		KeyShfHome:   "\x1b[7$",
-		KeyShfEnd:    "\x1b[8$",
-		KeyCtrlHome:  "\x1b[7^",
-		KeyCtrlEnd:   "\x1b[8^",
+		Name:           "aterm",
+		Columns:        80,
+		Lines:          24,
+		Colors:         8,
+		Bell:           "\a",
+		Flash:          "\x1b[?5h$<100/>\x1b[?5l",
+		Clear:          "\x1b[H\x1b[2J",
+		EnterCA:        "\x1b7\x1b[?47h",
+		ExitCA:         "\x1b[2J\x1b[?47l\x1b8",
+		ShowCursor:     "\x1b[?25h",
+		HideCursor:     "\x1b[?25l",
+		AttrOff:        "\x1b[m\x0f",
+		Underline:      "\x1b[4m",
+		Bold:           "\x1b[1m",
+		Blink:          "\x1b[5m",
+		Reverse:        "\x1b[7m",
+		EnterKeypad:    "\x1b=",
+		ExitKeypad:     "\x1b>",
+		SetFg:          "\x1b[3%p1%dm",
+		SetBg:          "\x1b[4%p1%dm",
+		SetFgBg:        "\x1b[3%p1%d;4%p2%dm",
+		PadChar:        "\x00",
+		AltChars:       "``aaffggjjkkllmmnnooppqqrrssttuuvvwwxxyyzz{{||}}~~",
+		EnterAcs:       "\x0e",
+		ExitAcs:        "\x0f",
+		EnableAcs:      "\x1b(B\x1b)0",
+		Mouse:          "\x1b[M",
+		MouseMode:      "%?%p1%{1}%=%t%'h'%Pa%e%'l'%Pa%;\x1b[?1000%ga%c\x1b[?1002%ga%c\x1b[?1003%ga%c\x1b[?1006%ga%c",
+		SetCursor:      "\x1b[%i%p1%d;%p2%dH",
+		CursorBack1:    "\b",
+		CursorUp1:      "\x1b[A",
+		CursorDown1:    "\n",
+		CursorForward1: "\x1b[C",
+		CursorBack:     "\x1b[%p1%dD",
+		CursorUp:       "\x1b[%p1%dA",
+		CursorDown:     "\x1b[%p1%dB",
+		CursorForward:  "\x1b[%p1%dC",
+		CursorHome:     "\x1b[H",
+		CarriageReturn: "\r",
+		ColumnAddress:  "\x1b[%i%p1%dG",
+		RowAddress:     "\x1b[%i%p1%dd",
+		ClearToEOL:     "\x1b[K",
+		BackColorErase: true,
+		ExitUnderline:  "\x1b[24m",
+		ResetFgBg:      "\x1b[39;49m",
+		EnablePaste:    "\x1b[?2004h",
+		DisablePaste:   "\x1b[?2004l",
+		PasteStart:     "\x1b[200~",
+		PasteEnd:       "\x1b[201~",
+		EnableFocus:    "\x1b[?1004h",
+		DisableFocus:   "\x1b[?1004l",
+		FocusIn:        "\x1b[I",
+		FocusOut:       "\x1b[O",
+		KeyUp:          "\x1b[A",
+		KeyDown:        "\x1b[B",
+		KeyRight:       "\x1b[C",
+		KeyLeft:        "\x1b[D",
+		KeyInsert:      "\x1b[2~",
+		KeyDelete:      "\x1b[3~",
+		KeyBackspace:   "\u007f",
+		KeyHome:        "\x1b[7~",
+		KeyEnd:         "\x1b[8~",
+		KeyPgUp:        "\x1b[5~",
+		KeyPgDn:        "\x1b[6~",
+		KeyF1:          "\x1bOP",
+		KeyF2:          "\x1bOQ",
+		KeyF3:          "\x1bOR",
+		KeyF4:          "\x1bOS",
+		KeyF5:          "\x1b[15~",
+		KeyF6:          "\x1b[17~",
+		KeyF7:          "\x1b[18~",
+		KeyF8:          "\x1b[19~",
+		KeyF9:          "\x1b[20~",
+		KeyF10:         "\x1b[21~",
+		KeyF11:         "\x1b[23~",
+		KeyF12:         "\x1b[24~",
+		KeyF13:         "\x1b[25~",
+		KeyF14:         "\x1b[26~",
+		KeyF15:         "\x1b[28~",
+		KeyF16:         "\x1b[29~",
+		KeyF17:         "\x1b[31~",
+		KeyF18:         "\x1b[32~",
+		KeyF19:         "\x1b[33~",
+		KeyF20:         "\x1b[34~",
+		KeyF21:         "\x1b[23$",
+		KeyF22:         "\x1b[24$",
+		KeyF23:         "\x1b[11^",
+		KeyF24:         "\x1b[12^",
+		KeyF25:         "\x1b[13^",
+		KeyF26:         "\x1b[14^",
+		KeyF27:         "\x1b[15^",
+		KeyF28:         "\x1b[17^",
+		KeyF29:         "\x1b[18^",
+		KeyF30:         "\x1b[19^",
+		KeyF31:         "\x1b[20^",
+		KeyF32:         "\x1b[21^",
+		KeyF33:         "\x1b[23^",
+		KeyF34:         "\x1b[24^",
+		KeyF35:         "\x1b[25^",
+		KeyF36:         "\x1b[26^",
+		KeyF37:         "\x1b[28^",
+		KeyF38:         "\x1b[29^",
+		KeyF39:         "\x1b[31^",
+		KeyF40:         "\x1b[32^",
+		KeyF41:         "\x1b[33^",
+		KeyF42:         "\x1b[34^",
+		KeyF43:         "\x1b[23@",
+		KeyF44:         "\x1b[24@",
+		KeyBacktab:     "\x1b[Z",
+		KeyShfLeft:     "\x1b[d",
+		KeyShfRight:    "\x1b[c",
+		KeyShfUp:       "\x1b[a",
+		KeyShfDown:     "\x1b[b",
+		KeyCtrlLeft:    "\x1b[Od",
+		KeyCtrlRight:   "\x1b[Oc",
+		KeyCtrlUp:      "\x1b[Oa",
+		KeyCtrlDown:    "\x1b[Ob",
+		KeyShfHome:     "\x1b[7$",
+		KeyShfEnd:      "\x1b[8$",
+		KeyCtrlHome:    "\x1b[7^",
+		KeyCtrlEnd:     "\x1b[8^",
 	})
 	AddTerminfo(&Terminfo{
-		Name:         "beterm",
-		Columns:      80,
-		Lines:        25,
-		Colors:       8,
-		Bell:         "\a",
-		Clear:        "\x1b[H\x1b[J",
-		AttrOff:      "\x1b[0;10m",
-		Underline:    "\x1b[4m",
-		Bold:         "\x1b[1m",
-		Reverse:      "\x1b[7m",
-		EnterKeypad:  "\x1b[?4h",
-		ExitKeypad:   "\x1b[?4l",
-		SetFg:        "\x1b[3%p1%dm",
-		SetBg:        "\x1b[4%p1%dm",
-		SetFgBg:      "\x1b[3%p1%d;4%p2%dm",
-		PadChar:      "\x00",
-		SetCursor:    "\x1b[%i%p1%d;%p2%dH",
-		CursorBack1:  "\b",
-		CursorUp1:    "\x1b[A",
-		KeyUp:        "\x1b[A",
-		KeyDown:      "\x1b[B",
-		KeyRight:     "\x1b[C",
-		KeyLeft:      "\x1b[D",
-		KeyInsert:    "\x1b[2~",
-		KeyDelete:    "\x1b[3~",
-		KeyBackspace: "\b",
-		KeyHome:      "\x1b[1~",
-		KeyEnd:       "\x1b[4~",
-		KeyPgUp:      "\x1b[5~",
-		KeyPgDn:      "\x1b[6~",
-		KeyF1:        "\x1b[11~",
-		KeyF2:        "\x1b[12~",
-		KeyF3:        "\x1b[13~",
-		KeyF4:        "\x1b[14~",
-		KeyF5:        "\x1b[15~",
-		KeyF6:        "\x1b[16~",
-		KeyF7:        "\x1b[17~",
-		KeyF8:        "\x1b[18~",
-		KeyF9:        "\x1b[19~",
-		KeyF10:       "\x1b[20~",
-		KeyF11:       "\x1b[21~",
-		KeyF12:       "\x1b[22~",
+		Name:           "beterm",
+		Columns:        80,
+		Lines:          25,
+		Colors:         8,
+		Bell:           "\a",
+		Clear:          "\x1b[H\x1b[J",
+		AttrOff:        "\x1b[0;10m",
+		Underline:      "\x1b[4m",
+		Bold:           "\x1b[1m",
+		Reverse:        "\x1b[7m",
+		EnterKeypad:    "\x1b[?4h",
+		ExitKeypad:     "\x1b[?4l",
+		SetFg:          "\x1b[3%p1%dm",
+		SetBg:          "\x1b[4%p1%dm",
+		SetFgBg:        "\x1b[3%p1%d;4%p2%dm",
+		PadChar:        "\x00",
+		SetCursor:      "\x1b[%i%p1%d;%p2%dH",
+		CursorBack1:    "\b",
+		CursorUp1:      "\x1b[A",
+		CursorDown1:    "\n",
+		CursorForward1: "\x1b[C",
+		CursorBack:     "\x1b[%p1%dD",
+		CursorUp:       "\x1b[%p1%dA",
+		CursorDown:     "\x1b[%p1%dB",
+		CursorForward:  "\x1b[%p1%dC",
+		CursorHome:     "\x1b[H",
+		CarriageReturn: "\r",
+		ColumnAddress:  "\x1b[%i%p1%dG",
+		RowAddress:     "\x1b[%i%p1%dd",
+		ClearToEOL:     "\x1b[K",
+		EraseChars:     "\x1b[%p1%dX",
+		ExitUnderline:  "\x1b[24m",
+		ResetFgBg:      "\x1b[m",
+		KeyUp:          "\x1b[A",
+		KeyDown:        "\x1b[B",
+		KeyRight:       "\x1b[C",
+		KeyLeft:        "\x1b[D",
+		KeyInsert:      "\x1b[2~",
+		KeyDelete:      "\x1b[3~",
+		KeyBackspace:   "\b",
+		KeyHome:        "\x1b[1~",
+		KeyEnd:         "\x1b[4~",
+		KeyPgUp:        "\x1b[5~",
+		KeyPgDn:        "\x1b[6~",
+		KeyF1:          "\x1b[11~",
+		KeyF2:          "\x1b[12~",
+		KeyF3:          "\x1b[13~",
+		KeyF4:          "\x1b[14~",
+		KeyF5:          "\x1b[15~",
+		KeyF6:          "\x1b[16~",
+		KeyF7:          "\x1b[17~",
+		KeyF8:          "\x1b[18~",
+		KeyF9:          "\x1b[19~",
+		KeyF10:         "\x1b[20~",
+		KeyF11:         "\x1b[21~",
+		KeyF12:         "\x1b[22~",
 	})
 	AddTerminfo(&Terminfo{
-		Name:         "bsdos-pc",
-		Columns:      80,
-		Lines:        25,
-		Colors:       8,
-		Bell:         "\a",
-		Clear:        "\x1bc",
-		AttrOff:      "\x1b[0;10m",
-		Underline:    "\x1b[4m",
-		Bold:         "\x1b[1m",
-		Blink:        "\x1b[5m",
-		Reverse:      "\x1b[7m",
-		SetFg:        "\x1b[3%p1%dm",
-		SetBg:        "\x1b[4%p1%dm",
-		SetFgBg:      "\x1b[3%p1%d;4%p2%dm",
-		PadChar:      "\x00",
-		AltChars:     "+\x10,\x11-\x18.\x190\xdb`\x04a\xb1f\xf8g\xf1h\xb0j\xd9k\xbfl\xdam\xc0n\xc5o~p\xc4q\xc4r\xc4s_t\xc3u\xb4v\xc1w\xc2x\xb3y\xf3z\xf2{\xe3|\xd8}\x9c~\xfe",
-		EnterAcs:     "\x1b[11m",
-		ExitAcs:      "\x1b[10m",
-		SetCursor:    "\x1b[%i%p1%d;%p2%dH",
-		CursorBack1:  "\b",
-		CursorUp1:    "\x1b[A",
-		KeyUp:        "\x1b[A",
-		KeyDown:      "\x1b[B",
-		KeyRight:     "\x1b[C",
-		KeyLeft:      "\x1b[D",
-		KeyInsert:    "\x1b[L",
-		KeyBackspace: "\b",
-		KeyHome:      "\x1b[H",
-		KeyPgUp:      "\x1b[I",
-		KeyPgDn:      "\x1b[G",
+		Name:           "bsdos-pc",
+		Columns:        80,
+		Lines:          25,
+		Colors:         8,
+		Bell:           "\a",
+		Clear:          "\x1bc",
+		AttrOff:        "\x1b[0;10m",
+		Underline:      "\x1b[4m",
+		Bold:           "\x1b[1m",
+		Blink:          "\x1b[5m",
+		Reverse:        "\x1b[7m",
+		SetFg:          "\x1b[3%p1%dm",
+		SetBg:          "\x1b[4%p1%dm",
+		SetFgBg:        "\x1b[3%p1%d;4%p2%dm",
+		PadChar:        "\x00",
+		AltChars:       "+\x10,\x11-\x18.\x190\xdb`\x04a\xb1f\xf8g\xf1h\xb0j\xd9k\xbfl\xdam\xc0n\xc5o~p\xc4q\xc4r\xc4s_t\xc3u\xb4v\xc1w\xc2x\xb3y\xf3z\xf2{\xe3|\xd8}\x9c~\xfe",
+		EnterAcs:       "\x1b[11m",
+		ExitAcs:        "\x1b[10m",
+		SetCursor:      "\x1b[%i%p1%d;%p2%dH",
+		CursorBack1:    "\b",
+		CursorUp1:      "\x1b[A",
+		CursorDown1:    "\n",
+		CursorForward1: "\x1b[C",
+		CursorBack:     "\x1b[%p1%dD",
+		CursorUp:       "\x1b[%p1%dA",
+		CursorDown:     "\x1b[%p1%dB",
+		CursorForward:  "\x1b[%p1%dC",
+		CursorHome:     "\x1b[H",
+		CarriageReturn: "\r",
+		ClearToEOL:     "\x1b[K",
+		ExitUnderline:  "\x1b[m",
+		ResetFgBg:      "\x1b[37;40m",
+		KeyUp:          "\x1b[A",
+		KeyDown:        "\x1b[B",
+		KeyRight:       "\x1b[C",
+		KeyLeft:        "\x1b[D",
+		KeyInsert:      "\x1b[L",
+		KeyBackspace:   "\b",
+		KeyHome:        "\x1b[H",
+		KeyPgUp:        "\x1b[I",
+		KeyPgDn:        "\x1b[G",
 	})
 	AddTerminfo(&Terminfo{
-		Name:         "cygwin",
-		Columns:      -1,
-		Lines:        -1,
-		Colors:       8,
-		Bell:         "\a",
-		Clear:        "\x1b[H\x1b[J",
-		EnterCA:      "\x1b7\x1b[?47h",
-		ExitCA:       "\x1b[2J\x1b[?47l\x1b8",
-		AttrOff:      "\x1b[0;10m",
-		Underline:    "\x1b[4m",
-		Bold:         "\x1b[1m",
-		Reverse:      "\x1b[7m",
-		SetFg:        "\x1b[3%p1%dm",
-		SetBg:        "\x1b[4%p1%dm",
-		SetFgBg:      "\x1b[3%p1%d;4%p2%dm",
-		PadChar:      "\x00",
-		AltChars:     "+\x10,\x11-\x18.\x190\xdb`\x04a\xb1f\xf8g\xf1h\xb0j\xd9k\xbfl\xdam\xc0n\xc5o~p\xc4q\xc4r\xc4s_t\xc3u\xb4v\xc1w\xc2x\xb3y\xf3z\xf2{\xe3|\xd8}\x9c~\xfe",
-		EnterAcs:     "\x1b[11m",
-		ExitAcs:      "\x1b[10m",
-		SetCursor:    "\x1b[%i%p1%d;%p2%dH",
-		CursorBack1:  "\b",
-		CursorUp1:    "\x1b[A",
-		KeyUp:        "\x1b[A",
-		KeyDown:      "\x1b[B",
-		KeyRight:     "\x1b[C",
-		KeyLeft:      "\x1b[D",
-		KeyInsert:    "\x1b[2~",
-		KeyDelete:    "\x1b[3~",
-		KeyBackspace: "\b",
-		KeyHome:      "\x1b[1~",
-		KeyEnd:       "\x1b[4~",
-		KeyPgUp:      "\x1b[5~",
-		KeyPgDn:      "\x1b[6~",
-		KeyF1:        "\x1b[[A",
-		KeyF2:        "\x1b[[B",
-		KeyF3:        "\x1b[[C",
-		KeyF4:        "\x1b[[D",
-		KeyF5:        "\x1b[[E",
-		KeyF6:        "\x1b[17~",
-		KeyF7:        "\x1b[18~",
-		KeyF8:        "\x1b[19~",
-		KeyF9:        "\x1b[20~",
-		KeyF10:       "\x1b[21~",
-		KeyF11:       "\x1b[23~",
-		KeyF12:       "\x1b[24~",
-		KeyF13:       "\x1b[25~",
-		KeyF14:       "\x1b[26~",
-		KeyF15:       "\x1b[28~",
-		KeyF16:       "\x1b[29~",
-		KeyF17:       "\x1b[31~",
-		KeyF18:       "\x1b[32~",
-		KeyF19:       "\x1b[33~",
-		KeyF20:       "\x1b[34~",
+		Name:           "cygwin",
+		Columns:        -1,
+		Lines:          -1,
+		Colors:         8,
+		Bell:           "\a",
+		Clear:          "\x1b[H\x1b[J",
+		EnterCA:        "\x1b7\x1b[?47h",
+		ExitCA:         "\x1b[2J\x1b[?47l\x1b8",
+		AttrOff:        "\x1b[0;10m",
+		Underline:      "\x1b[4m",
+		Bold:           "\x1b[1m",
+		Reverse:        "\x1b[7m",
+		SetFg:          "\x1b[3%p1%dm",
+		SetBg:          "\x1b[4%p1%dm",
+		SetFgBg:        "\x1b[3%p1%d;4%p2%dm",
+		PadChar:        "\x00",
+		AltChars:       "+\x10,\x11-\x18.\x190\xdb`\x04a\xb1f\xf8g\xf1h\xb0j\xd9k\xbfl\xdam\xc0n\xc5o~p\xc4q\xc4r\xc4s_t\xc3u\xb4v\xc1w\xc2x\xb3y\xf3z\xf2{\xe3|\xd8}\x9c~\xfe",
+		EnterAcs:       "\x1b[11m",
+		ExitAcs:        "\x1b[10m",
+		SetCursor:      "\x1b[%i%p1%d;%p2%dH",
+		CursorBack1:    "\b",
+		CursorUp1:      "\x1b[A",
+		CursorDown1:    "\x1b[B",
+		CursorForward1: "\x1b[C",
+		CursorBack:     "\x1b[%p1%dD",
+		CursorUp:       "\x1b[%p1%dA",
+		CursorDown:     "\x1b[%p1%dB",
+		CursorForward:  "\x1b[%p1%dC",
+		CursorHome:     "\x1b[H",
+		CarriageReturn: "\r",
+		ColumnAddress:  "\x1b[%i%p1%dG",
+		RowAddress:     "\x1b[%i%p1%dd",
+		ClearToEOL:     "\x1b[K",
+		ExitUnderline:  "\x1b[24m",
+		ResetFgBg:      "\x1b[39;49m",
+		TitleStart:     "\x1b];",
+		TitleEnd:       "\a",
+		KeyUp:          "\x1b[A",
+		KeyDown:        "\x1b[B",
+		KeyRight:       "\x1b[C",
+		KeyLeft:        "\x1b[D",
+		KeyInsert:      "\x1b[2~",
+		KeyDelete:      "\x1b[3~",
+		KeyBackspace:   "\b",
+		KeyHome:        "\x1b[1~",
+		KeyEnd:         "\x1b[4~",
+		KeyPgUp:        "\x1b[5~",
+		KeyPgDn:        "\x1b[6~",
+		KeyF1:          "\x1b[[A",
+		KeyF2:          "\x1b[[B",
+		KeyF3:          "\x1b[[C",
+		KeyF4:          "\x1b[[D",
+		KeyF5:          "\x1b[[E",
+		KeyF6:          "\x1b[17~",
+		KeyF7:          "\x1b[18~",
+		KeyF8:          "\x1b[19~",
+		KeyF9:          "\x1b[20~",
+		KeyF10:         "\x1b[21~",
+		KeyF11:         "\x1b[23~",
+		KeyF12:         "\x1b[24~",
+		KeyF13:         "\x1b[25~",
+		KeyF14:         "\x1b[26~",
+		KeyF15:         "\x1b[28~",
+		KeyF16:         "\x1b[29~",
+		KeyF17:         "\x1b[31~",
+		KeyF18:         "\x1b[32~",
+		KeyF19:         "\x1b[33~",
+		KeyF20:         "\x1b[34~",
 	})
 	AddTerminfo(&Terminfo{
-		Name:        "d200",
-		Aliases:     []string{"d200-dg"},
-		Columns:     80,
-		Lines:       24,
-		Bell:        "\a",
-		Clear:       "\f",
-		AttrOff:     "\x0f\x15\x1d\x1eE",
-		Underline:   "\x14",
-		Bold:        "\x1eD\x14",
-		Dim:         "\x1c",
-		Blink:       "\x0e",
-		Reverse:     "\x1eD",
-		PadChar:     "\x00",
-		SetCursor:   "\x10%p2%c%p1%c",
-		CursorBack1: "\x19",
-		CursorUp1:   "\x17",
-		KeyUp:       "\x17",
-		KeyDown:     "\x1a",
-		KeyRight:    "\x18",
-		KeyLeft:     "\x19",
-		KeyHome:     "\b",
-		KeyF1:       "\x1eq",
-		KeyF2:       "\x1er",
-		KeyF3:       "\x1es",
-		KeyF4:       "\x1et",
-		KeyF5:       "\x1eu",
-		KeyF6:       "\x1ev",
-		KeyF7:       "\x1ew",
-		KeyF8:       "\x1ex",
-		KeyF9:       "\x1ey",
-		KeyF10:      "\x1ez",
-		KeyF11:      "\x1e{",
-		KeyF12:      "\x1e|",
-		KeyF13:      "\x1e}",
-		KeyF14:      "\x1e~",
-		KeyF15:      "\x1ep",
-		KeyF16:      "\x1ea",
-		KeyF17:      "\x1eb",
-		KeyF18:      "\x1ec",
-		KeyF19:      "\x1ed",
-		KeyF20:      "\x1ee",
-		KeyF21:      "\x1ef",
-		KeyF22:      "\x1eg",
-		KeyF23:      "\x1eh",
-		KeyF24:      "\x1ei",
-		KeyF25:      "\x1ej",
-		KeyF26:      "\x1ek",
-		KeyF27:      "\x1el",
-		KeyF28:      "\x1em",
-		KeyF29:      "\x1en",
-		KeyF30:      "\x1e`",
-		KeyF31:      "\x1e1",
-		KeyF32:      "\x1e2",
-		KeyF33:      "\x1e3",
-		KeyF34:      "\x1e4",
-		KeyF35:      "\x1e5",
-		KeyF36:      "\x1e6",
-		KeyF37:      "\x1e7",
-		KeyF38:      "\x1e8",
-		KeyF39:      "\x1e9",
-		KeyF40:      "\x1e:",
-		KeyF41:      "\x1e;",
-		KeyF42:      "\x1e<",
-		KeyF43:      "\x1e=",
-		KeyF44:      "\x1e>",
-		KeyF45:      "\x1e0",
-		KeyF46:      "\x1e!",
-		KeyF47:      "\x1e\"",
-		KeyF48:      "\x1e#",
-		KeyF49:      "\x1e$",
-		KeyF50:      "\x1e%%",
-		KeyF51:      "\x1e&",
-		KeyF52:      "\x1e'",
-		KeyF53:      "\x1e(",
-		KeyF54:      "\x1e)",
-		KeyF55:      "\x1e*",
-		KeyF56:      "\x1e+",
-		KeyF57:      "\x1e,",
-		KeyF58:      "\x1e-",
-		KeyF59:      "\x1e.",
-		KeyF60:      "\x1e ",
-		KeyClear:    "\f",
-		KeyShfLeft:  "\x1e\x19",
-		KeyShfRight: "\x1e\x18",
-		KeyShfHome:  "\x1e\b",
+		Name:           "d200",
+		Aliases:        []string{"d200-dg"},
+		Columns:        80,
+		Lines:          24,
+		Bell:           "\a",
+		Clear:          "\f",
+		AttrOff:        "\x0f\x15\x1d\x1eE",
+		Underline:      "\x14",
+		Bold:           "\x1eD\x14",
+		Dim:            "\x1c",
+		Blink:          "\x0e",
+		Reverse:        "\x1eD",
+		PadChar:        "\x00",
+		SetCursor:      "\x10%p2%c%p1%c",
+		CursorBack1:    "\x19",
+		CursorUp1:      "\x17",
+		CursorDown1:    "\x1a",
+		CursorForward1: "\x18",
+		CarriageReturn: "\r",
+		ClearToEOL:     "\v",
+		ExitUnderline:  "\x15",
+		KeyUp:          "\x17",
+		KeyDown:        "\x1a",
+		KeyRight:       "\x18",
+		KeyLeft:        "\x19",
+		KeyHome:        "\b",
+		KeyF1:          "\x1eq",
+		KeyF2:          "\x1er",
+		KeyF3:          "\x1es",
+		KeyF4:          "\x1et",
+		KeyF5:          "\x1eu",
+		KeyF6:          "\x1ev",
+		KeyF7:          "\x1ew",
+		KeyF8:          "\x1ex",
+		KeyF9:          "\x1ey",
+		KeyF10:         "\x1ez",
+		KeyF11:         "\x1e{",
+		KeyF12:         "\x1e|",
+		KeyF13:         "\x1e}",
+		KeyF14:         "\x1e~",
+		KeyF15:         "\x1ep",
+		KeyF16:         "\x1ea",
+		KeyF17:         "\x1eb",
+		KeyF18:         "\x1ec",
+		KeyF19:         "\x1ed",
+		KeyF20:         "\x1ee",
+		KeyF21:         "\x1ef",
+		KeyF22:         "\x1eg",
+		KeyF23:         "\x1eh",
+		KeyF24:         "\x1ei",
+		KeyF25:         "\x1ej",
+		KeyF26:         "\x1ek",
+		KeyF27:         "\x1el",
+		KeyF28:         "\x1em",
+		KeyF29:         "\x1en",
+		KeyF30:         "\x1e`",
+		KeyF31:         "\x1e1",
+		KeyF32:         "\x1e2",
+		KeyF33:         "\x1e3",
+		KeyF34:         "\x1e4",
+		KeyF35:         "\x1e5",
+		KeyF36:         "\x1e6",
+		KeyF37:         "\x1e7",
+		KeyF38:         "\x1e8",
+		KeyF39:         "\x1e9",
+		KeyF40:         "\x1e:",
+		KeyF41:         "\x1e;",
+		KeyF42:         "\x1e<",
+		KeyF43:         "\x1e=",
+		KeyF44:         "\x1e>",
+		KeyF45:         "\x1e0",
+		KeyF46:         "\x1e!",
+		KeyF47:         "\x1e\"",
+		KeyF48:         "\x1e#",
+		KeyF49:         "\x1e$",
+		KeyF50:         "\x1e%%",
+		KeyF51:         "\x1e&",
+		KeyF52:         "\x1e'",
+		KeyF53:         "\x1e(",
+		KeyF54:         "\x1e)",
+		KeyF55:         "\x1e*",
+		KeyF56:         "\x1e+",
+		KeyF57:         "\x1e,",
+		KeyF58:         "\x1e-",
+		KeyF59:         "\x1e.",
+		KeyF60:         "\x1e ",
+		KeyClear:       "\f",
+		KeyShfLeft:     "\x1e\x19",
+		KeyShfRight:    "\x1e\x18",
+		KeyShfHome:     "\x1e\b",
 	})
 	AddTerminfo(&Terminfo{
-		Name:        "d210",
-		Aliases:     []string{"d214"},
-		Columns:     80,
-		Lines:       24,
-		Bell:        "\a",
-		Clear:       "\x1b[2J",
-		AttrOff:     "\x1b[m",
-		Underline:   "\x1b[4m",
-		Bold:        "\x1b[4;7m",
-		Dim:         "\x1b[2m",
-		Blink:       "\x1b[5m",
-		Reverse:     "\x1b[7m",
-		PadChar:     "\x00",
-		SetCursor:   "\x1b[%i%p1%d;%p2%dH",
-		CursorBack1: "\b",
-		CursorUp1:   "\x1b[A",
-		KeyUp:       "\x1b[A",
-		KeyDown:     "\x1b[B",
-		KeyRight:    "\x1b[C",
-		KeyLeft:     "\x1b[D",
-		KeyHome:     "\x1b[H",
-		KeyF1:       "\x1b[001z",
-		KeyF2:       "\x1b[002z",
-		KeyF3:       "\x1b[003z",
-		KeyF4:       "\x1b[004z",
-		KeyF5:       "\x1b[005z",
-		KeyF6:       "\x1b[006z",
-		KeyF7:       "\x1b[007z",
-		KeyF8:       "\x1b[008z",
-		KeyF9:       "\x1b[009z",
-		KeyF10:      "\x1b[010z",
-		KeyF11:      "\x1b[011z",
-		KeyF12:      "\x1b[012z",
-		KeyF13:      "\x1b[013z",
-		KeyF14:      "\x1b[014z",
-		KeyF15:      "\x1b[000z",
-		KeyF16:      "\x1b[101z",
-		KeyF17:      "\x1b[102z",
-		KeyF18:      "\x1b[103z",
-		KeyF19:      "\x1b[104z",
-		KeyF20:      "\x1b[105z",
-		KeyF21:      "\x1b[106z",
-		KeyF22:      "\x1b[107z",
-		KeyF23:      "\x1b[108z",
-		KeyF24:      "\x1b[109z",
-		KeyF25:      "\x1b[110z",
-		KeyF26:      "\x1b[111z",
-		KeyF27:      "\x1b[112z",
-		KeyF28:      "\x1b[113z",
-		KeyF29:      "\x1b[114z",
-		KeyF30:      "\x1b[100z",
-		KeyF31:      "\x1b[201z",
-		KeyF32:      "\x1b[202z",
-		KeyF33:      "\x1b[203z",
-		KeyF34:      "\x1b[204z",
-		KeyF35:      "\x1b[205z",
-		KeyF36:      "\x1b[206z",
-		KeyF37:      "\x1b[207z",
-		KeyF38:      "\x1b[208z",
-		KeyF39:      "\x1b[209z",
-		KeyF40:      "\x1b[210z",
-		KeyF41:      "\x1b[211z",
-		KeyF42:      "\x1b[212z",
-		KeyF43:      "\x1b[213z",
-		KeyF44:      "\x1b[214z",
-		KeyF45:      "\x1b[200z",
-		KeyF46:      "\x1b[301z",
-		KeyF47:      "\x1b[302z",
-		KeyF48:      "\x1b[303z",
-		KeyF49:      "\x1b[304z",
-		KeyF50:      "\x1b[305z",
-		KeyF51:      "\x1b[306z",
-		KeyF52:      "\x1b[307z",
-		KeyF53:      "\x1b[308z",
-		KeyF54:      "\x1b[309z",
-		KeyF55:      "\x1b[310z",
-		KeyF56:      "\x1b[311z",
-		KeyF57:      "\x1b[312z",
-		KeyF58:      "\x1b[313z",
-		KeyF59:      "\x1b[314z",
-		KeyF60:      "\x1b[300z",
-		KeyPrint:    "\x1b[i",
-		KeyClear:    "\x1b[2J",
+		Name:           "d210",
+		Aliases:        []string{"d214"},
+		Columns:        80,
+		Lines:          24,
+		Bell:           "\a",
+		Clear:          "\x1b[2J",
+		AttrOff:        "\x1b[m",
+		Underline:      "\x1b[4m",
+		Bold:           "\x1b[4;7m",
+		Dim:            "\x1b[2m",
+		Blink:          "\x1b[5m",
+		Reverse:        "\x1b[7m",
+		PadChar:        "\x00",
+		SetCursor:      "\x1b[%i%p1%d;%p2%dH",
+		CursorBack1:    "\b",
+		CursorUp1:      "\x1b[A",
+		CursorDown1:    "\x1b[B",
+		CursorForward1: "\x1b[C",
+		CursorBack:     "\x1b[%p1%dD",
+		CursorUp:       "\x1b[%p1%dA",
+		CursorDown:     "\x1b[%p1%dB",
+		CursorForward:  "\x1b[%p1%dC",
+		CursorHome:     "\x1b[H",
+		CarriageReturn: "\r",
+		ClearToEOL:     "\x1b[K",
+		ExitUnderline:  "\x1b[m",
+		KeyUp:          "\x1b[A",
+		KeyDown:        "\x1b[B",
+		KeyRight:       "\x1b[C",
+		KeyLeft:        "\x1b[D",
+		KeyHome:        "\x1b[H",
+		KeyF1:          "\x1b[001z",
+		KeyF2:          "\x1b[002z",
+		KeyF3:          "\x1b[003z",
+		KeyF4:          "\x1b[004z",
+		KeyF5:          "\x1b[005z",
+		KeyF6:          "\x1b[006z",
+		KeyF7:          "\x1b[007z",
+		KeyF8:          "\x1b[008z",
+		KeyF9:          "\x1b[009z",
+		KeyF10:         "\x1b[010z",
+		KeyF11:         "\x1b[011z",
+		KeyF12:         "\x1b[012z",
+		KeyF13:         "\x1b[013z",
+		KeyF14:         "\x1b[014z",
+		KeyF15:         "\x1b[000z",
+		KeyF16:         "\x1b[101z",
+		KeyF17:         "\x1b[102z",
+		KeyF18:         "\x1b[103z",
+		KeyF19:         "\x1b[104z",
+		KeyF20:         "\x1b[105z",
+		KeyF21:         "\x1b[106z",
+		KeyF22:         "\x1b[107z",
+		KeyF23:         "\x1b[108z",
+		KeyF24:         "\x1b[109z",
+		KeyF25:         "\x1b[110z",
+		KeyF26:         "\x1b[111z",
+		KeyF27:         "\x1b[112z",
+		KeyF28:         "\x1b[113z",
+		KeyF29:         "\x1b[114z",
+		KeyF30:         "\x1b[100z",
+		KeyF31:         "\x1b[201z",
+		KeyF32:         "\x1b[202z",
+		KeyF33:         "\x1b[203z",
+		KeyF34:         "\x1b[204z",
+		KeyF35:         "\x1b[205z",
+		KeyF36:         "\x1b[206z",
+		KeyF37:         "\x1b[207z",
+		KeyF38:         "\x1b[208z",
+		KeyF39:         "\x1b[209z",
+		KeyF40:         "\x1b[210z",
+		KeyF41:         "\x1b[211z",
+		KeyF42:         "\x1b[212z",
+		KeyF43:         "\x1b[213z",
+		KeyF44:         "\x1b[214z",
+		KeyF45:         "\x1b[200z",
+		KeyF46:         "\x1b[301z",
+		KeyF47:         "\x1b[302z",
+		KeyF48:         "\x1b[303z",
+		KeyF49:         "\x1b[304z",
+		KeyF50:         "\x1b[305z",
+		KeyF51:         "\x1b[306z",
+		KeyF52:         "\x1b[307z",
+		KeyF53:         "\x1b[308z",
+		KeyF54:         "\x1b[309z",
+		KeyF55:         "\x1b[310z",
+		KeyF56:         "\x1b[311z",
+		KeyF57:         "\x1b[312z",
+		KeyF58:         "\x1b[313z",
+		KeyF59:         "\x1b[314z",
+		KeyF60:         "\x1b[300z",
+		KeyPrint:       "\x1b[i",
+		KeyClear:       "\x1b[2J",
 	})
 	AddTerminfo(&Terminfo{
-		Name:         "dtterm",
-		Columns:      80,
-		Lines:        24,
-		Colors:       8,
-		Bell:         "\a",
-		Clear:        "\x1b[H\x1b[J",
-		ShowCursor:   "\x1b[?25h",
-		HideCursor:   "\x1b[?25l",
-		AttrOff:      "\x1b[m\x0f",
-		Underline:    "\x1b[4m",
-		Bold:         "\x1b[1m",
-		Dim:          "\x1b[2m",
-		Blink:        "\x1b[5m",
-		Reverse:      "\x1b[7m",
-		SetFg:        "\x1b[3%p1%dm",
-		SetBg:        "\x1b[4%p1%dm",
-		SetFgBg:      "\x1b[3%p1%d;4%p2%dm",
-		PadChar:      "\x00",
-		AltChars:     "``aaffggjjkkllmmnnooppqqrrssttuuvvwwxxyyzz{{||}}~~",
-		EnterAcs:     "\x0e",
-		ExitAcs:      "\x0f",
-		EnableAcs:    "\x1b(B\x1b)0",
-		SetCursor:    "\x1b[%i%p1%d;%p2%dH",
-		CursorBack1:  "\b",
-		CursorUp1:    "\x1b[A",
-		KeyUp:        "\x1b[A",
-		KeyDown:      "\x1b[B",
-		KeyRight:     "\x1b[C",
-		KeyLeft:      "\x1b[D",
-		KeyInsert:    "\x1b[2~",
-		KeyDelete:    "\x1b[3~",
-		KeyBackspace: "\b",
-		KeyPgUp:      "\x1b[5~",
-		KeyPgDn:      "\x1b[6~",
-		KeyF1:        "\x1b[11~",
-		KeyF2:        "\x1b[12~",
-		KeyF3:        "\x1b[13~",
-		KeyF4:        "\x1b[14~",
-		KeyF5:        "\x1b[15~",
-		KeyF6:        "\x1b[17~",
-		KeyF7:        "\x1b[18~",
-		KeyF8:        "\x1b[19~",
-		KeyF9:        "\x1b[20~",
-		KeyF10:       "\x1b[21~",
-		KeyF11:       "\x1b[23~",
-		KeyF12:       "\x1b[24~",
-		KeyF13:       "\x1b[25~",
-		KeyF14:       "\x1b[26~",
-		KeyF15:       "\x1b[28~",
-		KeyF16:       "\x1b[29~",
-		KeyF17:       "\x1b[31~",
-		KeyF18:       "\x1b[32~",
-		KeyF19:       "\x1b[33~",
-		KeyF20:       "\x1b[34~",
-		KeyHelp:      "\x1b[28~",
+		Name:           "dtterm",
+		Columns:        80,
+		Lines:          24,
+		Colors:         8,
+		Bell:           "\a",
+		Flash:          "\x1b[?5h$<200>\x1b[?5l",
+		Clear:          "\x1b[H\x1b[J",
+		ShowCursor:     "\x1b[?25h",
+		HideCursor:     "\x1b[?25l",
+		AttrOff:        "\x1b[m\x0f",
+		Underline:      "\x1b[4m",
+		Bold:           "\x1b[1m",
+		Dim:            "\x1b[2m",
+		Blink:          "\x1b[5m",
+		Reverse:        "\x1b[7m",
+		SetFg:          "\x1b[3%p1%dm",
+		SetBg:          "\x1b[4%p1%dm",
+		SetFgBg:        "\x1b[3%p1%d;4%p2%dm",
+		PadChar:        "\x00",
+		AltChars:       "``aaffggjjkkllmmnnooppqqrrssttuuvvwwxxyyzz{{||}}~~",
+		EnterAcs:       "\x0e",
+		ExitAcs:        "\x0f",
+		EnableAcs:      "\x1b(B\x1b)0",
+		SetCursor:      "\x1b[%i%p1%d;%p2%dH",
+		CursorBack1:    "\b",
+		CursorUp1:      "\x1b[A",
+		CursorDown1:    "\n",
+		CursorForward1: "\x1b[C",
+		CursorBack:     "\x1b[%p1%dD",
+		CursorUp:       "\x1b[%p1%dA",
+		CursorDown:     "\x1b[%p1%dB",
+		CursorForward:  "\x1b[%p1%dC",
+		CursorHome:     "\x1b[H",
+		CarriageReturn: "\r",
+		ClearToEOL:     "\x1b[K",
+		EraseChars:     "\x1b[%p1%dX",
+		ExitUnderline:  "\x1b[24m",
+		ResetFgBg:      "\x1b[39;49m",
+		KeyUp:          "\x1b[A",
+		KeyDown:        "\x1b[B",
+		KeyRight:       "\x1b[C",
+		KeyLeft:        "\x1b[D",
+		KeyInsert:      "\x1b[2~",
+		KeyDelete:      "\x1b[3~",
+		KeyBackspace:   "\b",
+		KeyPgUp:        "\x1b[5~",
+		KeyPgDn:        "\x1b[6~",
+		KeyF1:          "\x1b[11~",
+		KeyF2:          "\x1b[12~",
+		KeyF3:          "\x1b[13~",
+		KeyF4:          "\x1b[14~",
+		KeyF5:          "\x1b[15~",
+		KeyF6:          "\x1b[17~",
+		KeyF7:          "\x1b[18~",
+		KeyF8:          "\x1b[19~",
+		KeyF9:          "\x1b[20~",
+		KeyF10:         "\x1b[21~",
+		KeyF11:         "\x1b[23~",
+		KeyF12:         "\x1b[24~",
+		KeyF13:         "\x1b[25~",
+		KeyF14:         "\x1b[26~",
+		KeyF15:         "\x1b[28~",
+		KeyF16:         "\x1b[29~",
+		KeyF17:         "\x1b[31~",
+		KeyF18:         "\x1b[32~",
+		KeyF19:         "\x1b[33~",
+		KeyF20:         "\x1b[34~",
+		KeyHelp:        "\x1b[28~",
 	})
 	AddTerminfo(&Terminfo{
-		Name:         "Eterm",
-		Aliases:      []string{"Eterm-color"},
-		Columns:      80,
-		Lines:        24,
-		Colors:       8,
-		Bell:         "\a",
-		Clear:        "\x1b[H\x1b[2J",
-		EnterCA:      "\x1b7\x1b[?47h",
-		ExitCA:       "\x1b[2J\x1b[?47l\x1b8",
-		ShowCursor:   "\x1b[?25h",
-		HideCursor:   "\x1b[?25l",
-		AttrOff:      "\x1b[m\x0f",
-		Underline:    "\x1b[4m",
-		Bold:         "\x1b[1m",
-		Blink:        "\x1b[5m",
-		Reverse:      "\x1b[7m",
-		SetFg:        "\x1b[3%p1%dm",
-		SetBg:        "\x1b[4%p1%dm",
-		SetFgBg:      "\x1b[3%p1%d;4%p2%dm",
-		PadChar:      "\x00",
-		AltChars:     "``aaffggjjkkllmmnnooppqqrrssttuuvvwwxxyyzz{{||}}~~",
-		EnterAcs:     "\x0e",
-		ExitAcs:      "\x0f",
-		EnableAcs:    "\x1b)0",
-		Mouse:        "\x1b[M",
-		MouseMode:    "%?%p1%{1}%=%t%'h'%Pa%e%'l'%Pa%;\x1b[?1000%ga%c\x1b[?1002%ga%c\x1b[?1003%ga%c\x1b[?1006%ga%c",
-		SetCursor:    "\x1b[%i%p1%d;%p2%dH",
-		CursorBack1:  "\b",
-		CursorUp1:    "\x1b[A",
-		KeyUp:        "\x1b[A",
-		KeyDown:      "\x1b[B",
-		KeyRight:     "\x1b[C",
-		KeyLeft:      "\x1b[D",
-		KeyInsert:    "\x1b[2~",
-		KeyDelete:    "\x1b[3~",
-		KeyBackspace: "\b",
-		KeyHome:      "\x1b[7~",
-		KeyEnd:       "\x1b[8~",
-		KeyPgUp:      "\x1b[5~",
-		KeyPgDn:      "\x1b[6~",
-		KeyF1:        "\x1b[11~",
-		KeyF2:        "\x1b[12~",
-		KeyF3:        "\x1b[13~",
-		KeyF4:        "\x1b[14~",
-		KeyF5:        "\x1b[15~",
-		KeyF6:        "\x1b[17~",
-		KeyF7:        "\x1b[18~",
-		KeyF8:        "\x1b[19~",
-		KeyF9:        "\x1b[20~",
-		KeyF10:       "\x1b[21~",
-		KeyF11:       "\x1b[23~",
-		KeyF12:       "\x1b[24~",
-		KeyF13:       "\x1b[25~",
-		KeyF14:       "\x1b[26~",
-		KeyF15:       "\x1b[28~",
-		KeyF16:       "\x1b[29~",
-		KeyF17:       "\x1b[31~",
-		KeyF18:       "\x1b[32~",
-		KeyF19:       "\x1b[33~",
-		KeyF20:       "\x1b[34~",
-		KeyF21:       "\x1b[23$",
-		KeyF22:       "\x1b[24$",
-		KeyF23:       "\x1b[11^",
-		KeyF24:       "\x1b[12^",
-		KeyF25:       "\x1b[13^",
-		KeyF26:       "\x1b[14^",
-		KeyF27:       "\x1b[15^",
-		KeyF28:       "\x1b[17^",
-		KeyF29:       "\x1b[18^",
-		KeyF30:       "\x1b[19^",
-		KeyF31:       "\x1b[20^",
-		KeyF32:       "\x1b[21^",
-		KeyF33:       "\x1b[23^",
-		KeyF34:       "\x1b[24^",
-		KeyF35:       "\x1b[25^",
-		KeyF36:       "\x1b[26^",
-		KeyF37:       "\x1b[28^",
-		KeyF38:       "\x1b[29^",
-		KeyF39:       "\x1b[31^",
-		KeyF40:       "\x1b[32^",
-		KeyF41:       "\x1b[33^",
-		KeyF42:       "\x1b[34^",
-		KeyF43:       "\x1b[23@",
-		KeyF44:       "\x1b[24@",
-		KeyHelp:      "\x1b[28~",
-		KeyShfLeft:   "\x1b[d",
-		KeyShfRight:  "\x1b[c",
-		KeyShfUp:     "\x1b[a",
-		KeyShfDown:   "\x1b[b",
-		KeyCtrlLeft:  "\x1b[Od",
-		KeyCtrlRight: "\x1b[Oc",
-		KeyCtrlUp:    "\x1b[Oa",
-		KeyCtrlDown:  "\x1b[Ob",
-		KeyShfHome:   "\x1b[7$",
-		KeyShfEnd:    "\x1b[8$",
-		KeyCtrlHome:  "\x1b[7^",
-		KeyCtrlEnd:   "\x1b[8^",
+		Name:           "Eterm",
+		Aliases:        []string{"Eterm-color"},
+		Columns:        80,
+		Lines:          24,
+		Colors:         8,
+		Bell:           "\a",
+		Clear:          "\x1b[H\x1b[2J",
+		EnterCA:        "\x1b7\x1b[?47h",
+		ExitCA:         "\x1b[2J\x1b[?47l\x1b8",
+		ShowCursor:     "\x1b[?25h",
+		HideCursor:     "\x1b[?25l",
+		AttrOff:        "\x1b[m\x0f",
+		Underline:      "\x1b[4m",
+		Bold:           "\x1b[1m",
+		Blink:          "\x1b[5m",
+		Reverse:        "\x1b[7m",
+		SetFg:          "\x1b[3%p1%dm",
+		SetBg:          "\x1b[4%p1%dm",
+		SetFgBg:        "\x1b[3%p1%d;4%p2%dm",
+		PadChar:        "\x00",
+		AltChars:       "``aaffggjjkkllmmnnooppqqrrssttuuvvwwxxyyzz{{||}}~~",
+		EnterAcs:       "\x0e",
+		ExitAcs:        "\x0f",
+		EnableAcs:      "\x1b)0",
+		Mouse:          "\x1b[M",
+		MouseMode:      "%?%p1%{1}%=%t%'h'%Pa%e%'l'%Pa%;\x1b[?1000%ga%c\x1b[?1002%ga%c\x1b[?1003%ga%c\x1b[?1006%ga%c",
+		SetCursor:      "\x1b[%i%p1%d;%p2%dH",
+		CursorBack1:    "\b",
+		CursorUp1:      "\x1b[A",
+		CursorDown1:    "\x1b[B",
+		CursorForward1: "\x1b[C",
+		CursorBack:     "\x1b[%p1%dD",
+		CursorUp:       "\x1b[%p1%dA",
+		CursorDown:     "\x1b[%p1%dB",
+		CursorForward:  "\x1b[%p1%dC",
+		CursorHome:     "\x1b[H",
+		CarriageReturn: "\r",
+		ColumnAddress:  "\x1b[%i%p1%dG",
+		RowAddress:     "\x1b[%i%p1%dd",
+		ClearToEOL:     "\x1b[K",
+		EraseChars:     "\x1b[%p1%dX",
+		BackColorErase: true,
+		ExitUnderline:  "\x1b[24m",
+		ResetFgBg:      "\x1b[39;49m",
+		EnablePaste:    "\x1b[?2004h",
+		DisablePaste:   "\x1b[?2004l",
+		PasteStart:     "\x1b[200~",
+		PasteEnd:       "\x1b[201~",
+		EnableFocus:    "\x1b[?1004h",
+		DisableFocus:   "\x1b[?1004l",
+		FocusIn:        "\x1b[I",
+		FocusOut:       "\x1b[O",
+		KeyUp:          "\x1b[A",
+		KeyDown:        "\x1b[B",
+		KeyRight:       "\x1b[C",
+		KeyLeft:        "\x1b[D",
+		KeyInsert:      "\x1b[2~",
+		KeyDelete:      "\x1b[3~",
+		KeyBackspace:   "\b",
+		KeyHome:        "\x1b[7~",
+		KeyEnd:         "\x1b[8~",
+		KeyPgUp:        "\x1b[5~",
+		KeyPgDn:        "\x1b[6~",
+		KeyF1:          "\x1b[11~",
+		KeyF2:          "\x1b[12~",
+		KeyF3:          "\x1b[13~",
+		KeyF4:          "\x1b[14~",
+		KeyF5:          "\x1b[15~",
+		KeyF6:          "\x1b[17~",
+		KeyF7:          "\x1b[18~",
+		KeyF8:          "\x1b[19~",
+		KeyF9:          "\x1b[20~",
+		KeyF10:         "\x1b[21~",
+		KeyF11:         "\x1b[23~",
+		KeyF12:         "\x1b[24~",
+		KeyF13:         "\x1b[25~",
+		KeyF14:         "\x1b[26~",
+		KeyF15:         "\x1b[28~",
+		KeyF16:         "\x1b[29~",
+		KeyF17:         "\x1b[31~",
+		KeyF18:         "\x1b[32~",
+		KeyF19:         "\x1b[33~",
+		KeyF20:         "\x1b[34~",
+		KeyF21:         "\x1b[23$",
+		KeyF22:         "\x1b[24$",
+		KeyF23:         "\x1b[11^",
+		KeyF24:         "\x1b[12^",
+		KeyF25:         "\x1b[13^",
+		KeyF26:         "\x1b[14^",
+		KeyF27:         "\x1b[15^",
+		KeyF28:         "\x1b[17^",
+		KeyF29:         "\x1b[18^",
+		KeyF30:         "\x1b[19^",
+		KeyF31:         "\x1b[20^",
+		KeyF32:         "\x1b[21^",
+		KeyF33:         "\x1b[23^",
+		KeyF34:         "\x1b[24^",
+		KeyF35:         "\x1b[25^",
+		KeyF36:         "\x1b[26^",
+		KeyF37:         "\x1b[28^",
+		KeyF38:         "\x1b[29^",
+		KeyF39:         "\x1b[31^",
+		KeyF40:         "\x1b[32^",
+		KeyF41:         "\x1b[33^",
+		KeyF42:         "\x1b[34^",
+		KeyF43:         "\x1b[23@",
+		KeyF44:         "\x1b[24@",
+		KeyHelp:        "\x1b[28~",
+		KeyShfLeft:     "\x1b[d",
+		KeyShfRight:    "\x1b[c",
+		KeyShfUp:       "\x1b[a",
+		KeyShfDown:     "\x1b[b",
+		KeyCtrlLeft:    "\x1b[Od",
+		KeyCtrlRight:   "\x1b[Oc",
+		KeyCtrlUp:      "\x1b[Oa",
+		KeyCtrlDown:    "\x1b[Ob",
+		KeyShfHome:     "\x1b[7$",
+		KeyShfEnd:      "\x1b[8$",
+		KeyCtrlHome:    "\x1b[7^",
+		KeyCtrlEnd:     "\x1b[8^",
 	})
 	AddTerminfo(&Terminfo{
-		Name:         "Eterm-256color",
-		Columns:      80,
-		Lines:        24,
-		Colors:       256,
-		Bell:         "\a",
-		Clear:        "\x1b[H\x1b[2J",
-		EnterCA:      "\x1b7\x1b[?47h",
-		ExitCA:       "\x1b[2J\x1b[?47l\x1b8",
-		ShowCursor:   "\x1b[?25h",
-		HideCursor:   "\x1b[?25l",
-		AttrOff:      "\x1b[m\x0f",
-		Underline:    "\x1b[4m",
-		Bold:         "\x1b[1m",
-		Blink:        "\x1b[5m",
-		Reverse:      "\x1b[7m",
-		SetFg:        "\x1b[%?%p1%{8}%<%t3%p1%d%e%p1%{16}%<%t9%p1%{8}%-%d%e38;5;%p1%d%;m",
-		SetBg:        "\x1b[%?%p1%{8}%<%t4%p1%d%e%p1%{16}%<%t10%p1%{8}%-%d%e48;5;%p1%d%;m",
-		SetFgBg:      "\x1b[%?%p1%{8}%<%t3%p1%d%e%p1%{16}%<%t9%p1%{8}%-%d%e38;5;%p1%d%;;%?%p2%{8}%<%t4%p2%d%e%p2%{16}%<%t10%p2%{8}%-%d%e48;5;%p2%d%;m",
-		PadChar:      "\x00",
-		AltChars:     "``aaffggjjkkllmmnnooppqqrrssttuuvvwwxxyyzz{{||}}~~",
-		EnterAcs:     "\x0e",
-		ExitAcs:      "\x0f",
-		EnableAcs:    "\x1b)0",
-		Mouse:        "\x1b[M",
-		MouseMode:    "%?%p1%{1}%=%t%'h'%Pa%e%'l'%Pa%;\x1b[?1000%ga%c\x1b[?1002%ga%c\x1b[?1003%ga%c\x1b[?1006%ga%c",
-		SetCursor:    "\x1b[%i%p1%d;%p2%dH",
-		CursorBack1:  "\b",
-		CursorUp1:    "\x1b[A",
-		KeyUp:        "\x1b[A",
-		KeyDown:      "\x1b[B",
-		KeyRight:     "\x1b[C",
-		KeyLeft:      "\x1b[D",
-		KeyInsert:    "\x1b[2~",
-		KeyDelete:    "\x1b[3~",
-		KeyBackspace: "\b",
-		KeyHome:      "\x1b[7~",
-		KeyEnd:       "\x1b[8~",
-		KeyPgUp:      "\x1b[5~",
-		KeyPgDn:      "\x1b[6~",
-		KeyF1:        "\x1b[11~",
-		KeyF2:        "\x1b[12~",
-		KeyF3:        "\x1b[13~",
-		KeyF4:        "\x1b[14~",
-		KeyF5:        "\x1b[15~",
-		KeyF6:        "\x1b[17~",
-		KeyF7:        "\x1b[18~",
-		KeyF8:        "\x1b[19~",
-		KeyF9:        "\x1b[20~",
-		KeyF10:       "\x1b[21~",
-		KeyF11:       "\x1b[23~",
-		KeyF12:       "\x1b[24~",
-		KeyF13:       "\x1b[25~",
-		KeyF14:       "\x1b[26~",
-		KeyF15:       "\x1b[28~",
-		KeyF16:       "\x1b[29~",
-		KeyF17:       "\x1b[31~",
-		KeyF18:       "\x1b[32~",
-		KeyF19:       "\x1b[33~",
-		KeyF20:       "\x1b[34~",
-		KeyF21:       "\x1b[23$",
-		KeyF22:       "\x1b[24$",
-		KeyF23:       "\x1b[11^",
-		KeyF24:       "\x1b[12^",
-		KeyF25:       "\x1b[13^",
-		KeyF26:       "\x1b[14^",
-		KeyF27:       "\x1b[15^",
-		KeyF28:       "\x1b[17^",
-		KeyF29:       "\x1b[18^",
-		KeyF30:       "\x1b[19^",
-		KeyF31:       "\x1b[20^",
-		KeyF32:       "\x1b[21^",
-		KeyF33:       "\x1b[23^",
-		KeyF34:       "\x1b[24^",
-		KeyF35:       "\x1b[25^",
-		KeyF36:       "\x1b[26^",
-		KeyF37:       "\x1b[28^",
-		KeyF38:       "\x1b[29^",
-		KeyF39:       "\x1b[31^",
-		KeyF40:       "\x1b[32^",
-		KeyF41:       "\x1b[33^",
-		KeyF42:       "\x1b[34^",
-		KeyF43:       "\x1b[23@",
-		KeyF44:       "\x1b[24@",
-		KeyHelp:      "\x1b[28~",
-		KeyShfLeft:   "\x1b[d",
-		KeyShfRight:  "\x1b[c",
-		KeyShfUp:     "\x1b[a",
-		KeyShfDown:   "\x1b[b",
-		KeyCtrlLeft:  "\x1b[Od",
-		KeyCtrlRight: "\x1b[Oc",
-		KeyCtrlUp:    "\x1b[Oa",
-		KeyCtrlDown:  "\x1b[Ob",
-		KeyShfHome:   "\x1b[7$",
-		KeyShfEnd:    "\x1b[8$",
-		KeyCtrlHome:  "\x1b[7^",
-		KeyCtrlEnd:   "\x1b[8^",
+		Name:           "Eterm-256color",
+		Columns:        80,
+		Lines:          24,
+		Colors:         256,
+		Bell:           "\a",
+		Clear:          "\x1b[H\x1b[2J",
+		EnterCA:        "\x1b7\x1b[?47h",
+		ExitCA:         "\x1b[2J\x1b[?47l\x1b8",
+		ShowCursor:     "\x1b[?25h",
+		HideCursor:     "\x1b[?25l",
+		AttrOff:        "\x1b[m\x0f",
+		Underline:      "\x1b[4m",
+		Bold:           "\x1b[1m",
+		Blink:          "\x1b[5m",
+		Reverse:        "\x1b[7m",
+		SetFg:          "\x1b[%?%p1%{8}%<%t3%p1%d%e%p1%{16}%<%t9%p1%{8}%-%d%e38;5;%p1%d%;m",
+		SetBg:          "\x1b[%?%p1%{8}%<%t4%p1%d%e%p1%{16}%<%t10%p1%{8}%-%d%e48;5;%p1%d%;m",
+		SetFgBg:        "\x1b[%?%p1%{8}%<%t3%p1%d%e%p1%{16}%<%t9%p1%{8}%-%d%e38;5;%p1%d%;;%?%p2%{8}%<%t4%p2%d%e%p2%{16}%<%t10%p2%{8}%-%d%e48;5;%p2%d%;m",
+		PadChar:        "\x00",
+		AltChars:       "``aaffggjjkkllmmnnooppqqrrssttuuvvwwxxyyzz{{||}}~~",
+		EnterAcs:       "\x0e",
+		ExitAcs:        "\x0f",
+		EnableAcs:      "\x1b)0",
+		Mouse:          "\x1b[M",
+		MouseMode:      "%?%p1%{1}%=%t%'h'%Pa%e%'l'%Pa%;\x1b[?1000%ga%c\x1b[?1002%ga%c\x1b[?1003%ga%c\x1b[?1006%ga%c",
+		SetCursor:      "\x1b[%i%p1%d;%p2%dH",
+		CursorBack1:    "\b",
+		CursorUp1:      "\x1b[A",
+		CursorDown1:    "\x1b[B",
+		CursorForward1: "\x1b[C",
+		CursorBack:     "\x1b[%p1%dD",
+		CursorUp:       "\x1b[%p1%dA",
+		CursorDown:     "\x1b[%p1%dB",
+		CursorForward:  "\x1b[%p1%dC",
+		CursorHome:     "\x1b[H",
+		CarriageReturn: "\r",
+		ColumnAddress:  "\x1b[%i%p1%dG",
+		RowAddress:     "\x1b[%i%p1%dd",
+		ClearToEOL:     "\x1b[K",
+		EraseChars:     "\x1b[%p1%dX",
+		BackColorErase: true,
+		ExitUnderline:  "\x1b[24m",
+		ResetFgBg:      "\x1b[39;49m",
+		EnablePaste:    "\x1b[?2004h",
+		DisablePaste:   "\x1b[?2004l",
+		PasteStart:     "\x1b[200~",
+		PasteEnd:       "\x1b[201~",
+		EnableFocus:    "\x1b[?1004h",
+		DisableFocus:   "\x1b[?1004l",
+		FocusIn:        "\x1b[I",
+		FocusOut:       "\x1b[O",
+		KeyUp:          "\x1b[A",
+		KeyDown:        "\x1b[B",
+		KeyRight:       "\x1b[C",
+		KeyLeft:        "\x1b[D",
+		KeyInsert:      "\x1b[2~",
+		KeyDelete:      "\x1b[3~",
+		KeyBackspace:   "\b",
+		KeyHome:        "\x1b[7~",
+		KeyEnd:         "\x1b[8~",
+		KeyPgUp:        "\x1b[5~",
+		KeyPgDn:        "\x1b[6~",
+		KeyF1:          "\x1b[11~",
+		KeyF2:          "\x1b[12~",
+		KeyF3:          "\x1b[13~",
+		KeyF4:          "\x1b[14~",
+		KeyF5:          "\x1b[15~",
+		KeyF6:          "\x1b[17~",
+		KeyF7:          "\x1b[18~",
+		KeyF8:          "\x1b[19~",
+		KeyF9:          "\x1b[20~",
+		KeyF10:         "\x1b[21~",
+		KeyF11:         "\x1b[23~",
+		KeyF12:         "\x1b[24~",
+		KeyF13:         "\x1b[25~",
+		KeyF14:         "\x1b[26~",
+		KeyF15:         "\x1b[28~",
+		KeyF16:         "\x1b[29~",
+		KeyF17:         "\x1b[31~",
+		KeyF18:         "\x1b[32~",
+		KeyF19:         "\x1b[33~",
+		KeyF20:         "\x1b[34~",
+		KeyF21:         "\x1b[23$",
+		KeyF22:         "\x1b[24$",
+		KeyF23:         "\x1b[11^",
+		KeyF24:         "\x1b[12^",
+		KeyF25:         "\x1b[13^",
+		KeyF26:         "\x1b[14^",
+		KeyF27:         "\x1b[15^",
+		KeyF28:         "\x1b[17^",
+		KeyF29:         "\x1b[18^",
+		KeyF30:         "\x1b[19^",
+		KeyF31:         "\x1b[20^",
+		KeyF32:         "\x1b[21^",
+		KeyF33:         "\x1b[23^",
+		KeyF34:         "\x1b[24^",
+		KeyF35:         "\x1b[25^",
+		KeyF36:         "\x1b[26^",
+		KeyF37:         "\x1b[28^",
+		KeyF38:         "\x1b[29^",
+		KeyF39:         "\x1b[31^",
+		KeyF40:         "\x1b[32^",
+		KeyF41:         "\x1b[33^",
+		KeyF42:         "\x1b[34^",
+		KeyF43:         "\x1b[23@",
+		KeyF44:         "\x1b[24@",
+		KeyHelp:        "\x1b[28~",
+		KeyShfLeft:     "\x1b[d",
+		KeyShfRight:    "\x1b[c",
+		KeyShfUp:       "\x1b[a",
+		KeyShfDown:     "\x1b[b",
+		KeyCtrlLeft:    "\x1b[Od",
+		KeyCtrlRight:   "\x1b[Oc",
+		KeyCtrlUp:      "\x1b[Oa",
+		KeyCtrlDown:    "\x1b[Ob",
+		KeyShfHome:     "\x1b[7$",
+		KeyShfEnd:      "\x1b[8$",
+		KeyCtrlHome:    "\x1b[7^",
+		KeyCtrlEnd:     "\x1b[8^",
 	})
 	AddTerminfo(&Terminfo{
-		Name:        "eterm",
-		Columns:     80,
-		Lines:       24,
-		Bell:        "\a",
-		Clear:       "\x1b[H\x1b[J",
-		EnterCA:     "\x1b7\x1b[?47h",
-		ExitCA:      "\x1b[2J\x1b[?47l\x1b8",
-		AttrOff:     "\x1b[m",
-		Underline:   "\x1b[4m",
-		Bold:        "\x1b[1m",
-		Reverse:     "\x1b[7m",
-		PadChar:     "\x00",
-		SetCursor:   "\x1b[%i%p1%d;%p2%dH",
-		CursorBack1: "\b",
-		CursorUp1:   "\x1b[A",
+		Name:           "eterm",
+		Columns:        80,
+		Lines:          24,
+		Bell:           "\a",
+		Clear:          "\x1b[H\x1b[J",
+		EnterCA:        "\x1b7\x1b[?47h",
+		ExitCA:         "\x1b[2J\x1b[?47l\x1b8",
+		AttrOff:        "\x1b[m",
+		Underline:      "\x1b[4m",
+		Bold:           "\x1b[1m",
+		Reverse:        "\x1b[7m",
+		PadChar:        "\x00",
+		SetCursor:      "\x1b[%i%p1%d;%p2%dH",
+		CursorBack1:    "\b",
+		CursorUp1:      "\x1b[A",
+		CursorDown1:    "\n",
+		CursorForward1: "\x1b[C",
+		CursorBack:     "\x1b[%p1%dD",
+		CursorUp:       "\x1b[%p1%dA",
+		CursorDown:     "\x1b[%p1%dB",
+		CursorForward:  "\x1b[%p1%dC",
+		CursorHome:     "\x1b[H",
+		CarriageReturn: "\r",
+		ClearToEOL:     "\x1b[K",
+		ExitUnderline:  "\x1b[m",
 	})
 	AddTerminfo(&Terminfo{
 		Name:            "gnome",
@@ -823,6 +1002,29 @@ func init() {
 		SetCursor:       "\x1b[%i%p1%d;%p2%dH",
 		CursorBack1:     "\b",
 		CursorUp1:       "\x1b[A",
+		CursorDown1:     "\n",
+		CursorForward1:  "\x1b[C",
+		CursorBack:      "\x1b[%p1%dD",
+		CursorUp:        "\x1b[%p1%dA",
+		CursorDown:      "\x1b[%p1%dB",
+		CursorForward:   "\x1b[%p1%dC",
+		CursorHome:      "\x1b[H",
+		CarriageReturn:  "\r",
+		ColumnAddress:   "\x1b[%i%p1%dG",
+		RowAddress:      "\x1b[%i%p1%dd",
+		ClearToEOL:      "\x1b[K",
+		EraseChars:      "\x1b[%p1%dX",
+		BackColorErase:  true,
+		ExitUnderline:   "\x1b[24m",
+		ResetFgBg:       "\x1b[39;49m",
+		EnablePaste:     "\x1b[?2004h",
+		DisablePaste:    "\x1b[?2004l",
+		PasteStart:      "\x1b[200~",
+		PasteEnd:        "\x1b[201~",
+		EnableFocus:     "\x1b[?1004h",
+		DisableFocus:    "\x1b[?1004l",
+		FocusIn:         "\x1b[I",
+		FocusOut:        "\x1b[O",
 		KeyUp:           "\x1bOA",
 		KeyDown:         "\x1bOB",
 		KeyRight:        "\x1bOC",
@@ -969,6 +1171,29 @@ func init() {
 		SetCursor:       "\x1b[%i%p1%d;%p2%dH",
 		CursorBack1:     "\b",
 		CursorUp1:       "\x1b[A",
+		CursorDown1:     "\n",
+		CursorForward1:  "\x1b[C",
+		CursorBack:      "\x1b[%p1%dD",
+		CursorUp:        "\x1b[%p1%dA",
+		CursorDown:      "\x1b[%p1%dB",
+		CursorForward:   "\x1b[%p1%dC",
+		CursorHome:      "\x1b[H",
+		CarriageReturn:  "\r",
+		ColumnAddress:   "\x1b[%i%p1%dG",
+		RowAddress:      "\x1b[%i%p1%dd",
+		ClearToEOL:      "\x1b[K",
+		EraseChars:      "\x1b[%p1%dX",
+		BackColorErase:  true,
+		ExitUnderline:   "\x1b[24m",
+		ResetFgBg:       "\x1b[39;49m",
+		EnablePaste:     "\x1b[?2004h",
+		DisablePaste:    "\x1b[?2004l",
+		PasteStart:      "\x1b[200~",
+		PasteEnd:        "\x1b[201~",
+		EnableFocus:     "\x1b[?1004h",
+		DisableFocus:    "\x1b[?1004l",
+		FocusIn:         "\x1b[I",
+		FocusOut:        "\x1b[O",
 		KeyUp:           "\x1bOA",
 		KeyDown:         "\x1bOB",
 		KeyRight:        "\x1bOC",
@@ -1086,1605 +1311,2026 @@ func init() {
 		KeyAltShfEnd:    "\x1b[1;4F",
 	})
 	AddTerminfo(&Terminfo{
-		Name:         "hpterm",
-		Aliases:      []string{"X-hpterm"},
-		Columns:      80,
-		Lines:        24,
-		Bell:         "\a",
-		Clear:        "\x1b&a0y0C\x1bJ",
-		AttrOff:      "\x1b&d@",
-		Underline:    "\x1b&dD",
-		Bold:         "\x1b&dB",
-		Dim:          "\x1b&dH",
-		Reverse:      "\x1b&dB",
-		EnterKeypad:  "\x1b&s1A",
-		ExitKeypad:   "\x1b&s0A",
-		PadChar:      "\x00",
-		EnterAcs:     "\x0e",
-		ExitAcs:      "\x0f",
-		SetCursor:    "\x1b&a%p1%dy%p2%dC",
-		CursorBack1:  "\b",
-		CursorUp1:    "\x1bA",
-		KeyUp:        "\x1bA",
-		KeyDown:      "\x1bB",
-		KeyRight:     "\x1bC",
-		KeyLeft:      "\x1bD",
-		KeyInsert:    "\x1bQ",
-		KeyDelete:    "\x1bP",
-		KeyBackspace: "\b",
-		KeyHome:      "\x1bh",
-		KeyPgUp:      "\x1bV",
-		KeyPgDn:      "\x1bU",
-		KeyF1:        "\x1bp",
-		KeyF2:        "\x1bq",
-		KeyF3:        "\x1br",
-		KeyF4:        "\x1bs",
-		KeyF5:        "\x1bt",
-		KeyF6:        "\x1bu",
-		KeyF7:        "\x1bv",
-		KeyF8:        "\x1bw",
-		KeyClear:     "\x1bJ",
+		Name:           "hpterm",
+		Aliases:        []string{"X-hpterm"},
+		Columns:        80,
+		Lines:          24,
+		Bell:           "\a",
+		Clear:          "\x1b&a0y0C\x1bJ",
+		AttrOff:        "\x1b&d@",
+		Underline:      "\x1b&dD",
+		Bold:           "\x1b&dB",
+		Dim:            "\x1b&dH",
+		Reverse:        "\x1b&dB",
+		EnterKeypad:    "\x1b&s1A",
+		ExitKeypad:     "\x1b&s0A",
+		PadChar:        "\x00",
+		EnterAcs:       "\x0e",
+		ExitAcs:        "\x0f",
+		SetCursor:      "\x1b&a%p1%dy%p2%dC",
+		CursorBack1:    "\b",
+		CursorUp1:      "\x1bA",
+		CursorDown1:    "\x1bB",
+		CursorForward1: "\x1bC",
+		CarriageReturn: "\r",
+		ColumnAddress:  "\x1b&a%p1%dC",
+		RowAddress:     "\x1b&a%p1%dY",
+		ClearToEOL:     "\x1bK",
+		ExitUnderline:  "\x1b&d@",
+		KeyUp:          "\x1bA",
+		KeyDown:        "\x1bB",
+		KeyRight:       "\x1bC",
+		KeyLeft:        "\x1bD",
+		KeyInsert:      "\x1bQ",
+		KeyDelete:      "\x1bP",
+		KeyBackspace:   "\b",
+		KeyHome:        "\x1bh",
+		KeyPgUp:        "\x1bV",
+		KeyPgDn:        "\x1bU",
+		KeyF1:          "\x1bp",
+		KeyF2:          "\x1bq",
+		KeyF3:          "\x1br",
+		KeyF4:          "\x1bs",
+		KeyF5:          "\x1bt",
+		KeyF6:          "\x1bu",
+		KeyF7:          "\x1bv",
+		KeyF8:          "\x1bw",
+		KeyClear:       "\x1bJ",
 	})
 	AddTerminfo(&Terminfo{
-		Name:        "hz1500",
-		Columns:     80,
-		Lines:       24,
-		Bell:        "\a",
-		Clear:       "~\x1c",
-		PadChar:     "\x00",
-		SetCursor:   "~\x11%p2%p2%?%{30}%>%t%' '%+%;%'`'%+%c%p1%'`'%+%c",
-		CursorBack1: "\b",
-		CursorUp1:   "~\f",
-		KeyUp:       "~\f",
-		KeyDown:     "\n",
-		KeyRight:    "\x10",
-		KeyLeft:     "\b",
-		KeyHome:     "~\x12",
+		Name:           "hz1500",
+		Columns:        80,
+		Lines:          24,
+		Bell:           "\a",
+		Clear:          "~\x1c",
+		PadChar:        "\x00",
+		SetCursor:      "~\x11%p2%p2%?%{30}%>%t%' '%+%;%'`'%+%c%p1%'`'%+%c",
+		CursorBack1:    "\b",
+		CursorUp1:      "~\f",
+		CursorDown1:    "~\v",
+		CursorForward1: "\x10",
+		CursorHome:     "~\x12",
+		CarriageReturn: "\r",
+		ClearToEOL:     "~\x0f",
+		KeyUp:          "~\f",
+		KeyDown:        "\n",
+		KeyRight:       "\x10",
+		KeyLeft:        "\b",
+		KeyHome:        "~\x12",
 	})
 	AddTerminfo(&Terminfo{
-		Name:         "konsole",
-		Columns:      80,
-		Lines:        24,
-		Colors:       8,
-		Clear:        "\x1b[H\x1b[2J",
-		EnterCA:      "\x1b7\x1b[?47h",
-		ExitCA:       "\x1b[2J\x1b[?47l\x1b8",
-		ShowCursor:   "\x1b[?25h",
-		HideCursor:   "\x1b[?25l",
-		AttrOff:      "\x1b[0m\x0f",
-		Underline:    "\x1b[4m",
-		Bold:         "\x1b[1m",
-		Blink:        "\x1b[5m",
-		Reverse:      "\x1b[7m",
-		EnterKeypad:  "\x1b[?1h\x1b=",
-		ExitKeypad:   "\x1b[?1l\x1b>",
-		SetFg:        "\x1b[3%p1%dm",
-		SetBg:        "\x1b[4%p1%dm",
-		SetFgBg:      "\x1b[3%p1%d;4%p2%dm",
-		AltChars:     "``aaffggiijjkkllmmnnooppqqrrssttuuvvwwxxyyzz{{||}}~~",
-		EnterAcs:     "\x0e",
-		ExitAcs:      "\x0f",
-		EnableAcs:    "\x1b)0",
-		Mouse:        "\x1b[M",
-		MouseMode:    "%?%p1%{1}%=%t%'h'%Pa%e%'l'%Pa%;\x1b[?1000%ga%c\x1b[?1002%ga%c\x1b[?1003%ga%c\x1b[?1006%ga%c",
-		SetCursor:    "\x1b[%i%p1%d;%p2%dH",
-		CursorBack1:  "\b",
-		CursorUp1:    "\x1b[A",
-		KeyUp:        "\x1bOA",
-		KeyDown:      "\x1bOB",
-		KeyRight:     "\x1bOC",
-		KeyLeft:      "\x1bOD",
-		KeyInsert:    "\x1b[2~",
-		KeyDelete:    "\x1b[3~",
-		KeyBackspace: "\u007f",
-		KeyHome:      "\x1bOH",
-		KeyEnd:       "\x1bOF",
-		KeyPgUp:      "\x1b[5~",
-		KeyPgDn:      "\x1b[6~",
-		KeyF1:        "\x1bOP",
-		KeyF2:        "\x1bOQ",
-		KeyF3:        "\x1bOR",
-		KeyF4:        "\x1bOS",
-		KeyF5:        "\x1b[15~",
-		KeyF6:        "\x1b[17~",
-		KeyF7:        "\x1b[18~",
-		KeyF8:        "\x1b[19~",
-		KeyF9:        "\x1b[20~",
-		KeyF10:       "\x1b[21~",
-		KeyF11:       "\x1b[23~",
-		KeyF12:       "\x1b[24~",
-		KeyF13:       "\x1bO2P",
-		KeyF14:       "\x1bO2Q",
-		KeyF15:       "\x1bO2R",
-		KeyF16:       "\x1bO2S",
-		KeyF17:       "\x1b[15;2~",
-		KeyF18:       "\x1b[17;2~",
-		KeyF19:       "\x1b[18;2~",
-		KeyF20:       "\x1b[19;2~",
-		KeyF21:       "\x1b[20;2~",
-		KeyF22:       "\x1b[21;2~",
-		KeyF23:       "\x1b[23;2~",
-		KeyF24:       "\x1b[24;2~",
-		KeyF25:       "\x1bO5P",
-		KeyF26:       "\x1bO5Q",
-		KeyF27:       "\x1bO5R",
-		KeyF28:       "\x1bO5S",
-		KeyF29:       "\x1b[15;5~",
-		KeyF30:       "\x1b[17;5~",
-		KeyF31:       "\x1b[18;5~",
-		KeyF32:       "\x1b[19;5~",
-		KeyF33:       "\x1b[20;5~",
-		KeyF34:       "\x1b[21;5~",
-		KeyF35:       "\x1b[23;5~",
-		KeyF36:       "\x1b[24;5~",
-		KeyF37:       "\x1bO6P",
-		KeyF38:       "\x1bO6Q",
-		KeyF39:       "\x1bO6R",
-		KeyF40:       "\x1bO6S",
-		KeyF41:       "\x1b[15;6~",
-		KeyF42:       "\x1b[17;6~",
-		KeyF43:       "\x1b[18;6~",
-		KeyF44:       "\x1b[19;6~",
-		KeyF45:       "\x1b[20;6~",
-		KeyF46:       "\x1b[21;6~",
-		KeyF47:       "\x1b[23;6~",
-		KeyF48:       "\x1b[24;6~",
-		KeyF49:       "\x1bO3P",
-		KeyF50:       "\x1bO3Q",
-		KeyF51:       "\x1bO3R",
-		KeyF52:       "\x1bO3S",
-		KeyF53:       "\x1b[15;3~",
-		KeyF54:       "\x1b[17;3~",
-		KeyF55:       "\x1b[18;3~",
-		KeyF56:       "\x1b[19;3~",
-		KeyF57:       "\x1b[20;3~",
-		KeyF58:       "\x1b[21;3~",
-		KeyF59:       "\x1b[23;3~",
-		KeyF60:       "\x1b[24;3~",
-		KeyF61:       "\x1bO4P",
-		KeyF62:       "\x1bO4Q",
-		KeyF63:       "\x1bO4R",
-		KeyBacktab:   "\x1b[Z",
+		Name:           "konsole",
+		Columns:        80,
+		Lines:          24,
+		Colors:         8,
+		Flash:          "\x1b[?5h$<100/>\x1b[?5l",
+		Clear:          "\x1b[H\x1b[2J",
+		EnterCA:        "\x1b7\x1b[?47h",
+		ExitCA:         "\x1b[2J\x1b[?47l\x1b8",
+		ShowCursor:     "\x1b[?25h",
+		HideCursor:     "\x1b[?25l",
+		AttrOff:        "\x1b[0m\x0f",
+		Underline:      "\x1b[4m",
+		Bold:           "\x1b[1m",
+		Blink:          "\x1b[5m",
+		Reverse:        "\x1b[7m",
+		EnterKeypad:    "\x1b[?1h\x1b=",
+		ExitKeypad:     "\x1b[?1l\x1b>",
+		SetFg:          "\x1b[3%p1%dm",
+		SetBg:          "\x1b[4%p1%dm",
+		SetFgBg:        "\x1b[3%p1%d;4%p2%dm",
+		AltChars:       "``aaffggiijjkkllmmnnooppqqrrssttuuvvwwxxyyzz{{||}}~~",
+		EnterAcs:       "\x0e",
+		ExitAcs:        "\x0f",
+		EnableAcs:      "\x1b)0",
+		Mouse:          "\x1b[M",
+		MouseMode:      "%?%p1%{1}%=%t%'h'%Pa%e%'l'%Pa%;\x1b[?1000%ga%c\x1b[?1002%ga%c\x1b[?1003%ga%c\x1b[?1006%ga%c",
+		SetCursor:      "\x1b[%i%p1%d;%p2%dH",
+		CursorBack1:    "\b",
+		CursorUp1:      "\x1b[A",
+		CursorDown1:    "\n",
+		CursorForward1: "\x1b[C",
+		CursorBack:     "\x1b[%p1%dD",
+		CursorUp:       "\x1b[%p1%dA",
+		CursorDown:     "\x1b[%p1%dB",
+		CursorForward:  "\x1b[%p1%dC",
+		CursorHome:     "\x1b[H",
+		CarriageReturn: "\r",
+		ColumnAddress:  "\x1b[%i%p1%dG",
+		RowAddress:     "\x1b[%i%p1%dd",
+		ClearToEOL:     "\x1b[K",
+		EraseChars:     "\x1b[%p1%dX",
+		BackColorErase: true,
+		ExitUnderline:  "\x1b[24m",
+		ResetFgBg:      "\x1b[39;49m",
+		EnablePaste:    "\x1b[?2004h",
+		DisablePaste:   "\x1b[?2004l",
+		PasteStart:     "\x1b[200~",
+		PasteEnd:       "\x1b[201~",
+		EnableFocus:    "\x1b[?1004h",
+		DisableFocus:   "\x1b[?1004l",
+		FocusIn:        "\x1b[I",
+		FocusOut:       "\x1b[O",
+		KeyUp:          "\x1bOA",
+		KeyDown:        "\x1bOB",
+		KeyRight:       "\x1bOC",
+		KeyLeft:        "\x1bOD",
+		KeyInsert:      "\x1b[2~",
+		KeyDelete:      "\x1b[3~",
+		KeyBackspace:   "\u007f",
+		KeyHome:        "\x1bOH",
+		KeyEnd:         "\x1bOF",
+		KeyPgUp:        "\x1b[5~",
+		KeyPgDn:        "\x1b[6~",
+		KeyF1:          "\x1bOP",
+		KeyF2:          "\x1bOQ",
+		KeyF3:          "\x1bOR",
+		KeyF4:          "\x1bOS",
+		KeyF5:          "\x1b[15~",
+		KeyF6:          "\x1b[17~",
+		KeyF7:          "\x1b[18~",
+		KeyF8:          "\x1b[19~",
+		KeyF9:          "\x1b[20~",
+		KeyF10:         "\x1b[21~",
+		KeyF11:         "\x1b[23~",
+		KeyF12:         "\x1b[24~",
+		KeyF13:         "\x1bO2P",
+		KeyF14:         "\x1bO2Q",
+		KeyF15:         "\x1bO2R",
+		KeyF16:         "\x1bO2S",
+		KeyF17:         "\x1b[15;2~",
+		KeyF18:         "\x1b[17;2~",
+		KeyF19:         "\x1b[18;2~",
+		KeyF20:         "\x1b[19;2~",
+		KeyF21:         "\x1b[20;2~",
+		KeyF22:         "\x1b[21;2~",
+		KeyF23:         "\x1b[23;2~",
+		KeyF24:         "\x1b[24;2~",
+		KeyF25:         "\x1bO5P",
+		KeyF26:         "\x1bO5Q",
+		KeyF27:         "\x1bO5R",
+		KeyF28:         "\x1bO5S",
+		KeyF29:         "\x1b[15;5~",
+		KeyF30:         "\x1b[17;5~",
+		KeyF31:         "\x1b[18;5~",
+		KeyF32:         "\x1b[19;5~",
+		KeyF33:         "\x1b[20;5~",
+		KeyF34:         "\x1b[21;5~",
+		KeyF35:         "\x1b[23;5~",
+		KeyF36:         "\x1b[24;5~",
+		KeyF37:         "\x1bO6P",
+		KeyF38:         "\x1bO6Q",
+		KeyF39:         "\x1bO6R",
+		KeyF40:         "\x1bO6S",
+		KeyF41:         "\x1b[15;6~",
+		KeyF42:         "\x1b[17;6~",
+		KeyF43:         "\x1b[18;6~",
+		KeyF44:         "\x1b[19;6~",
+		KeyF45:         "\x1b[20;6~",
+		KeyF46:         "\x1b[21;6~",
+		KeyF47:         "\x1b[23;6~",
+		KeyF48:         "\x1b[24;6~",
+		KeyF49:         "\x1bO3P",
+		KeyF50:         "\x1bO3Q",
+		KeyF51:         "\x1bO3R",
+		KeyF52:         "\x1bO3S",
+		KeyF53:         "\x1b[15;3~",
+		KeyF54:         "\x1b[17;3~",
+		KeyF55:         "\x1b[18;3~",
+		KeyF56:         "\x1b[19;3~",
+		KeyF57:         "\x1b[20;3~",
+		KeyF58:         "\x1b[21;3~",
+		KeyF59:         "\x1b[23;3~",
+		KeyF60:         "\x1b[24;3~",
+		KeyF61:         "\x1bO4P",
+		KeyF62:         "\x1bO4Q",
+		KeyF63:         "\x1bO4R",
+		KeyBacktab:     "\x1b[Z",
 	})
 	AddTerminfo(&Terminfo{
-		Name:         "konsole-256color",
-		Columns:      80,
-		Lines:        24,
-		Colors:       256,
-		Clear:        "\x1b[H\x1b[2J",
-		EnterCA:      "\x1b7\x1b[?47h",
-		ExitCA:       "\x1b[2J\x1b[?47l\x1b8",
-		ShowCursor:   "\x1b[?25h",
-		HideCursor:   "\x1b[?25l",
-		AttrOff:      "\x1b[0m\x0f",
-		Underline:    "\x1b[4m",
-		Bold:         "\x1b[1m",
-		Blink:        "\x1b[5m",
-		Reverse:      "\x1b[7m",
-		EnterKeypad:  "\x1b[?1h\x1b=",
-		ExitKeypad:   "\x1b[?1l\x1b>",
-		SetFg:        "\x1b[%?%p1%{8}%<%t3%p1%d%e%p1%{16}%<%t9%p1%{8}%-%d%e38;5;%p1%d%;m",
-		SetBg:        "\x1b[%?%p1%{8}%<%t4%p1%d%e%p1%{16}%<%t10%p1%{8}%-%d%e48;5;%p1%d%;m",
-		SetFgBg:      "\x1b[%?%p1%{8}%<%t3%p1%d%e%p1%{16}%<%t9%p1%{8}%-%d%e38;5;%p1%d%;;%?%p2%{8}%<%t4%p2%d%e%p2%{16}%<%t10%p2%{8}%-%d%e48;5;%p2%d%;m",
-		AltChars:     "``aaffggiijjkkllmmnnooppqqrrssttuuvvwwxxyyzz{{||}}~~",
-		EnterAcs:     "\x0e",
-		ExitAcs:      "\x0f",
-		EnableAcs:    "\x1b)0",
-		Mouse:        "\x1b[M",
-		MouseMode:    "%?%p1%{1}%=%t%'h'%Pa%e%'l'%Pa%;\x1b[?1000%ga%c\x1b[?1002%ga%c\x1b[?1003%ga%c\x1b[?1006%ga%c",
-		SetCursor:    "\x1b[%i%p1%d;%p2%dH",
-		CursorBack1:  "\b",
-		CursorUp1:    "\x1b[A",
-		KeyUp:        "\x1bOA",
-		KeyDown:      "\x1bOB",
-		KeyRight:     "\x1bOC",
-		KeyLeft:      "\x1bOD",
-		KeyInsert:    "\x1b[2~",
-		KeyDelete:    "\x1b[3~",
-		KeyBackspace: "\u007f",
-		KeyHome:      "\x1bOH",
-		KeyEnd:       "\x1bOF",
-		KeyPgUp:      "\x1b[5~",
-		KeyPgDn:      "\x1b[6~",
-		KeyF1:        "\x1bOP",
-		KeyF2:        "\x1bOQ",
-		KeyF3:        "\x1bOR",
-		KeyF4:        "\x1bOS",
-		KeyF5:        "\x1b[15~",
-		KeyF6:        "\x1b[17~",
-		KeyF7:        "\x1b[18~",
-		KeyF8:        "\x1b[19~",
-		KeyF9:        "\x1b[20~",
-		KeyF10:       "\x1b[21~",
-		KeyF11:       "\x1b[23~",
-		KeyF12:       "\x1b[24~",
-		KeyF13:       "\x1bO2P",
-		KeyF14:       "\x1bO2Q",
-		KeyF15:       "\x1bO2R",
-		KeyF16:       "\x1bO2S",
-		KeyF17:       "\x1b[15;2~",
-		KeyF18:       "\x1b[17;2~",
-		KeyF19:       "\x1b[18;2~",
-		KeyF20:       "\x1b[19;2~",
-		KeyF21:       "\x1b[20;2~",
-		KeyF22:       "\x1b[21;2~",
-		KeyF23:       "\x1b[23;2~",
-		KeyF24:       "\x1b[24;2~",
-		KeyF25:       "\x1bO5P",
-		KeyF26:       "\x1bO5Q",
-		KeyF27:       "\x1bO5R",
-		KeyF28:       "\x1bO5S",
-		KeyF29:       "\x1b[15;5~",
-		KeyF30:       "\x1b[17;5~",
-		KeyF31:       "\x1b[18;5~",
-		KeyF32:       "\x1b[19;5~",
-		KeyF33:       "\x1b[20;5~",
-		KeyF34:       "\x1b[21;5~",
-		KeyF35:       "\x1b[23;5~",
-		KeyF36:       "\x1b[24;5~",
-		KeyF37:       "\x1bO6P",
-		KeyF38:       "\x1bO6Q",
-		KeyF39:       "\x1bO6R",
-		KeyF40:       "\x1bO6S",
-		KeyF41:       "\x1b[15;6~",
-		KeyF42:       "\x1b[17;6~",
-		KeyF43:       "\x1b[18;6~",
-		KeyF44:       "\x1b[19;6~",
-		KeyF45:       "\x1b[20;6~",
-		KeyF46:       "\x1b[21;6~",
-		KeyF47:       "\x1b[23;6~",
-		KeyF48:       "\x1b[24;6~",
-		KeyF49:       "\x1bO3P",
-		KeyF50:       "\x1bO3Q",
-		KeyF51:       "\x1bO3R",
-		KeyF52:       "\x1bO3S",
-		KeyF53:       "\x1b[15;3~",
-		KeyF54:       "\x1b[17;3~",
-		KeyF55:       "\x1b[18;3~",
-		KeyF56:       "\x1b[19;3~",
-		KeyF57:       "\x1b[20;3~",
-		KeyF58:       "\x1b[21;3~",
-		KeyF59:       "\x1b[23;3~",
-		KeyF60:       "\x1b[24;3~",
-		KeyF61:       "\x1bO4P",
-		KeyF62:       "\x1bO4Q",
-		KeyF63:       "\x1bO4R",
-		KeyBacktab:   "\x1b[Z",
+		Name:           "konsole-256color",
+		Columns:        80,
+		Lines:          24,
+		Colors:         256,
+		Flash:          "\x1b[?5h$<100/>\x1b[?5l",
+		Clear:          "\x1b[H\x1b[2J",
+		EnterCA:        "\x1b7\x1b[?47h",
+		ExitCA:         "\x1b[2J\x1b[?47l\x1b8",
+		ShowCursor:     "\x1b[?25h",
+		HideCursor:     "\x1b[?25l",
+		AttrOff:        "\x1b[0m\x0f",
+		Underline:      "\x1b[4m",
+		Bold:           "\x1b[1m",
+		Blink:          "\x1b[5m",
+		Reverse:        "\x1b[7m",
+		EnterKeypad:    "\x1b[?1h\x1b=",
+		ExitKeypad:     "\x1b[?1l\x1b>",
+		SetFg:          "\x1b[%?%p1%{8}%<%t3%p1%d%e%p1%{16}%<%t9%p1%{8}%-%d%e38;5;%p1%d%;m",
+		SetBg:          "\x1b[%?%p1%{8}%<%t4%p1%d%e%p1%{16}%<%t10%p1%{8}%-%d%e48;5;%p1%d%;m",
+		SetFgBg:        "\x1b[%?%p1%{8}%<%t3%p1%d%e%p1%{16}%<%t9%p1%{8}%-%d%e38;5;%p1%d%;;%?%p2%{8}%<%t4%p2%d%e%p2%{16}%<%t10%p2%{8}%-%d%e48;5;%p2%d%;m",
+		AltChars:       "``aaffggiijjkkllmmnnooppqqrrssttuuvvwwxxyyzz{{||}}~~",
+		EnterAcs:       "\x0e",
+		ExitAcs:        "\x0f",
+		EnableAcs:      "\x1b)0",
+		Mouse:          "\x1b[M",
+		MouseMode:      "%?%p1%{1}%=%t%'h'%Pa%e%'l'%Pa%;\x1b[?1000%ga%c\x1b[?1002%ga%c\x1b[?1003%ga%c\x1b[?1006%ga%c",
+		SetCursor:      "\x1b[%i%p1%d;%p2%dH",
+		CursorBack1:    "\b",
+		CursorUp1:      "\x1b[A",
+		CursorDown1:    "\n",
+		CursorForward1: "\x1b[C",
+		CursorBack:     "\x1b[%p1%dD",
+		CursorUp:       "\x1b[%p1%dA",
+		CursorDown:     "\x1b[%p1%dB",
+		CursorForward:  "\x1b[%p1%dC",
+		CursorHome:     "\x1b[H",
+		CarriageReturn: "\r",
+		ColumnAddress:  "\x1b[%i%p1%dG",
+		RowAddress:     "\x1b[%i%p1%dd",
+		ClearToEOL:     "\x1b[K",
+		EraseChars:     "\x1b[%p1%dX",
+		BackColorErase: true,
+		ExitUnderline:  "\x1b[24m",
+		ResetFgBg:      "\x1b[39;49m",
+		EnablePaste:    "\x1b[?2004h",
+		DisablePaste:   "\x1b[?2004l",
+		PasteStart:     "\x1b[200~",
+		PasteEnd:       "\x1b[201~",
+		EnableFocus:    "\x1b[?1004h",
+		DisableFocus:   "\x1b[?1004l",
+		FocusIn:        "\x1b[I",
+		FocusOut:       "\x1b[O",
+		KeyUp:          "\x1bOA",
+		KeyDown:        "\x1bOB",
+		KeyRight:       "\x1bOC",
+		KeyLeft:        "\x1bOD",
+		KeyInsert:      "\x1b[2~",
+		KeyDelete:      "\x1b[3~",
+		KeyBackspace:   "\u007f",
+		KeyHome:        "\x1bOH",
+		KeyEnd:         "\x1bOF",
+		KeyPgUp:        "\x1b[5~",
+		KeyPgDn:        "\x1b[6~",
+		KeyF1:          "\x1bOP",
+		KeyF2:          "\x1bOQ",
+		KeyF3:          "\x1bOR",
+		KeyF4:          "\x1bOS",
+		KeyF5:          "\x1b[15~",
+		KeyF6:          "\x1b[17~",
+		KeyF7:          "\x1b[18~",
+		KeyF8:          "\x1b[19~",
+		KeyF9:          "\x1b[20~",
+		KeyF10:         "\x1b[21~",
+		KeyF11:         "\x1b[23~",
+		KeyF12:         "\x1b[24~",
+		KeyF13:         "\x1bO2P",
+		KeyF14:         "\x1bO2Q",
+		KeyF15:         "\x1bO2R",
+		KeyF16:         "\x1bO2S",
+		KeyF17:         "\x1b[15;2~",
+		KeyF18:         "\x1b[17;2~",
+		KeyF19:         "\x1b[18;2~",
+		KeyF20:         "\x1b[19;2~",
+		KeyF21:         "\x1b[20;2~",
+		KeyF22:         "\x1b[21;2~",
+		KeyF23:         "\x1b[23;2~",
+		KeyF24:         "\x1b[24;2~",
+		KeyF25:         "\x1bO5P",
+		KeyF26:         "\x1bO5Q",
+		KeyF27:         "\x1bO5R",
+		KeyF28:         "\x1bO5S",
+		KeyF29:         "\x1b[15;5~",
+		KeyF30:         "\x1b[17;5~",
+		KeyF31:         "\x1b[18;5~",
+		KeyF32:         "\x1b[19;5~",
+		KeyF33:         "\x1b[20;5~",
+		KeyF34:         "\x1b[21;5~",
+		KeyF35:         "\x1b[23;5~",
+		KeyF36:         "\x1b[24;5~",
+		KeyF37:         "\x1bO6P",
+		KeyF38:         "\x1bO6Q",
+		KeyF39:         "\x1bO6R",
+		KeyF40:         "\x1bO6S",
+		KeyF41:         "\x1b[15;6~",
+		KeyF42:         "\x1b[17;6~",
+		KeyF43:         "\x1b[18;6~",
+		KeyF44:         "\x1b[19;6~",
+		KeyF45:         "\x1b[20;6~",
+		KeyF46:         "\x1b[21;6~",
+		KeyF47:         "\x1b[23;6~",
+		KeyF48:         "\x1b[24;6~",
+		KeyF49:         "\x1bO3P",
+		KeyF50:         "\x1bO3Q",
+		KeyF51:         "\x1bO3R",
+		KeyF52:         "\x1bO3S",
+		KeyF53:         "\x1b[15;3~",
+		KeyF54:         "\x1b[17;3~",
+		KeyF55:         "\x1b[18;3~",
+		KeyF56:         "\x1b[19;3~",
+		KeyF57:         "\x1b[20;3~",
+		KeyF58:         "\x1b[21;3~",
+		KeyF59:         "\x1b[23;3~",
+		KeyF60:         "\x1b[24;3~",
+		KeyF61:         "\x1bO4P",
+		KeyF62:         "\x1bO4Q",
+		KeyF63:         "\x1bO4R",
+		KeyBacktab:     "\x1b[Z",
 	})
 	AddTerminfo(&Terminfo{
-		Name:         "kterm",
-		Columns:      80,
-		Lines:        24,
-		Colors:       8,
-		Bell:         "\a",
-		Clear:        "\x1b[H\x1b[2J",
-		EnterCA:      "\x1b7\x1b[?47h",
-		ExitCA:       "\x1b[2J\x1b[?47l\x1b8",
-		AttrOff:      "\x1b[m\x1b(B",
-		Underline:    "\x1b[4m",
-		Bold:         "\x1b[1m",
-		Reverse:      "\x1b[7m",
-		EnterKeypad:  "\x1b[?1h\x1b=",
-		ExitKeypad:   "\x1b[?1l\x1b>",
-		SetFg:        "\x1b[3%p1%dm",
-		SetBg:        "\x1b[4%p1%dm",
-		SetFgBg:      "\x1b[3%p1%d;4%p2%dm",
-		PadChar:      "\x00",
-		AltChars:     "``aajjkkllmmnnooppqqrrssttuuvvwwxx~~",
-		EnterAcs:     "\x1b(0",
-		ExitAcs:      "\x1b(B",
-		Mouse:        "\x1b[M",
-		MouseMode:    "%?%p1%{1}%=%t%'h'%Pa%e%'l'%Pa%;\x1b[?1000%ga%c\x1b[?1002%ga%c\x1b[?1003%ga%c\x1b[?1006%ga%c",
-		SetCursor:    "\x1b[%i%p1%d;%p2%dH",
-		CursorBack1:  "\b",
-		CursorUp1:    "\x1b[A",
-		KeyUp:        "\x1bOA",
-		KeyDown:      "\x1bOB",
-		KeyRight:     "\x1bOC",
-		KeyLeft:      "\x1bOD",
-		KeyInsert:    "\x1b[2~",
-		KeyDelete:    "\x1b[3~",
-		KeyBackspace: "\b",
-		KeyPgUp:      "\x1b[5~",
-		KeyPgDn:      "\x1b[6~",
-		KeyF1:        "\x1b[11~",
-		KeyF2:        "\x1b[12~",
-		KeyF3:        "\x1b[13~",
-		KeyF4:        "\x1b[14~",
-		KeyF5:        "\x1b[15~",
-		KeyF6:        "\x1b[17~",
-		KeyF7:        "\x1b[18~",
-		KeyF8:        "\x1b[19~",
-		KeyF9:        "\x1b[20~",
-		KeyF10:       "\x1b[21~",
-		KeyF11:       "\x1b[23~",
-		KeyF12:       "\x1b[24~",
-		KeyF13:       "\x1b[25~",
-		KeyF14:       "\x1b[26~",
-		KeyF15:       "\x1b[28~",
-		KeyF16:       "\x1b[29~",
-		KeyF17:       "\x1b[31~",
-		KeyF18:       "\x1b[32~",
-		KeyF19:       "\x1b[33~",
-		KeyF20:       "\x1b[34~",
+		Name:           "kterm",
+		Columns:        80,
+		Lines:          24,
+		Colors:         8,
+		Bell:           "\a",
+		Clear:          "\x1b[H\x1b[2J",
+		EnterCA:        "\x1b7\x1b[?47h",
+		ExitCA:         "\x1b[2J\x1b[?47l\x1b8",
+		AttrOff:        "\x1b[m\x1b(B",
+		Underline:      "\x1b[4m",
+		Bold:           "\x1b[1m",
+		Reverse:        "\x1b[7m",
+		EnterKeypad:    "\x1b[?1h\x1b=",
+		ExitKeypad:     "\x1b[?1l\x1b>",
+		SetFg:          "\x1b[3%p1%dm",
+		SetBg:          "\x1b[4%p1%dm",
+		SetFgBg:        "\x1b[3%p1%d;4%p2%dm",
+		PadChar:        "\x00",
+		AltChars:       "``aajjkkllmmnnooppqqrrssttuuvvwwxx~~",
+		EnterAcs:       "\x1b(0",
+		ExitAcs:        "\x1b(B",
+		Mouse:          "\x1b[M",
+		MouseMode:      "%?%p1%{1}%=%t%'h'%Pa%e%'l'%Pa%;\x1b[?1000%ga%c\x1b[?1002%ga%c\x1b[?1003%ga%c\x1b[?1006%ga%c",
+		SetCursor:      "\x1b[%i%p1%d;%p2%dH",
+		CursorBack1:    "\b",
+		CursorUp1:      "\x1b[A",
+		CursorDown1:    "\n",
+		CursorForward1: "\x1b[C",
+		CursorBack:     "\x1b[%p1%dD",
+		CursorUp:       "\x1b[%p1%dA",
+		CursorDown:     "\x1b[%p1%dB",
+		CursorForward:  "\x1b[%p1%dC",
+		CursorHome:     "\x1b[H",
+		CarriageReturn: "\r",
+		ClearToEOL:     "\x1b[K",
+		ExitUnderline:  "\x1b[m",
+		ResetFgBg:      "\x1b[39;49m",
+		EnablePaste:    "\x1b[?2004h",
+		DisablePaste:   "\x1b[?2004l",
+		PasteStart:     "\x1b[200~",
+		PasteEnd:       "\x1b[201~",
+		EnableFocus:    "\x1b[?1004h",
+		DisableFocus:   "\x1b[?1004l",
+		FocusIn:        "\x1b[I",
+		FocusOut:       "\x1b[O",
+		KeyUp:          "\x1bOA",
+		KeyDown:        "\x1bOB",
+		KeyRight:       "\x1bOC",
+		KeyLeft:        "\x1bOD",
+		KeyInsert:      "\x1b[2~",
+		KeyDelete:      "\x1b[3~",
+		KeyBackspace:   "\b",
+		KeyPgUp:        "\x1b[5~",
+		KeyPgDn:        "\x1b[6~",
+		KeyF1:          "\x1b[11~",
+		KeyF2:          "\x1b[12~",
+		KeyF3:          "\x1b[13~",
+		KeyF4:          "\x1b[14~",
+		KeyF5:          "\x1b[15~",
+		KeyF6:          "\x1b[17~",
+		KeyF7:          "\x1b[18~",
+		KeyF8:          "\x1b[19~",
+		KeyF9:          "\x1b[20~",
+		KeyF10:         "\x1b[21~",
+		KeyF11:         "\x1b[23~",
+		KeyF12:         "\x1b[24~",
+		KeyF13:         "\x1b[25~",
+		KeyF14:         "\x1b[26~",
+		KeyF15:         "\x1b[28~",
+		KeyF16:         "\x1b[29~",
+		KeyF17:         "\x1b[31~",
+		KeyF18:         "\x1b[32~",
+		KeyF19:         "\x1b[33~",
+		KeyF20:         "\x1b[34~",
 	})
 	AddTerminfo(&Terminfo{
-		Name:         "linux",
-		Columns:      -1,
-		Lines:        -1,
-		Colors:       8,
-		Bell:         "\a",
-		Clear:        "\x1b[H\x1b[J",
-		ShowCursor:   "\x1b[?25h\x1b[?0c",
-		HideCursor:   "\x1b[?25l\x1b[?1c",
-		AttrOff:      "\x1b[0;10m",
-		Underline:    "\x1b[4m",
-		Bold:         "\x1b[1m",
-		Dim:          "\x1b[2m",
-		Blink:        "\x1b[5m",
-		Reverse:      "\x1b[7m",
-		SetFg:        "\x1b[3%p1%dm",
-		SetBg:        "\x1b[4%p1%dm",
-		SetFgBg:      "\x1b[3%p1%d;4%p2%dm",
-		PadChar:      "\x00",
-		AltChars:     "+\x10,\x11-\x18.\x190\xdb`\x04a\xb1f\xf8g\xf1h\xb0i\xcej\xd9k\xbfl\xdam\xc0n\xc5o~p\xc4q\xc4r\xc4s_t\xc3u\xb4v\xc1w\xc2x\xb3y\xf3z\xf2{\xe3|\xd8}\x9c~\xfe",
-		EnterAcs:     "\x1b[11m",
-		ExitAcs:      "\x1b[10m",
-		Mouse:        "\x1b[M",
-		MouseMode:    "%?%p1%{1}%=%t%'h'%Pa%e%'l'%Pa%;\x1b[?1000%ga%c\x1b[?1002%ga%c\x1b[?1003%ga%c\x1b[?1006%ga%c",
-		SetCursor:    "\x1b[%i%p1%d;%p2%dH",
-		CursorBack1:  "\b",
-		CursorUp1:    "\x1b[A",
-		KeyUp:        "\x1b[A",
-		KeyDown:      "\x1b[B",
-		KeyRight:     "\x1b[C",
-		KeyLeft:      "\x1b[D",
-		KeyInsert:    "\x1b[2~",
-		KeyDelete:    "\x1b[3~",
-		KeyBackspace: "\u007f",
-		KeyHome:      "\x1b[1~",
-		KeyEnd:       "\x1b[4~",
-		KeyPgUp:      "\x1b[5~",
-		KeyPgDn:      "\x1b[6~",
-		KeyF1:        "\x1b[[A",
-		KeyF2:        "\x1b[[B",
-		KeyF3:        "\x1b[[C",
-		KeyF4:        "\x1b[[D",
-		KeyF5:        "\x1b[[E",
-		KeyF6:        "\x1b[17~",
-		KeyF7:        "\x1b[18~",
-		KeyF8:        "\x1b[19~",
-		KeyF9:        "\x1b[20~",
-		KeyF10:       "\x1b[21~",
-		KeyF11:       "\x1b[23~",
-		KeyF12:       "\x1b[24~",
-		KeyF13:       "\x1b[25~",
-		KeyF14:       "\x1b[26~",
-		KeyF15:       "\x1b[28~",
-		KeyF16:       "\x1b[29~",
-		KeyF17:       "\x1b[31~",
-		KeyF18:       "\x1b[32~",
-		KeyF19:       "\x1b[33~",
-		KeyF20:       "\x1b[34~",
-		KeyBacktab:   "\x1b[Z",
+		Name:           "linux",
+		Columns:        -1,
+		Lines:          -1,
+		Colors:         8,
+		Bell:           "\a",
+		Flash:          "\x1b[?5h$<200/>\x1b[?5l",
+		Clear:          "\x1b[H\x1b[J",
+		ShowCursor:     "\x1b[?25h\x1b[?0c",
+		HideCursor:     "\x1b[?25l\x1b[?1c",
+		AttrOff:        "\x1b[0;10m",
+		Underline:      "\x1b[4m",
+		Bold:           "\x1b[1m",
+		Dim:            "\x1b[2m",
+		Blink:          "\x1b[5m",
+		Reverse:        "\x1b[7m",
+		SetFg:          "\x1b[3%p1%dm",
+		SetBg:          "\x1b[4%p1%dm",
+		SetFgBg:        "\x1b[3%p1%d;4%p2%dm",
+		PadChar:        "\x00",
+		AltChars:       "+\x10,\x11-\x18.\x190\xdb`\x04a\xb1f\xf8g\xf1h\xb0i\xcej\xd9k\xbfl\xdam\xc0n\xc5o~p\xc4q\xc4r\xc4s_t\xc3u\xb4v\xc1w\xc2x\xb3y\xf3z\xf2{\xe3|\xd8}\x9c~\xfe",
+		EnterAcs:       "\x1b[11m",
+		ExitAcs:        "\x1b[10m",
+		Mouse:          "\x1b[M",
+		MouseMode:      "%?%p1%{1}%=%t%'h'%Pa%e%'l'%Pa%;\x1b[?1000%ga%c\x1b[?1002%ga%c\x1b[?1003%ga%c\x1b[?1006%ga%c",
+		SetCursor:      "\x1b[%i%p1%d;%p2%dH",
+		CursorBack1:    "\b",
+		CursorUp1:      "\x1b[A",
+		CursorDown1:    "\n",
+		CursorForward1: "\x1b[C",
+		CursorBack:     "\x1b[%p1%dD",
+		CursorUp:       "\x1b[%p1%dA",
+		CursorDown:     "\x1b[%p1%dB",
+		CursorForward:  "\x1b[%p1%dC",
+		CursorHome:     "\x1b[H",
+		CarriageReturn: "\r",
+		ColumnAddress:  "\x1b[%i%p1%dG",
+		RowAddress:     "\x1b[%i%p1%dd",
+		ClearToEOL:     "\x1b[K",
+		EraseChars:     "\x1b[%p1%dX",
+		BackColorErase: true,
+		ExitUnderline:  "\x1b[24m",
+		ResetFgBg:      "\x1b[39;49m",
+		EnablePaste:    "\x1b[?2004h",
+		DisablePaste:   "\x1b[?2004l",
+		PasteStart:     "\x1b[200~",
+		PasteEnd:       "\x1b[201~",
+		EnableFocus:    "\x1b[?1004h",
+		DisableFocus:   "\x1b[?1004l",
+		FocusIn:        "\x1b[I",
+		FocusOut:       "\x1b[O",
+		KeyUp:          "\x1b[A",
+		KeyDown:        "\x1b[B",
+		KeyRight:       "\x1b[C",
+		KeyLeft:        "\x1b[D",
+		KeyInsert:      "\x1b[2~",
+		KeyDelete:      "\x1b[3~",
+		KeyBackspace:   "\u007f",
+		KeyHome:        "\x1b[1~",
+		KeyEnd:         "\x1b[4~",
+		KeyPgUp:        "\x1b[5~",
+		KeyPgDn:        "\x1b[6~",
+		KeyF1:          "\x1b[[A",
+		KeyF2:          "\x1b[[B",
+		KeyF3:          "\x1b[[C",
+		KeyF4:          "\x1b[[D",
+		KeyF5:          "\x1b[[E",
+		KeyF6:          "\x1b[17~",
+		KeyF7:          "\x1b[18~",
+		KeyF8:          "\x1b[19~",
+		KeyF9:          "\x1b[20~",
+		KeyF10:         "\x1b[21~",
+		KeyF11:         "\x1b[23~",
+		KeyF12:         "\x1b[24~",
+		KeyF13:         "\x1b[25~",
+		KeyF14:         "\x1b[26~",
+		KeyF15:         "\x1b[28~",
+		KeyF16:         "\x1b[29~",
+		KeyF17:         "\x1b[31~",
+		KeyF18:         "\x1b[32~",
+		KeyF19:         "\x1b[33~",
+		KeyF20:         "\x1b[34~",
+		KeyBacktab:     "\x1b[Z",
 	})
 	AddTerminfo(&Terminfo{
-		Name:         "pcansi",
-		Columns:      80,
-		Lines:        24,
-		Colors:       8,
-		Bell:         "\a",
-		Clear:        "\x1b[H\x1b[J",
-		AttrOff:      "\x1b[0;10m",
-		Underline:    "\x1b[4m",
-		Bold:         "\x1b[1m",
-		Blink:        "\x1b[5m",
-		Reverse:      "\x1b[7m",
-		SetFg:        "\x1b[3%p1%dm",
-		SetBg:        "\x1b[4%p1%dm",
-		SetFgBg:      "\x1b[3%p1%d;4%p2%dm",
-		PadChar:      "\x00",
-		AltChars:     "+\x10,\x11-\x18.\x190\xdb`\x04a\xb1f\xf8g\xf1h\xb0j\xd9k\xbfl\xdam\xc0n\xc5o~p\xc4q\xc4r\xc4s_t\xc3u\xb4v\xc1w\xc2x\xb3y\xf3z\xf2{\xe3|\xd8}\x9c~\xfe",
-		EnterAcs:     "\x1b[12m",
-		ExitAcs:      "\x1b[10m",
-		SetCursor:    "\x1b[%i%p1%d;%p2%dH",
-		CursorBack1:  "\x1b[D",
-		CursorUp1:    "\x1b[A",
-		KeyUp:        "\x1b[A",
-		KeyDown:      "\x1b[B",
-		KeyRight:     "\x1b[C",
-		KeyLeft:      "\x1b[D",
-		KeyBackspace: "\b",
-		KeyHome:      "\x1b[H",
+		Name:           "pcansi",
+		Columns:        80,
+		Lines:          24,
+		Colors:         8,
+		Bell:           "\a",
+		Clear:          "\x1b[H\x1b[J",
+		AttrOff:        "\x1b[0;10m",
+		Underline:      "\x1b[4m",
+		Bold:           "\x1b[1m",
+		Blink:          "\x1b[5m",
+		Reverse:        "\x1b[7m",
+		SetFg:          "\x1b[3%p1%dm",
+		SetBg:          "\x1b[4%p1%dm",
+		SetFgBg:        "\x1b[3%p1%d;4%p2%dm",
+		PadChar:        "\x00",
+		AltChars:       "+\x10,\x11-\x18.\x190\xdb`\x04a\xb1f\xf8g\xf1h\xb0j\xd9k\xbfl\xdam\xc0n\xc5o~p\xc4q\xc4r\xc4s_t\xc3u\xb4v\xc1w\xc2x\xb3y\xf3z\xf2{\xe3|\xd8}\x9c~\xfe",
+		EnterAcs:       "\x1b[12m",
+		ExitAcs:        "\x1b[10m",
+		SetCursor:      "\x1b[%i%p1%d;%p2%dH",
+		CursorBack1:    "\x1b[D",
+		CursorUp1:      "\x1b[A",
+		CursorDown1:    "\x1b[B",
+		CursorForward1: "\x1b[C",
+		CursorHome:     "\x1b[H",
+		CarriageReturn: "\r",
+		ClearToEOL:     "\x1b[K",
+		ExitUnderline:  "\x1b[m",
+		ResetFgBg:      "\x1b[37;40m",
+		KeyUp:          "\x1b[A",
+		KeyDown:        "\x1b[B",
+		KeyRight:       "\x1b[C",
+		KeyLeft:        "\x1b[D",
+		KeyBackspace:   "\b",
+		KeyHome:        "\x1b[H",
 	})
 	AddTerminfo(&Terminfo{
-		Name:         "rxvt",
-		Columns:      80,
-		Lines:        24,
-		Colors:       8,
-		Bell:         "\a",
-		Clear:        "\x1b[H\x1b[2J",
-		EnterCA:      "\x1b7\x1b[?47h",
-		ExitCA:       "\x1b[2J\x1b[?47l\x1b8",
-		ShowCursor:   "\x1b[?25h",
-		HideCursor:   "\x1b[?25l",
-		AttrOff:      "\x1b[m\x0f",
-		Underline:    "\x1b[4m",
-		Bold:         "\x1b[1m",
-		Blink:        "\x1b[5m",
-		Reverse:      "\x1b[7m",
-		EnterKeypad:  "\x1b=",
-		ExitKeypad:   "\x1b>",
-		SetFg:        "\x1b[3%p1%dm",
-		SetBg:        "\x1b[4%p1%dm",
-		SetFgBg:      "\x1b[3%p1%d;4%p2%dm",
-		PadChar:      "\x00",
-		AltChars:     "``aaffggjjkkllmmnnooppqqrrssttuuvvwwxxyyzz{{||}}~~",
-		EnterAcs:     "\x0e",
-		ExitAcs:      "\x0f",
-		EnableAcs:    "\x1b(B\x1b)0",
-		Mouse:        "\x1b[M",
-		MouseMode:    "%?%p1%{1}%=%t%'h'%Pa%e%'l'%Pa%;\x1b[?1000%ga%c\x1b[?1002%ga%c\x1b[?1003%ga%c\x1b[?1006%ga%c",
-		SetCursor:    "\x1b[%i%p1%d;%p2%dH",
-		CursorBack1:  "\b",
-		CursorUp1:    "\x1b[A",
-		KeyUp:        "\x1b[A",
-		KeyDown:      "\x1b[B",
-		KeyRight:     "\x1b[C",
-		KeyLeft:      "\x1b[D",
-		KeyInsert:    "\x1b[2~",
-		KeyDelete:    "\x1b[3~",
-		KeyBackspace: "\b",
-		KeyHome:      "\x1b[7~",
-		KeyEnd:       "\x1b[8~",
-		KeyPgUp:      "\x1b[5~",
-		KeyPgDn:      "\x1b[6~",
-		KeyF1:        "\x1b[11~",
-		KeyF2:        "\x1b[12~",
-		KeyF3:        "\x1b[13~",
-		KeyF4:        "\x1b[14~",
-		KeyF5:        "\x1b[15~",
-		KeyF6:        "\x1b[17~",
-		KeyF7:        "\x1b[18~",
-		KeyF8:        "\x1b[19~",
-		KeyF9:        "\x1b[20~",
-		KeyF10:       "\x1b[21~",
-		KeyF11:       "\x1b[23~",
-		KeyF12:       "\x1b[24~",
-		KeyF13:       "\x1b[25~",
-		KeyF14:       "\x1b[26~",
-		KeyF15:       "\x1b[28~",
-		KeyF16:       "\x1b[29~",
-		KeyF17:       "\x1b[31~",
-		KeyF18:       "\x1b[32~",
-		KeyF19:       "\x1b[33~",
-		KeyF20:       "\x1b[34~",
-		KeyF21:       "\x1b[23$",
-		KeyF22:       "\x1b[24$",
-		KeyF23:       "\x1b[11^",
-		KeyF24:       "\x1b[12^",
-		KeyF25:       "\x1b[13^",
-		KeyF26:       "\x1b[14^",
-		KeyF27:       "\x1b[15^",
-		KeyF28:       "\x1b[17^",
-		KeyF29:       "\x1b[18^",
-		KeyF30:       "\x1b[19^",
-		KeyF31:       "\x1b[20^",
-		KeyF32:       "\x1b[21^",
-		KeyF33:       "\x1b[23^",
-		KeyF34:       "\x1b[24^",
-		KeyF35:       "\x1b[25^",
-		KeyF36:       "\x1b[26^",
-		KeyF37:       "\x1b[28^",
-		KeyF38:       "\x1b[29^",
-		KeyF39:       "\x1b[31^",
-		KeyF40:       "\x1b[32^",
-		KeyF41:       "\x1b[33^",
-		KeyF42:       "\x1b[34^",
-		KeyF43:       "\x1b[23@",
-		KeyF44:       "\x1b[24@",
-		KeyBacktab:   "\x1b[Z",
-		KeyShfLeft:   "\x1b[d",
-		KeyShfRight:  "\x1b[c",
-		KeyShfUp:     "\x1b[a",
-		KeyShfDown:   "\x1b[b",
-		KeyCtrlLeft:  "\x1b[Od",
-		KeyCtrlRight: "\x1b[Oc",
-		KeyCtrlUp:    "\x1b[Oa",
-		KeyCtrlDown:  "\x1b[Ob",
-		KeyShfHome:   "\x1b[7$",
-		KeyShfEnd:    "\x1b[8$",
-		KeyCtrlHome:  "\x1b[7^",
-		KeyCtrlEnd:   "\x1b[8^",
+		Name:           "rxvt",
+		Columns:        80,
+		Lines:          24,
+		Colors:         8,
+		Bell:           "\a",
+		Flash:          "\x1b[?5h$<100/>\x1b[?5l",
+		Clear:          "\x1b[H\x1b[2J",
+		EnterCA:        "\x1b7\x1b[?47h",
+		ExitCA:         "\x1b[2J\x1b[?47l\x1b8",
+		ShowCursor:     "\x1b[?25h",
+		HideCursor:     "\x1b[?25l",
+		AttrOff:        "\x1b[m\x0f",
+		Underline:      "\x1b[4m",
+		Bold:           "\x1b[1m",
+		Blink:          "\x1b[5m",
+		Reverse:        "\x1b[7m",
+		EnterKeypad:    "\x1b=",
+		ExitKeypad:     "\x1b>",
+		SetFg:          "\x1b[3%p1%dm",
+		SetBg:          "\x1b[4%p1%dm",
+		SetFgBg:        "\x1b[3%p1%d;4%p2%dm",
+		PadChar:        "\x00",
+		AltChars:       "``aaffggjjkkllmmnnooppqqrrssttuuvvwwxxyyzz{{||}}~~",
+		EnterAcs:       "\x0e",
+		ExitAcs:        "\x0f",
+		EnableAcs:      "\x1b(B\x1b)0",
+		Mouse:          "\x1b[M",
+		MouseMode:      "%?%p1%{1}%=%t%'h'%Pa%e%'l'%Pa%;\x1b[?1000%ga%c\x1b[?1002%ga%c\x1b[?1003%ga%c\x1b[?1006%ga%c",
+		SetCursor:      "\x1b[%i%p1%d;%p2%dH",
+		CursorBack1:    "\b",
+		CursorUp1:      "\x1b[A",
+		CursorDown1:    "\n",
+		CursorForward1: "\x1b[C",
+		CursorBack:     "\x1b[%p1%dD",
+		CursorUp:       "\x1b[%p1%dA",
+		CursorDown:     "\x1b[%p1%dB",
+		CursorForward:  "\x1b[%p1%dC",
+		CursorHome:     "\x1b[H",
+		CarriageReturn: "\r",
+		ColumnAddress:  "\x1b[%i%p1%dG",
+		RowAddress:     "\x1b[%i%p1%dd",
+		ClearToEOL:     "\x1b[K",
+		BackColorErase: true,
+		ExitUnderline:  "\x1b[24m",
+		ResetFgBg:      "\x1b[39;49m",
+		EnablePaste:    "\x1b[?2004h",
+		DisablePaste:   "\x1b[?2004l",
+		PasteStart:     "\x1b[200~",
+		PasteEnd:       "\x1b[201~",
+		EnableFocus:    "\x1b[?1004h",
+		DisableFocus:   "\x1b[?1004l",
+		FocusIn:        "\x1b[I",
+		FocusOut:       "\x1b[O",
+		KeyUp:          "\x1b[A",
+		KeyDown:        "\x1b[B",
+		KeyRight:       "\x1b[C",
+		KeyLeft:        "\x1b[D",
+		KeyInsert:      "\x1b[2~",
+		KeyDelete:      "\x1b[3~",
+		KeyBackspace:   "\b",
+		KeyHome:        "\x1b[7~",
+		KeyEnd:         "\x1b[8~",
+		KeyPgUp:        "\x1b[5~",
+		KeyPgDn:        "\x1b[6~",
+		KeyF1:          "\x1b[11~",
+		KeyF2:          "\x1b[12~",
+		KeyF3:          "\x1b[13~",
+		KeyF4:          "\x1b[14~",
+		KeyF5:          "\x1b[15~",
+		KeyF6:          "\x1b[17~",
+		KeyF7:          "\x1b[18~",
+		KeyF8:          "\x1b[19~",
+		KeyF9:          "\x1b[20~",
+		KeyF10:         "\x1b[21~",
+		KeyF11:         "\x1b[23~",
+		KeyF12:         "\x1b[24~",
+		KeyF13:         "\x1b[25~",
+		KeyF14:         "\x1b[26~",
+		KeyF15:         "\x1b[28~",
+		KeyF16:         "\x1b[29~",
+		KeyF17:         "\x1b[31~",
+		KeyF18:         "\x1b[32~",
+		KeyF19:         "\x1b[33~",
+		KeyF20:         "\x1b[34~",
+		KeyF21:         "\x1b[23$",
+		KeyF22:         "\x1b[24$",
+		KeyF23:         "\x1b[11^",
+		KeyF24:         "\x1b[12^",
+		KeyF25:         "\x1b[13^",
+		KeyF26:         "\x1b[14^",
+		KeyF27:         "\x1b[15^",
+		KeyF28:         "\x1b[17^",
+		KeyF29:         "\x1b[18^",
+		KeyF30:         "\x1b[19^",
+		KeyF31:         "\x1b[20^",
+		KeyF32:         "\x1b[21^",
+		KeyF33:         "\x1b[23^",
+		KeyF34:         "\x1b[24^",
+		KeyF35:         "\x1b[25^",
+		KeyF36:         "\x1b[26^",
+		KeyF37:         "\x1b[28^",
+		KeyF38:         "\x1b[29^",
+		KeyF39:         "\x1b[31^",
+		KeyF40:         "\x1b[32^",
+		KeyF41:         "\x1b[33^",
+		KeyF42:         "\x1b[34^",
+		KeyF43:         "\x1b[23@",
+		KeyF44:         "\x1b[24@",
+		KeyBacktab:     "\x1b[Z",
+		KeyShfLeft:     "\x1b[d",
+		KeyShfRight:    "\x1b[c",
+		KeyShfUp:       "\x1b[a",
+		KeyShfDown:     "\x1b[b",
+		KeyCtrlLeft:    "\x1b[Od",
+		KeyCtrlRight:   "\x1b[Oc",
+		KeyCtrlUp:      "\x1b[Oa",
+		KeyCtrlDown:    "\x1b[Ob",
+		KeyShfHome:     "\x1b[7$",
+		KeyShfEnd:      "\x1b[8$",
+		KeyCtrlHome:    "\x1b[7^",
+		KeyCtrlEnd:     "\x1b[8^",
 	})
 	AddTerminfo(&Terminfo{
-		Name:         "rxvt-256color",
-		Columns:      80,
-		Lines:        24,
-		Colors:       256,
-		Bell:         "\a",
-		Clear:        "\x1b[H\x1b[2J",
-		EnterCA:      "\x1b7\x1b[?47h",
-		ExitCA:       "\x1b[2J\x1b[?47l\x1b8",
-		ShowCursor:   "\x1b[?25h",
-		HideCursor:   "\x1b[?25l",
-		AttrOff:      "\x1b[m\x0f",
-		Underline:    "\x1b[4m",
-		Bold:         "\x1b[1m",
-		Blink:        "\x1b[5m",
-		Reverse:      "\x1b[7m",
-		EnterKeypad:  "\x1b=",
-		ExitKeypad:   "\x1b>",
-		SetFg:        "\x1b[%?%p1%{8}%<%t3%p1%d%e%p1%{16}%<%t9%p1%{8}%-%d%e38;5;%p1%d%;m",
-		SetBg:        "\x1b[%?%p1%{8}%<%t4%p1%d%e%p1%{16}%<%t10%p1%{8}%-%d%e48;5;%p1%d%;m",
-		SetFgBg:      "\x1b[%?%p1%{8}%<%t3%p1%d%e%p1%{16}%<%t9%p1%{8}%-%d%e38;5;%p1%d%;;%?%p2%{8}%<%t4%p2%d%e%p2%{16}%<%t10%p2%{8}%-%d%e48;5;%p2%d%;m",
-		PadChar:      "\x00",
-		AltChars:     "``aaffggjjkkllmmnnooppqqrrssttuuvvwwxxyyzz{{||}}~~",
-		EnterAcs:     "\x0e",
-		ExitAcs:      "\x0f",
-		EnableAcs:    "\x1b(B\x1b)0",
-		Mouse:        "\x1b[M",
-		MouseMode:    "%?%p1%{1}%=%t%'h'%Pa%e%'l'%Pa%;\x1b[?1000%ga%c\x1b[?1002%ga%c\x1b[?1003%ga%c\x1b[?1006%ga%c",
-		SetCursor:    "\x1b[%i%p1%d;%p2%dH",
-		CursorBack1:  "\b",
-		CursorUp1:    "\x1b[A",
-		KeyUp:        "\x1b[A",
-		KeyDown:      "\x1b[B",
-		KeyRight:     "\x1b[C",
-		KeyLeft:      "\x1b[D",
-		KeyInsert:    "\x1b[2~",
-		KeyDelete:    "\x1b[3~",
-		KeyBackspace: "\b",
-		KeyHome:      "\x1b[7~",
-		KeyEnd:       "\x1b[8~",
-		KeyPgUp:      "\x1b[5~",
-		KeyPgDn:      "\x1b[6~",
-		KeyF1:        "\x1b[11~",
-		KeyF2:        "\x1b[12~",
-		KeyF3:        "\x1b[13~",
-		KeyF4:        "\x1b[14~",
-		KeyF5:        "\x1b[15~",
-		KeyF6:        "\x1b[17~",
-		KeyF7:        "\x1b[18~",
-		KeyF8:        "\x1b[19~",
-		KeyF9:        "\x1b[20~",
-		KeyF10:       "\x1b[21~",
-		KeyF11:       "\x1b[23~",
-		KeyF12:       "\x1b[24~",
-		KeyF13:       "\x1b[25~",
-		KeyF14:       "\x1b[26~",
-		KeyF15:       "\x1b[28~",
-		KeyF16:       "\x1b[29~",
-		KeyF17:       "\x1b[31~",
-		KeyF18:       "\x1b[32~",
-		KeyF19:       "\x1b[33~",
-		KeyF20:       "\x1b[34~",
-		KeyF21:       "\x1b[23$",
-		KeyF22:       "\x1b[24$",
-		KeyF23:       "\x1b[11^",
-		KeyF24:       "\x1b[12^",
-		KeyF25:       "\x1b[13^",
-		KeyF26:       "\x1b[14^",
-		KeyF27:       "\x1b[15^",
-		KeyF28:       "\x1b[17^",
-		KeyF29:       "\x1b[18^",
-		KeyF30:       "\x1b[19^",
-		KeyF31:       "\x1b[20^",
-		KeyF32:       "\x1b[21^",
-		KeyF33:       "\x1b[23^",
-		KeyF34:       "\x1b[24^",
-		KeyF35:       "\x1b[25^",
-		KeyF36:       "\x1b[26^",
-		KeyF37:       "\x1b[28^",
-		KeyF38:       "\x1b[29^",
-		KeyF39:       "\x1b[31^",
-		KeyF40:       "\x1b[32^",
-		KeyF41:       "\x1b[33^",
-		KeyF42:       "\x1b[34^",
-		KeyF43:       "\x1b[23@",
-		KeyF44:       "\x1b[24@",
-		KeyBacktab:   "\x1b[Z",
-		KeyShfLeft:   "\x1b[d",
-		KeyShfRight:  "\x1b[c",
-		KeyShfUp:     "\x1b[a",
-		KeyShfDown:   "\x1b[b",
-		KeyCtrlLeft:  "\x1b[Od",
-		KeyCtrlRight: "\x1b[Oc",
-		KeyCtrlUp:    "\x1b[Oa",
-		KeyCtrlDown:  "\x1b[Ob",
-		KeyShfHome:   "\x1b[7$",
-		KeyShfEnd:    "\x1b[8$",
-		KeyCtrlHome:  "\x1b[7^",
-		KeyCtrlEnd:   "\x1b[8^",
+		Name:           "rxvt-256color",
+		Columns:        80,
+		Lines:          24,
+		Colors:         256,
+		Bell:           "\a",
+		Flash:          "\x1b[?5h$<100/>\x1b[?5l",
+		Clear:          "\x1b[H\x1b[2J",
+		EnterCA:        "\x1b7\x1b[?47h",
+		ExitCA:         "\x1b[2J\x1b[?47l\x1b8",
+		ShowCursor:     "\x1b[?25h",
+		HideCursor:     "\x1b[?25l",
+		AttrOff:        "\x1b[m\x0f",
+		Underline:      "\x1b[4m",
+		Bold:           "\x1b[1m",
+		Blink:          "\x1b[5m",
+		Reverse:        "\x1b[7m",
+		EnterKeypad:    "\x1b=",
+		ExitKeypad:     "\x1b>",
+		SetFg:          "\x1b[%?%p1%{8}%<%t3%p1%d%e%p1%{16}%<%t9%p1%{8}%-%d%e38;5;%p1%d%;m",
+		SetBg:          "\x1b[%?%p1%{8}%<%t4%p1%d%e%p1%{16}%<%t10%p1%{8}%-%d%e48;5;%p1%d%;m",
+		SetFgBg:        "\x1b[%?%p1%{8}%<%t3%p1%d%e%p1%{16}%<%t9%p1%{8}%-%d%e38;5;%p1%d%;;%?%p2%{8}%<%t4%p2%d%e%p2%{16}%<%t10%p2%{8}%-%d%e48;5;%p2%d%;m",
+		PadChar:        "\x00",
+		AltChars:       "``aaffggjjkkllmmnnooppqqrrssttuuvvwwxxyyzz{{||}}~~",
+		EnterAcs:       "\x0e",
+		ExitAcs:        "\x0f",
+		EnableAcs:      "\x1b(B\x1b)0",
+		Mouse:          "\x1b[M",
+		MouseMode:      "%?%p1%{1}%=%t%'h'%Pa%e%'l'%Pa%;\x1b[?1000%ga%c\x1b[?1002%ga%c\x1b[?1003%ga%c\x1b[?1006%ga%c",
+		SetCursor:      "\x1b[%i%p1%d;%p2%dH",
+		CursorBack1:    "\b",
+		CursorUp1:      "\x1b[A",
+		CursorDown1:    "\n",
+		CursorForward1: "\x1b[C",
+		CursorBack:     "\x1b[%p1%dD",
+		CursorUp:       "\x1b[%p1%dA",
+		CursorDown:     "\x1b[%p1%dB",
+		CursorForward:  "\x1b[%p1%dC",
+		CursorHome:     "\x1b[H",
+		CarriageReturn: "\r",
+		ColumnAddress:  "\x1b[%i%p1%dG",
+		RowAddress:     "\x1b[%i%p1%dd",
+		ClearToEOL:     "\x1b[K",
+		BackColorErase: true,
+		ExitUnderline:  "\x1b[24m",
+		ResetFgBg:      "\x1b[39;49m",
+		EnablePaste:    "\x1b[?2004h",
+		DisablePaste:   "\x1b[?2004l",
+		PasteStart:     "\x1b[200~",
+		PasteEnd:       "\x1b[201~",
+		EnableFocus:    "\x1b[?1004h",
+		DisableFocus:   "\x1b[?1004l",
+		FocusIn:        "\x1b[I",
+		FocusOut:       "\x1b[O",
+		KeyUp:          "\x1b[A",
+		KeyDown:        "\x1b[B",
+		KeyRight:       "\x1b[C",
+		KeyLeft:        "\x1b[D",
+		KeyInsert:      "\x1b[2~",
+		KeyDelete:      "\x1b[3~",
+		KeyBackspace:   "\b",
+		KeyHome:        "\x1b[7~",
+		KeyEnd:         "\x1b[8~",
+		KeyPgUp:        "\x1b[5~",
+		KeyPgDn:        "\x1b[6~",
+		KeyF1:          "\x1b[11~",
+		KeyF2:          "\x1b[12~",
+		KeyF3:          "\x1b[13~",
+		KeyF4:          "\x1b[14~",
+		KeyF5:          "\x1b[15~",
+		KeyF6:          "\x1b[17~",
+		KeyF7:          "\x1b[18~",
+		KeyF8:          "\x1b[19~",
+		KeyF9:          "\x1b[20~",
+		KeyF10:         "\x1b[21~",
+		KeyF11:         "\x1b[23~",
+		KeyF12:         "\x1b[24~",
+		KeyF13:         "\x1b[25~",
+		KeyF14:         "\x1b[26~",
+		KeyF15:         "\x1b[28~",
+		KeyF16:         "\x1b[29~",
+		KeyF17:         "\x1b[31~",
+		KeyF18:         "\x1b[32~",
+		KeyF19:         "\x1b[33~",
+		KeyF20:         "\x1b[34~",
+		KeyF21:         "\x1b[23$",
+		KeyF22:         "\x1b[24$",
+		KeyF23:         "\x1b[11^",
+		KeyF24:         "\x1b[12^",
+		KeyF25:         "\x1b[13^",
+		KeyF26:         "\x1b[14^",
+		KeyF27:         "\x1b[15^",
+		KeyF28:         "\x1b[17^",
+		KeyF29:         "\x1b[18^",
+		KeyF30:         "\x1b[19^",
+		KeyF31:         "\x1b[20^",
+		KeyF32:         "\x1b[21^",
+		KeyF33:         "\x1b[23^",
+		KeyF34:         "\x1b[24^",
+		KeyF35:         "\x1b[25^",
+		KeyF36:         "\x1b[26^",
+		KeyF37:         "\x1b[28^",
+		KeyF38:         "\x1b[29^",
+		KeyF39:         "\x1b[31^",
+		KeyF40:         "\x1b[32^",
+		KeyF41:         "\x1b[33^",
+		KeyF42:         "\x1b[34^",
+		KeyF43:         "\x1b[23@",
+		KeyF44:         "\x1b[24@",
+		KeyBacktab:     "\x1b[Z",
+		KeyShfLeft:     "\x1b[d",
+		KeyShfRight:    "\x1b[c",
+		KeyShfUp:       "\x1b[a",
+		KeyShfDown:     "\x1b[b",
+		KeyCtrlLeft:    "\x1b[Od",
+		KeyCtrlRight:   "\x1b[Oc",
+		KeyCtrlUp:      "\x1b[Oa",
+		KeyCtrlDown:    "\x1b[Ob",
+		KeyShfHome:     "\x1b[7$",
+		KeyShfEnd:      "\x1b[8$",
+		KeyCtrlHome:    "\x1b[7^",
+		KeyCtrlEnd:     "\x1b[8^",
 	})
 	AddTerminfo(&Terminfo{
-		Name:         "rxvt-unicode",
-		Columns:      80,
-		Lines:        24,
-		Colors:       88,
-		Bell:         "\a",
-		Clear:        "\x1b[H\x1b[2J",
-		EnterCA:      "\x1b[?1049h",
-		ExitCA:       "\x1b[r\x1b[?1049l",
-		ShowCursor:   "\x1b[?12l\x1b[?25h",
-		HideCursor:   "\x1b[?25l",
-		AttrOff:      "\x1b[m\x1b(B",
-		Underline:    "\x1b[4m",
-		Bold:         "\x1b[1m",
-		Blink:        "\x1b[5m",
-		Reverse:      "\x1b[7m",
-		EnterKeypad:  "\x1b=",
-		ExitKeypad:   "\x1b>",
-		SetFg:        "\x1b[38;5;%p1%dm",
-		SetBg:        "\x1b[48;5;%p1%dm",
-		SetFgBg:      "\x1b[38;5;%p1%d;48;5;%p2%dm",
-		AltChars:     "``aaffggjjkkllmmnnooppqqrrssttuuvvwwxxyyzz{{||}}~~-A.B+C,D0EhFiG",
-		EnterAcs:     "\x1b(0",
-		ExitAcs:      "\x1b(B",
-		Mouse:        "\x1b[M",
-		MouseMode:    "%?%p1%{1}%=%t%'h'%Pa%e%'l'%Pa%;\x1b[?1000%ga%c\x1b[?1002%ga%c\x1b[?1003%ga%c\x1b[?1006%ga%c",
-		SetCursor:    "\x1b[%i%p1%d;%p2%dH",
-		CursorBack1:  "\b",
-		CursorUp1:    "\x1b[A",
-		KeyUp:        "\x1b[A",
-		KeyDown:      "\x1b[B",
-		KeyRight:     "\x1b[C",
-		KeyLeft:      "\x1b[D",
-		KeyInsert:    "\x1b[2~",
-		KeyDelete:    "\x1b[3~",
-		KeyBackspace: "\u007f",
-		KeyHome:      "\x1b[7~",
-		KeyEnd:       "\x1b[8~",
-		KeyPgUp:      "\x1b[5~",
-		KeyPgDn:      "\x1b[6~",
-		KeyF1:        "\x1b[11~",
-		KeyF2:        "\x1b[12~",
-		KeyF3:        "\x1b[13~",
-		KeyF4:        "\x1b[14~",
-		KeyF5:        "\x1b[15~",
-		KeyF6:        "\x1b[17~",
-		KeyF7:        "\x1b[18~",
-		KeyF8:        "\x1b[19~",
-		KeyF9:        "\x1b[20~",
-		KeyF10:       "\x1b[21~",
-		KeyF11:       "\x1b[23~",
-		KeyF12:       "\x1b[24~",
-		KeyF13:       "\x1b[25~",
-		KeyF14:       "\x1b[26~",
-		KeyF15:       "\x1b[28~",
-		KeyF16:       "\x1b[29~",
-		KeyF17:       "\x1b[31~",
-		KeyF18:       "\x1b[32~",
-		KeyF19:       "\x1b[33~",
-		KeyF20:       "\x1b[34~",
-		KeyBacktab:   "\x1b[Z",
-		KeyShfLeft:   "\x1b[d",
-		KeyShfRight:  "\x1b[c",
-		KeyShfUp:     "\x1b[a",
-		KeyShfDown:   "\x1b[b",
-		KeyCtrlLeft:  "\x1b[Od",
-		KeyCtrlRight: "\x1b[Oc",
-		KeyCtrlUp:    "\x1b[Oa",
-		KeyCtrlDown:  "\x1b[Ob",
-		KeyShfHome:   "\x1b[7$",
-		KeyShfEnd:    "\x1b[8$",
-		KeyCtrlHome:  "\x1b[7^",
-		KeyCtrlEnd:   "\x1b[8^",
+		Name:           "rxvt-unicode",
+		Columns:        80,
+		Lines:          24,
+		Colors:         88,
+		Bell:           "\a",
+		Flash:          "\x1b[?5h$<20/>\x1b[?5l",
+		Clear:          "\x1b[H\x1b[2J",
+		EnterCA:        "\x1b[?1049h",
+		ExitCA:         "\x1b[r\x1b[?1049l",
+		ShowCursor:     "\x1b[?12l\x1b[?25h",
+		HideCursor:     "\x1b[?25l",
+		AttrOff:        "\x1b[m\x1b(B",
+		Underline:      "\x1b[4m",
+		Bold:           "\x1b[1m",
+		Blink:          "\x1b[5m",
+		Reverse:        "\x1b[7m",
+		EnterKeypad:    "\x1b=",
+		ExitKeypad:     "\x1b>",
+		SetFg:          "\x1b[38;5;%p1%dm",
+		SetBg:          "\x1b[48;5;%p1%dm",
+		SetFgBg:        "\x1b[38;5;%p1%d;48;5;%p2%dm",
+		AltChars:       "``aaffggjjkkllmmnnooppqqrrssttuuvvwwxxyyzz{{||}}~~-A.B+C,D0EhFiG",
+		EnterAcs:       "\x1b(0",
+		ExitAcs:        "\x1b(B",
+		Mouse:          "\x1b[M",
+		MouseMode:      "%?%p1%{1}%=%t%'h'%Pa%e%'l'%Pa%;\x1b[?1000%ga%c\x1b[?1002%ga%c\x1b[?1003%ga%c\x1b[?1006%ga%c",
+		SetCursor:      "\x1b[%i%p1%d;%p2%dH",
+		CursorBack1:    "\b",
+		CursorUp1:      "\x1b[A",
+		CursorDown1:    "\n",
+		CursorForward1: "\x1b[C",
+		CursorBack:     "\x1b[%p1%dD",
+		CursorUp:       "\x1b[%p1%dA",
+		CursorDown:     "\x1b[%p1%dB",
+		CursorForward:  "\x1b[%p1%dC",
+		CursorHome:     "\x1b[H",
+		CarriageReturn: "\r",
+		ColumnAddress:  "\x1b[%i%p1%dG",
+		RowAddress:     "\x1b[%i%p1%dd",
+		ClearToEOL:     "\x1b[K",
+		EraseChars:     "\x1b[%p1%dX",
+		BackColorErase: true,
+		ExitUnderline:  "\x1b[24m",
+		ResetFgBg:      "\x1b[39;49m",
+		EnablePaste:    "\x1b[?2004h",
+		DisablePaste:   "\x1b[?2004l",
+		PasteStart:     "\x1b[200~",
+		PasteEnd:       "\x1b[201~",
+		EnableFocus:    "\x1b[?1004h",
+		DisableFocus:   "\x1b[?1004l",
+		FocusIn:        "\x1b[I",
+		FocusOut:       "\x1b[O",
+		TitleStart:     "\x1b]2;",
+		TitleEnd:       "\a",
+		KeyUp:          "\x1b[A",
+		KeyDown:        "\x1b[B",
+		KeyRight:       "\x1b[C",
+		KeyLeft:        "\x1b[D",
+		KeyInsert:      "\x1b[2~",
+		KeyDelete:      "\x1b[3~",
+		KeyBackspace:   "\u007f",
+		KeyHome:        "\x1b[7~",
+		KeyEnd:         "\x1b[8~",
+		KeyPgUp:        "\x1b[5~",
+		KeyPgDn:        "\x1b[6~",
+		KeyF1:          "\x1b[11~",
+		KeyF2:          "\x1b[12~",
+		KeyF3:          "\x1b[13~",
+		KeyF4:          "\x1b[14~",
+		KeyF5:          "\x1b[15~",
+		KeyF6:          "\x1b[17~",
+		KeyF7:          "\x1b[18~",
+		KeyF8:          "\x1b[19~",
+		KeyF9:          "\x1b[20~",
+		KeyF10:         "\x1b[21~",
+		KeyF11:         "\x1b[23~",
+		KeyF12:         "\x1b[24~",
+		KeyF13:         "\x1b[25~",
+		KeyF14:         "\x1b[26~",
+		KeyF15:         "\x1b[28~",
+		KeyF16:         "\x1b[29~",
+		KeyF17:         "\x1b[31~",
+		KeyF18:         "\x1b[32~",
+		KeyF19:         "\x1b[33~",
+		KeyF20:         "\x1b[34~",
+		KeyBacktab:     "\x1b[Z",
+		KeyShfLeft:     "\x1b[d",
+		KeyShfRight:    "\x1b[c",
+		KeyShfUp:       "\x1b[a",
+		KeyShfDown:     "\x1b[b",
+		KeyCtrlLeft:    "\x1b[Od",
+		KeyCtrlRight:   "\x1b[Oc",
+		KeyCtrlUp:      "\x1b[Oa",
+		KeyCtrlDown:    "\x1b[Ob",
+		KeyShfHome:     "\x1b[7$",
+		KeyShfEnd:      "\x1b[8$",
+		KeyCtrlHome:    "\x1b[7^",
+		KeyCtrlEnd:     "\x1b[8^",
 	})
 	AddTerminfo(&Terminfo{
-		Name:         "rxvt-unicode-256color",
-		Columns:      80,
-		Lines:        24,
-		Colors:       256,
-		Bell:         "\a",
-		Clear:        "\x1b[H\x1b[2J",
-		EnterCA:      "\x1b[?1049h",
-		ExitCA:       "\x1b[r\x1b[?1049l",
-		ShowCursor:   "\x1b[?12l\x1b[?25h",
-		HideCursor:   "\x1b[?25l",
-		AttrOff:      "\x1b[m\x1b(B",
-		Underline:    "\x1b[4m",
-		Bold:         "\x1b[1m",
-		Blink:        "\x1b[5m",
-		Reverse:      "\x1b[7m",
-		EnterKeypad:  "\x1b=",
-		ExitKeypad:   "\x1b>",
-		SetFg:        "\x1b[38;5;%p1%dm",
-		SetBg:        "\x1b[48;5;%p1%dm",
-		SetFgBg:      "\x1b[38;5;%p1%d;48;5;%p2%dm",
-		AltChars:     "``aaffggjjkkllmmnnooppqqrrssttuuvvwwxxyyzz{{||}}~~-A.B+C,D0EhFiG",
-		EnterAcs:     "\x1b(0",
-		ExitAcs:      "\x1b(B",
-		Mouse:        "\x1b[M",
-		MouseMode:    "%?%p1%{1}%=%t%'h'%Pa%e%'l'%Pa%;\x1b[?1000%ga%c\x1b[?1002%ga%c\x1b[?1003%ga%c\x1b[?1006%ga%c",
-		SetCursor:    "\x1b[%i%p1%d;%p2%dH",
-		CursorBack1:  "\b",
-		CursorUp1:    "\x1b[A",
-		KeyUp:        "\x1b[A",
-		KeyDown:      "\x1b[B",
-		KeyRight:     "\x1b[C",
-		KeyLeft:      "\x1b[D",
-		KeyInsert:    "\x1b[2~",
-		KeyDelete:    "\x1b[3~",
-		KeyBackspace: "\u007f",
-		KeyHome:      "\x1b[7~",
-		KeyEnd:       "\x1b[8~",
-		KeyPgUp:      "\x1b[5~",
-		KeyPgDn:      "\x1b[6~",
-		KeyF1:        "\x1b[11~",
-		KeyF2:        "\x1b[12~",
-		KeyF3:        "\x1b[13~",
-		KeyF4:        "\x1b[14~",
-		KeyF5:        "\x1b[15~",
-		KeyF6:        "\x1b[17~",
-		KeyF7:        "\x1b[18~",
-		KeyF8:        "\x1b[19~",
-		KeyF9:        "\x1b[20~",
-		KeyF10:       "\x1b[21~",
-		KeyF11:       "\x1b[23~",
-		KeyF12:       "\x1b[24~",
-		KeyF13:       "\x1b[25~",
-		KeyF14:       "\x1b[26~",
-		KeyF15:       "\x1b[28~",
-		KeyF16:       "\x1b[29~",
-		KeyF17:       "\x1b[31~",
-		KeyF18:       "\x1b[32~",
-		KeyF19:       "\x1b[33~",
-		KeyF20:       "\x1b[34~",
-		KeyBacktab:   "\x1b[Z",
-		KeyShfLeft:   "\x1b[d",
-		KeyShfRight:  "\x1b[c",
-		KeyShfUp:     "\x1b[a",
-		KeyShfDown:   "\x1b[b",
-		KeyCtrlLeft:  "\x1b[Od",
-		KeyCtrlRight: "\x1b[Oc",
-		KeyCtrlUp:    "\x1b[Oa",
-		KeyCtrlDown:  "\x1b[Ob",
-		KeyShfHome:   "\x1b[7$",
-		KeyShfEnd:    "\x1b[8$",
-		KeyCtrlHome:  "\x1b[7^",
-		KeyCtrlEnd:   "\x1b[8^",
+		Name:           "rxvt-unicode-256color",
+		Columns:        80,
+		Lines:          24,
+		Colors:         256,
+		Bell:           "\a",
+		Flash:          "\x1b[?5h$<20/>\x1b[?5l",
+		Clear:          "\x1b[H\x1b[2J",
+		EnterCA:        "\x1b[?1049h",
+		ExitCA:         "\x1b[r\x1b[?1049l",
+		ShowCursor:     "\x1b[?12l\x1b[?25h",
+		HideCursor:     "\x1b[?25l",
+		AttrOff:        "\x1b[m\x1b(B",
+		Underline:      "\x1b[4m",
+		Bold:           "\x1b[1m",
+		Blink:          "\x1b[5m",
+		Reverse:        "\x1b[7m",
+		EnterKeypad:    "\x1b=",
+		ExitKeypad:     "\x1b>",
+		SetFg:          "\x1b[38;5;%p1%dm",
+		SetBg:          "\x1b[48;5;%p1%dm",
+		SetFgBg:        "\x1b[38;5;%p1%d;48;5;%p2%dm",
+		AltChars:       "``aaffggjjkkllmmnnooppqqrrssttuuvvwwxxyyzz{{||}}~~-A.B+C,D0EhFiG",
+		EnterAcs:       "\x1b(0",
+		ExitAcs:        "\x1b(B",
+		Mouse:          "\x1b[M",
+		MouseMode:      "%?%p1%{1}%=%t%'h'%Pa%e%'l'%Pa%;\x1b[?1000%ga%c\x1b[?1002%ga%c\x1b[?1003%ga%c\x1b[?1006%ga%c",
+		SetCursor:      "\x1b[%i%p1%d;%p2%dH",
+		CursorBack1:    "\b",
+		CursorUp1:      "\x1b[A",
+		CursorDown1:    "\n",
+		CursorForward1: "\x1b[C",
+		CursorBack:     "\x1b[%p1%dD",
+		CursorUp:       "\x1b[%p1%dA",
+		CursorDown:     "\x1b[%p1%dB",
+		CursorForward:  "\x1b[%p1%dC",
+		CursorHome:     "\x1b[H",
+		CarriageReturn: "\r",
+		ColumnAddress:  "\x1b[%i%p1%dG",
+		RowAddress:     "\x1b[%i%p1%dd",
+		ClearToEOL:     "\x1b[K",
+		EraseChars:     "\x1b[%p1%dX",
+		BackColorErase: true,
+		ExitUnderline:  "\x1b[24m",
+		ResetFgBg:      "\x1b[39;49m",
+		EnablePaste:    "\x1b[?2004h",
+		DisablePaste:   "\x1b[?2004l",
+		PasteStart:     "\x1b[200~",
+		PasteEnd:       "\x1b[201~",
+		EnableFocus:    "\x1b[?1004h",
+		DisableFocus:   "\x1b[?1004l",
+		FocusIn:        "\x1b[I",
+		FocusOut:       "\x1b[O",
+		TitleStart:     "\x1b]2;",
+		TitleEnd:       "\a",
+		KeyUp:          "\x1b[A",
+		KeyDown:        "\x1b[B",
+		KeyRight:       "\x1b[C",
+		KeyLeft:        "\x1b[D",
+		KeyInsert:      "\x1b[2~",
+		KeyDelete:      "\x1b[3~",
+		KeyBackspace:   "\u007f",
+		KeyHome:        "\x1b[7~",
+		KeyEnd:         "\x1b[8~",
+		KeyPgUp:        "\x1b[5~",
+		KeyPgDn:        "\x1b[6~",
+		KeyF1:          "\x1b[11~",
+		KeyF2:          "\x1b[12~",
+		KeyF3:          "\x1b[13~",
+		KeyF4:          "\x1b[14~",
+		KeyF5:          "\x1b[15~",
+		KeyF6:          "\x1b[17~",
+		KeyF7:          "\x1b[18~",
+		KeyF8:          "\x1b[19~",
+		KeyF9:          "\x1b[20~",
+		KeyF10:         "\x1b[21~",
+		KeyF11:         "\x1b[23~",
+		KeyF12:         "\x1b[24~",
+		KeyF13:         "\x1b[25~",
+		KeyF14:         "\x1b[26~",
+		KeyF15:         "\x1b[28~",
+		KeyF16:         "\x1b[29~",
+		KeyF17:         "\x1b[31~",
+		KeyF18:         "\x1b[32~",
+		KeyF19:         "\x1b[33~",
+		KeyF20:         "\x1b[34~",
+		KeyBacktab:     "\x1b[Z",
+		KeyShfLeft:     "\x1b[d",
+		KeyShfRight:    "\x1b[c",
+		KeyShfUp:       "\x1b[a",
+		KeyShfDown:     "\x1b[b",
+		KeyCtrlLeft:    "\x1b[Od",
+		KeyCtrlRight:   "\x1b[Oc",
+		KeyCtrlUp:      "\x1b[Oa",
+		KeyCtrlDown:    "\x1b[Ob",
+		KeyShfHome:     "\x1b[7$",
+		KeyShfEnd:      "\x1b[8$",
+		KeyCtrlHome:    "\x1b[7^",
+		KeyCtrlEnd:     "\x1b[8^",
 	})
 	AddTerminfo(&Terminfo{
-		Name:         "screen",
-		Columns:      80,
-		Lines:        24,
-		Colors:       8,
-		Bell:         "\a",
-		Clear:        "\x1b[H\x1b[J",
-		EnterCA:      "\x1b[?1049h",
-		ExitCA:       "\x1b[?1049l",
-		ShowCursor:   "\x1b[34h\x1b[?25h",
-		HideCursor:   "\x1b[?25l",
-		AttrOff:      "\x1b[m\x0f",
-		Underline:    "\x1b[4m",
-		Bold:         "\x1b[1m",
-		Blink:        "\x1b[5m",
-		Reverse:      "\x1b[7m",
-		EnterKeypad:  "\x1b[?1h\x1b=",
-		ExitKeypad:   "\x1b[?1l\x1b>",
-		SetFg:        "\x1b[3%p1%dm",
-		SetBg:        "\x1b[4%p1%dm",
-		SetFgBg:      "\x1b[3%p1%d;4%p2%dm",
-		PadChar:      "\x00",
-		AltChars:     "++,,--..00``aaffgghhiijjkkllmmnnooppqqrrssttuuvvwwxxyyzz{{||}}~~",
-		EnterAcs:     "\x0e",
-		ExitAcs:      "\x0f",
-		EnableAcs:    "\x1b(B\x1b)0",
-		Mouse:        "\x1b[M",
-		MouseMode:    "%?%p1%{1}%=%t%'h'%Pa%e%'l'%Pa%;\x1b[?1000%ga%c\x1b[?1002%ga%c\x1b[?1003%ga%c\x1b[?1006%ga%c",
-		SetCursor:    "\x1b[%i%p1%d;%p2%dH",
-		CursorBack1:  "\b",
-		CursorUp1:    "\x1bM",
-		KeyUp:        "\x1bOA",
-		KeyDown:      "\x1bOB",
-		KeyRight:     "\x1bOC",
-		KeyLeft:      "\x1bOD",
-		KeyInsert:    "\x1b[2~",
-		KeyDelete:    "\x1b[3~",
-		KeyBackspace: "\b",
-		KeyHome:      "\x1b[1~",
-		KeyEnd:       "\x1b[4~",
-		KeyPgUp:      "\x1b[5~",
-		KeyPgDn:      "\x1b[6~",
-		KeyF1:        "\x1bOP",
-		KeyF2:        "\x1bOQ",
-		KeyF3:        "\x1bOR",
-		KeyF4:        "\x1bOS",
-		KeyF5:        "\x1b[15~",
-		KeyF6:        "\x1b[17~",
-		KeyF7:        "\x1b[18~",
-		KeyF8:        "\x1b[19~",
-		KeyF9:        "\x1b[20~",
-		KeyF10:       "\x1b[21~",
-		KeyF11:       "\x1b[23~",
-		KeyF12:       "\x1b[24~",
-		KeyBacktab:   "\x1b[Z",
+		Name:           "screen",
+		Columns:        80,
+		Lines:          24,
+		Colors:         8,
+		Bell:           "\a",
+		Flash:          "\x1bg",
+		Clear:          "\x1b[H\x1b[J",
+		EnterCA:        "\x1b[?1049h",
+		ExitCA:         "\x1b[?1049l",
+		ShowCursor:     "\x1b[34h\x1b[?25h",
+		HideCursor:     "\x1b[?25l",
+		AttrOff:        "\x1b[m\x0f",
+		Underline:      "\x1b[4m",
+		Bold:           "\x1b[1m",
+		Blink:          "\x1b[5m",
+		Reverse:        "\x1b[7m",
+		EnterKeypad:    "\x1b[?1h\x1b=",
+		ExitKeypad:     "\x1b[?1l\x1b>",
+		SetFg:          "\x1b[3%p1%dm",
+		SetBg:          "\x1b[4%p1%dm",
+		SetFgBg:        "\x1b[3%p1%d;4%p2%dm",
+		PadChar:        "\x00",
+		AltChars:       "++,,--..00``aaffgghhiijjkkllmmnnooppqqrrssttuuvvwwxxyyzz{{||}}~~",
+		EnterAcs:       "\x0e",
+		ExitAcs:        "\x0f",
+		EnableAcs:      "\x1b(B\x1b)0",
+		Mouse:          "\x1b[M",
+		MouseMode:      "%?%p1%{1}%=%t%'h'%Pa%e%'l'%Pa%;\x1b[?1000%ga%c\x1b[?1002%ga%c\x1b[?1003%ga%c\x1b[?1006%ga%c",
+		SetCursor:      "\x1b[%i%p1%d;%p2%dH",
+		CursorBack1:    "\b",
+		CursorUp1:      "\x1bM",
+		CursorDown1:    "\n",
+		CursorForward1: "\x1b[C",
+		CursorBack:     "\x1b[%p1%dD",
+		CursorUp:       "\x1b[%p1%dA",
+		CursorDown:     "\x1b[%p1%dB",
+		CursorForward:  "\x1b[%p1%dC",
+		CursorHome:     "\x1b[H",
+		CarriageReturn: "\r",
+		ColumnAddress:  "\x1b[%i%p1%dG",
+		RowAddress:     "\x1b[%i%p1%dd",
+		ClearToEOL:     "\x1b[K",
+		ExitUnderline:  "\x1b[24m",
+		ResetFgBg:      "\x1b[39;49m",
+		EnablePaste:    "\x1b[?2004h",
+		DisablePaste:   "\x1b[?2004l",
+		PasteStart:     "\x1b[200~",
+		PasteEnd:       "\x1b[201~",
+		EnableFocus:    "\x1b[?1004h",
+		DisableFocus:   "\x1b[?1004l",
+		FocusIn:        "\x1b[I",
+		FocusOut:       "\x1b[O",
+		KeyUp:          "\x1bOA",
+		KeyDown:        "\x1bOB",
+		KeyRight:       "\x1bOC",
+		KeyLeft:        "\x1bOD",
+		KeyInsert:      "\x1b[2~",
+		KeyDelete:      "\x1b[3~",
+		KeyBackspace:   "\b",
+		KeyHome:        "\x1b[1~",
+		KeyEnd:         "\x1b[4~",
+		KeyPgUp:        "\x1b[5~",
+		KeyPgDn:        "\x1b[6~",
+		KeyF1:          "\x1bOP",
+		KeyF2:          "\x1bOQ",
+		KeyF3:          "\x1bOR",
+		KeyF4:          "\x1bOS",
+		KeyF5:          "\x1b[15~",
+		KeyF6:          "\x1b[17~",
+		KeyF7:          "\x1b[18~",
+		KeyF8:          "\x1b[19~",
+		KeyF9:          "\x1b[20~",
+		KeyF10:         "\x1b[21~",
+		KeyF11:         "\x1b[23~",
+		KeyF12:         "\x1b[24~",
+		KeyBacktab:     "\x1b[Z",
 	})
 	AddTerminfo(&Terminfo{
-		Name:         "screen-256color",
-		Columns:      80,
-		Lines:        24,
-		Colors:       256,
-		Bell:         "\a",
-		Clear:        "\x1b[H\x1b[J",
-		EnterCA:      "\x1b[?1049h",
-		ExitCA:       "\x1b[?1049l",
-		ShowCursor:   "\x1b[34h\x1b[?25h",
-		HideCursor:   "\x1b[?25l",
-		AttrOff:      "\x1b[m\x0f",
-		Underline:    "\x1b[4m",
-		Bold:         "\x1b[1m",
-		Blink:        "\x1b[5m",
-		Reverse:      "\x1b[7m",
-		EnterKeypad:  "\x1b[?1h\x1b=",
-		ExitKeypad:   "\x1b[?1l\x1b>",
-		SetFg:        "\x1b[%?%p1%{8}%<%t3%p1%d%e%p1%{16}%<%t9%p1%{8}%-%d%e38;5;%p1%d%;m",
-		SetBg:        "\x1b[%?%p1%{8}%<%t4%p1%d%e%p1%{16}%<%t10%p1%{8}%-%d%e48;5;%p1%d%;m",
-		SetFgBg:      "\x1b[%?%p1%{8}%<%t3%p1%d%e%p1%{16}%<%t9%p1%{8}%-%d%e38;5;%p1%d%;;%?%p2%{8}%<%t4%p2%d%e%p2%{16}%<%t10%p2%{8}%-%d%e48;5;%p2%d%;m",
-		PadChar:      "\x00",
-		AltChars:     "++,,--..00``aaffgghhiijjkkllmmnnooppqqrrssttuuvvwwxxyyzz{{||}}~~",
-		EnterAcs:     "\x0e",
-		ExitAcs:      "\x0f",
-		EnableAcs:    "\x1b(B\x1b)0",
-		Mouse:        "\x1b[M",
-		MouseMode:    "%?%p1%{1}%=%t%'h'%Pa%e%'l'%Pa%;\x1b[?1000%ga%c\x1b[?1002%ga%c\x1b[?1003%ga%c\x1b[?1006%ga%c",
-		SetCursor:    "\x1b[%i%p1%d;%p2%dH",
-		CursorBack1:  "\b",
-		CursorUp1:    "\x1bM",
-		KeyUp:        "\x1bOA",
-		KeyDown:      "\x1bOB",
-		KeyRight:     "\x1bOC",
-		KeyLeft:      "\x1bOD",
-		KeyInsert:    "\x1b[2~",
-		KeyDelete:    "\x1b[3~",
-		KeyBackspace: "\b",
-		KeyHome:      "\x1b[1~",
-		KeyEnd:       "\x1b[4~",
-		KeyPgUp:      "\x1b[5~",
-		KeyPgDn:      "\x1b[6~",
-		KeyF1:        "\x1bOP",
-		KeyF2:        "\x1bOQ",
-		KeyF3:        "\x1bOR",
-		KeyF4:        "\x1bOS",
-		KeyF5:        "\x1b[15~",
-		KeyF6:        "\x1b[17~",
-		KeyF7:        "\x1b[18~",
-		KeyF8:        "\x1b[19~",
-		KeyF9:        "\x1b[20~",
-		KeyF10:       "\x1b[21~",
-		KeyF11:       "\x1b[23~",
-		KeyF12:       "\x1b[24~",
-		KeyBacktab:   "\x1b[Z",
+		Name:           "screen-256color",
+		Columns:        80,
+		Lines:          24,
+		Colors:         256,
+		Bell:           "\a",
+		Flash:          "\x1bg",
+		Clear:          "\x1b[H\x1b[J",
+		EnterCA:        "\x1b[?1049h",
+		ExitCA:         "\x1b[?1049l",
+		ShowCursor:     "\x1b[34h\x1b[?25h",
+		HideCursor:     "\x1b[?25l",
+		AttrOff:        "\x1b[m\x0f",
+		Underline:      "\x1b[4m",
+		Bold:           "\x1b[1m",
+		Blink:          "\x1b[5m",
+		Reverse:        "\x1b[7m",
+		EnterKeypad:    "\x1b[?1h\x1b=",
+		ExitKeypad:     "\x1b[?1l\x1b>",
+		SetFg:          "\x1b[%?%p1%{8}%<%t3%p1%d%e%p1%{16}%<%t9%p1%{8}%-%d%e38;5;%p1%d%;m",
+		SetBg:          "\x1b[%?%p1%{8}%<%t4%p1%d%e%p1%{16}%<%t10%p1%{8}%-%d%e48;5;%p1%d%;m",
+		SetFgBg:        "\x1b[%?%p1%{8}%<%t3%p1%d%e%p1%{16}%<%t9%p1%{8}%-%d%e38;5;%p1%d%;;%?%p2%{8}%<%t4%p2%d%e%p2%{16}%<%t10%p2%{8}%-%d%e48;5;%p2%d%;m",
+		PadChar:        "\x00",
+		AltChars:       "++,,--..00``aaffgghhiijjkkllmmnnooppqqrrssttuuvvwwxxyyzz{{||}}~~",
+		EnterAcs:       "\x0e",
+		ExitAcs:        "\x0f",
+		EnableAcs:      "\x1b(B\x1b)0",
+		Mouse:          "\x1b[M",
+		MouseMode:      "%?%p1%{1}%=%t%'h'%Pa%e%'l'%Pa%;\x1b[?1000%ga%c\x1b[?1002%ga%c\x1b[?1003%ga%c\x1b[?1006%ga%c",
+		SetCursor:      "\x1b[%i%p1%d;%p2%dH",
+		CursorBack1:    "\b",
+		CursorUp1:      "\x1bM",
+		CursorDown1:    "\n",
+		CursorForward1: "\x1b[C",
+		CursorBack:     "\x1b[%p1%dD",
+		CursorUp:       "\x1b[%p1%dA",
+		CursorDown:     "\x1b[%p1%dB",
+		CursorForward:  "\x1b[%p1%dC",
+		CursorHome:     "\x1b[H",
+		CarriageReturn: "\r",
+		ColumnAddress:  "\x1b[%i%p1%dG",
+		RowAddress:     "\x1b[%i%p1%dd",
+		ClearToEOL:     "\x1b[K",
+		ExitUnderline:  "\x1b[24m",
+		ResetFgBg:      "\x1b[39;49m",
+		EnablePaste:    "\x1b[?2004h",
+		DisablePaste:   "\x1b[?2004l",
+		PasteStart:     "\x1b[200~",
+		PasteEnd:       "\x1b[201~",
+		EnableFocus:    "\x1b[?1004h",
+		DisableFocus:   "\x1b[?1004l",
+		FocusIn:        "\x1b[I",
+		FocusOut:       "\x1b[O",
+		KeyUp:          "\x1bOA",
+		KeyDown:        "\x1bOB",
+		KeyRight:       "\x1bOC",
+		KeyLeft:        "\x1bOD",
+		KeyInsert:      "\x1b[2~",
+		KeyDelete:      "\x1b[3~",
+		KeyBackspace:   "\b",
+		KeyHome:        "\x1b[1~",
+		KeyEnd:         "\x1b[4~",
+		KeyPgUp:        "\x1b[5~",
+		KeyPgDn:        "\x1b[6~",
+		KeyF1:          "\x1bOP",
+		KeyF2:          "\x1bOQ",
+		KeyF3:          "\x1bOR",
+		KeyF4:          "\x1bOS",
+		KeyF5:          "\x1b[15~",
+		KeyF6:          "\x1b[17~",
+		KeyF7:          "\x1b[18~",
+		KeyF8:          "\x1b[19~",
+		KeyF9:          "\x1b[20~",
+		KeyF10:         "\x1b[21~",
+		KeyF11:         "\x1b[23~",
+		KeyF12:         "\x1b[24~",
+		KeyBacktab:     "\x1b[Z",
 	})
 	AddTerminfo(&Terminfo{
-		Name:         "sun",
-		Aliases:      []string{"sun1", "sun2"},
-		Columns:      80,
-		Lines:        34,
-		Bell:         "\a",
-		Clear:        "\f",
-		AttrOff:      "\x1b[m",
-		Reverse:      "\x1b[7m",
-		PadChar:      "\x00",
-		SetCursor:    "\x1b[%i%p1%d;%p2%dH",
-		CursorBack1:  "\b",
-		CursorUp1:    "\x1b[A",
-		KeyUp:        "\x1b[A",
-		KeyDown:      "\x1b[B",
-		KeyRight:     "\x1b[C",
-		KeyLeft:      "\x1b[D",
-		KeyDelete:    "\u007f",
-		KeyBackspace: "\b",
-		KeyHome:      "\x1b[214z",
-		KeyEnd:       "\x1b[220z",
-		KeyPgUp:      "\x1b[216z",
-		KeyPgDn:      "\x1b[222z",
-		KeyF1:        "\x1b[224z",
-		KeyF2:        "\x1b[225z",
-		KeyF3:        "\x1b[226z",
-		KeyF4:        "\x1b[227z",
-		KeyF5:        "\x1b[228z",
-		KeyF6:        "\x1b[229z",
-		KeyF7:        "\x1b[230z",
-		KeyF8:        "\x1b[231z",
-		KeyF9:        "\x1b[232z",
-		KeyF10:       "\x1b[233z",
-		KeyF11:       "\x1b[234z",
-		KeyF12:       "\x1b[235z",
+		Name:           "sun",
+		Aliases:        []string{"sun1", "sun2"},
+		Columns:        80,
+		Lines:          34,
+		Bell:           "\a",
+		Clear:          "\f",
+		AttrOff:        "\x1b[m",
+		Reverse:        "\x1b[7m",
+		PadChar:        "\x00",
+		SetCursor:      "\x1b[%i%p1%d;%p2%dH",
+		CursorBack1:    "\b",
+		CursorUp1:      "\x1b[A",
+		CursorDown1:    "\n",
+		CursorForward1: "\x1b[C",
+		CarriageReturn: "\r",
+		ClearToEOL:     "\x1b[K",
+		KeyUp:          "\x1b[A",
+		KeyDown:        "\x1b[B",
+		KeyRight:       "\x1b[C",
+		KeyLeft:        "\x1b[D",
+		KeyDelete:      "\u007f",
+		KeyBackspace:   "\b",
+		KeyHome:        "\x1b[214z",
+		KeyEnd:         "\x1b[220z",
+		KeyPgUp:        "\x1b[216z",
+		KeyPgDn:        "\x1b[222z",
+		KeyF1:          "\x1b[224z",
+		KeyF2:          "\x1b[225z",
+		KeyF3:          "\x1b[226z",
+		KeyF4:          "\x1b[227z",
+		KeyF5:          "\x1b[228z",
+		KeyF6:          "\x1b[229z",
+		KeyF7:          "\x1b[230z",
+		KeyF8:          "\x1b[231z",
+		KeyF9:          "\x1b[232z",
+		KeyF10:         "\x1b[233z",
+		KeyF11:         "\x1b[234z",
+		KeyF12:         "\x1b[235z",
 	})
 	AddTerminfo(&Terminfo{
-		Name:         "sun-color",
-		Columns:      80,
-		Lines:        34,
-		Colors:       8,
-		Bell:         "\a",
-		Clear:        "\f",
-		AttrOff:      "\x1b[m",
-		Reverse:      "\x1b[7m",
-		SetFg:        "\x1b[3%p1%dm",
-		SetBg:        "\x1b[4%p1%dm",
-		SetFgBg:      "\x1b[3%p1%d;4%p2%dm",
-		PadChar:      "\x00",
-		SetCursor:    "\x1b[%i%p1%d;%p2%dH",
-		CursorBack1:  "\b",
-		CursorUp1:    "\x1b[A",
-		KeyUp:        "\x1b[A",
-		KeyDown:      "\x1b[B",
-		KeyRight:     "\x1b[C",
-		KeyLeft:      "\x1b[D",
-		KeyDelete:    "\u007f",
-		KeyBackspace: "\b",
-		KeyHome:      "\x1b[214z",
-		KeyEnd:       "\x1b[220z",
-		KeyPgUp:      "\x1b[216z",
-		KeyPgDn:      "\x1b[222z",
-		KeyF1:        "\x1b[224z",
-		KeyF2:        "\x1b[225z",
-		KeyF3:        "\x1b[226z",
-		KeyF4:        "\x1b[227z",
-		KeyF5:        "\x1b[228z",
-		KeyF6:        "\x1b[229z",
-		KeyF7:        "\x1b[230z",
-		KeyF8:        "\x1b[231z",
-		KeyF9:        "\x1b[232z",
-		KeyF10:       "\x1b[233z",
-		KeyF11:       "\x1b[234z",
-		KeyF12:       "\x1b[235z",
+		Name:           "sun-color",
+		Columns:        80,
+		Lines:          34,
+		Colors:         8,
+		Bell:           "\a",
+		Clear:          "\f",
+		AttrOff:        "\x1b[m",
+		Reverse:        "\x1b[7m",
+		SetFg:          "\x1b[3%p1%dm",
+		SetBg:          "\x1b[4%p1%dm",
+		SetFgBg:        "\x1b[3%p1%d;4%p2%dm",
+		PadChar:        "\x00",
+		SetCursor:      "\x1b[%i%p1%d;%p2%dH",
+		CursorBack1:    "\b",
+		CursorUp1:      "\x1b[A",
+		CursorDown1:    "\n",
+		CursorForward1: "\x1b[C",
+		CursorBack:     "\x1b[%p1%dD",
+		CursorUp:       "\x1b[%p1%dA",
+		CursorDown:     "\x1b[%p1%dB",
+		CursorForward:  "\x1b[%p1%dC",
+		CursorHome:     "\x1b[H",
+		CarriageReturn: "\r",
+		ClearToEOL:     "\x1b[K",
+		ResetFgBg:      "\x1b[0m",
+		KeyUp:          "\x1b[A",
+		KeyDown:        "\x1b[B",
+		KeyRight:       "\x1b[C",
+		KeyLeft:        "\x1b[D",
+		KeyDelete:      "\u007f",
+		KeyBackspace:   "\b",
+		KeyHome:        "\x1b[214z",
+		KeyEnd:         "\x1b[220z",
+		KeyPgUp:        "\x1b[216z",
+		KeyPgDn:        "\x1b[222z",
+		KeyF1:          "\x1b[224z",
+		KeyF2:          "\x1b[225z",
+		KeyF3:          "\x1b[226z",
+		KeyF4:          "\x1b[227z",
+		KeyF5:          "\x1b[228z",
+		KeyF6:          "\x1b[229z",
+		KeyF7:          "\x1b[230z",
+		KeyF8:          "\x1b[231z",
+		KeyF9:          "\x1b[232z",
+		KeyF10:         "\x1b[233z",
+		KeyF11:         "\x1b[234z",
+		KeyF12:         "\x1b[235z",
 	})
 	AddTerminfo(&Terminfo{
-		Name:         "tvi910",
-		Columns:      80,
-		Lines:        24,
-		Bell:         "\a",
-		Clear:        "\x1a",
-		AttrOff:      "\x1bG0",
-		Underline:    "\x1bG8",
-		Reverse:      "\x1bG4",
-		PadChar:      "\x00",
-		SetCursor:    "\x1b=%p1%' '%+%c%p2%' '%+%c",
-		CursorBack1:  "\b",
-		CursorUp1:    "\v",
-		KeyUp:        "\v",
-		KeyDown:      "\n",
-		KeyRight:     "\f",
-		KeyLeft:      "\b",
-		KeyBackspace: "\b",
-		KeyHome:      "\x1e",
-		KeyF1:        "\x01@\r",
-		KeyF2:        "\x01A\r",
-		KeyF3:        "\x01B\r",
-		KeyF4:        "\x01C\r",
-		KeyF5:        "\x01D\r",
-		KeyF6:        "\x01E\r",
-		KeyF7:        "\x01F\r",
-		KeyF8:        "\x01G\r",
-		KeyF9:        "\x01H\r",
+		Name:           "tvi910",
+		Columns:        80,
+		Lines:          24,
+		Bell:           "\a",
+		Clear:          "\x1a",
+		AttrOff:        "\x1bG0",
+		Underline:      "\x1bG8",
+		Reverse:        "\x1bG4",
+		PadChar:        "\x00",
+		SetCursor:      "\x1b=%p1%' '%+%c%p2%' '%+%c",
+		CursorBack1:    "\b",
+		CursorUp1:      "\v",
+		CursorDown1:    "\n",
+		CursorForward1: "\f",
+		CursorHome:     "\x1b=\x01\x01",
+		CarriageReturn: "\r",
+		ColumnAddress:  "\x1b]%p1%' '%+%c",
+		RowAddress:     "\x1b[%p1%' '%+%c",
+		ClearToEOL:     "\x1bT",
+		ExitUnderline:  "\x1bG0",
+		KeyUp:          "\v",
+		KeyDown:        "\n",
+		KeyRight:       "\f",
+		KeyLeft:        "\b",
+		KeyBackspace:   "\b",
+		KeyHome:        "\x1e",
+		KeyF1:          "\x01@\r",
+		KeyF2:          "\x01A\r",
+		KeyF3:          "\x01B\r",
+		KeyF4:          "\x01C\r",
+		KeyF5:          "\x01D\r",
+		KeyF6:          "\x01E\r",
+		KeyF7:          "\x01F\r",
+		KeyF8:          "\x01G\r",
+		KeyF9:          "\x01H\r",
 	})
 	AddTerminfo(&Terminfo{
-		Name:         "tvi912",
-		Aliases:      []string{"tvi914", "tvi920"},
-		Columns:      80,
-		Lines:        24,
-		Bell:         "\a",
-		Clear:        "\x1a",
-		Underline:    "\x1bl",
-		PadChar:      "\x00",
-		SetCursor:    "\x1b=%p1%' '%+%c%p2%' '%+%c",
-		CursorBack1:  "\b",
-		CursorUp1:    "\v",
-		KeyUp:        "\v",
-		KeyDown:      "\n",
-		KeyRight:     "\f",
-		KeyLeft:      "\b",
-		KeyBackspace: "\b",
-		KeyHome:      "\x1e",
-		KeyF1:        "\x01@\r",
-		KeyF2:        "\x01A\r",
-		KeyF3:        "\x01B\r",
-		KeyF4:        "\x01C\r",
-		KeyF5:        "\x01D\r",
-		KeyF6:        "\x01E\r",
-		KeyF7:        "\x01F\r",
-		KeyF8:        "\x01G\r",
-		KeyF9:        "\x01H\r",
+		Name:           "tvi912",
+		Aliases:        []string{"tvi914", "tvi920"},
+		Columns:        80,
+		Lines:          24,
+		Bell:           "\a",
+		Flash:          "\x1bb$<50/>\x1bd",
+		Clear:          "\x1a",
+		Underline:      "\x1bl",
+		PadChar:        "\x00",
+		SetCursor:      "\x1b=%p1%' '%+%c%p2%' '%+%c",
+		CursorBack1:    "\b",
+		CursorUp1:      "\v",
+		CursorDown1:    "\n",
+		CursorForward1: "\f",
+		CursorHome:     "\x1e",
+		CarriageReturn: "\r",
+		ClearToEOL:     "\x1bT",
+		ExitUnderline:  "\x1bm",
+		KeyUp:          "\v",
+		KeyDown:        "\n",
+		KeyRight:       "\f",
+		KeyLeft:        "\b",
+		KeyBackspace:   "\b",
+		KeyHome:        "\x1e",
+		KeyF1:          "\x01@\r",
+		KeyF2:          "\x01A\r",
+		KeyF3:          "\x01B\r",
+		KeyF4:          "\x01C\r",
+		KeyF5:          "\x01D\r",
+		KeyF6:          "\x01E\r",
+		KeyF7:          "\x01F\r",
+		KeyF8:          "\x01G\r",
+		KeyF9:          "\x01H\r",
 	})
 	AddTerminfo(&Terminfo{
-		Name:         "tvi921",
-		Columns:      80,
-		Lines:        24,
-		Clear:        "\x1a",
-		ShowCursor:   "\x1b.3",
-		AttrOff:      "\x1bG0",
-		Underline:    "\x1bG8",
-		Reverse:      "\x1bG4",
-		PadChar:      "\x00",
-		EnterAcs:     "\x1b$",
-		ExitAcs:      "\x1b%%",
-		SetCursor:    "\x1b=%p1%' '%+%c%p2%' '%+%c$<3/>",
-		CursorBack1:  "\b",
-		CursorUp1:    "\v",
-		KeyUp:        "\v",
-		KeyDown:      "\x16",
-		KeyRight:     "\f",
-		KeyLeft:      "\b",
-		KeyInsert:    "\x1bQ",
-		KeyDelete:    "\x1bW",
-		KeyBackspace: "\b",
-		KeyClear:     "\x1a",
+		Name:           "tvi921",
+		Columns:        80,
+		Lines:          24,
+		Clear:          "\x1a",
+		ShowCursor:     "\x1b.3",
+		AttrOff:        "\x1bG0",
+		Underline:      "\x1bG8",
+		Reverse:        "\x1bG4",
+		PadChar:        "\x00",
+		EnterAcs:       "\x1b$",
+		ExitAcs:        "\x1b%%",
+		SetCursor:      "\x1b=%p1%' '%+%c%p2%' '%+%c$<3/>",
+		CursorBack1:    "\b",
+		CursorUp1:      "\v",
+		CursorDown1:    "\x16",
+		CursorForward1: "\f",
+		CursorHome:     "\x1e",
+		CarriageReturn: "\r",
+		ClearToEOL:     "\x1bT",
+		ExitUnderline:  "\x1bG0",
+		KeyUp:          "\v",
+		KeyDown:        "\x16",
+		KeyRight:       "\f",
+		KeyLeft:        "\b",
+		KeyInsert:      "\x1bQ",
+		KeyDelete:      "\x1bW",
+		KeyBackspace:   "\b",
+		KeyClear:       "\x1a",
 	})
 	AddTerminfo(&Terminfo{
-		Name:         "tvi925",
-		Columns:      80,
-		Lines:        24,
-		Bell:         "\a",
-		Clear:        "\x1a",
-		ShowCursor:   "\x1b.4",
-		AttrOff:      "\x1bG0",
-		Underline:    "\x1bG8",
-		Reverse:      "\x1bG4",
-		PadChar:      "\x00",
-		SetCursor:    "\x1b=%p1%' '%+%c%p2%' '%+%c",
-		CursorBack1:  "\b",
-		CursorUp1:    "\v",
-		KeyUp:        "\v",
-		KeyDown:      "\x16",
-		KeyRight:     "\f",
-		KeyLeft:      "\b",
-		KeyInsert:    "\x1bQ",
-		KeyDelete:    "\x1bW",
-		KeyBackspace: "\b",
-		KeyHome:      "\x1e",
-		KeyF1:        "\x01@\r",
-		KeyF2:        "\x01A\r",
-		KeyF3:        "\x01B\r",
-		KeyF4:        "\x01C\r",
-		KeyF5:        "\x01D\r",
-		KeyF6:        "\x01E\r",
-		KeyF7:        "\x01F\r",
-		KeyF8:        "\x01G\r",
-		KeyF9:        "\x01H\r",
-		KeyClear:     "\x1a",
+		Name:           "tvi925",
+		Columns:        80,
+		Lines:          24,
+		Bell:           "\a",
+		Flash:          "\x1bb$<200>\x1bd",
+		Clear:          "\x1a",
+		ShowCursor:     "\x1b.4",
+		AttrOff:        "\x1bG0",
+		Underline:      "\x1bG8",
+		Reverse:        "\x1bG4",
+		PadChar:        "\x00",
+		SetCursor:      "\x1b=%p1%' '%+%c%p2%' '%+%c",
+		CursorBack1:    "\b",
+		CursorUp1:      "\v",
+		CursorDown1:    "\x16",
+		CursorForward1: "\f",
+		CursorHome:     "\x1e",
+		CarriageReturn: "\r",
+		ClearToEOL:     "\x1bT",
+		ExitUnderline:  "\x1bG0",
+		KeyUp:          "\v",
+		KeyDown:        "\x16",
+		KeyRight:       "\f",
+		KeyLeft:        "\b",
+		KeyInsert:      "\x1bQ",
+		KeyDelete:      "\x1bW",
+		KeyBackspace:   "\b",
+		KeyHome:        "\x1e",
+		KeyF1:          "\x01@\r",
+		KeyF2:          "\x01A\r",
+		KeyF3:          "\x01B\r",
+		KeyF4:          "\x01C\r",
+		KeyF5:          "\x01D\r",
+		KeyF6:          "\x01E\r",
+		KeyF7:          "\x01F\r",
+		KeyF8:          "\x01G\r",
+		KeyF9:          "\x01H\r",
+		KeyClear:       "\x1a",
 	})
 	AddTerminfo(&Terminfo{
-		Name:         "tvi950",
-		Columns:      80,
-		Lines:        24,
-		Bell:         "\a",
-		Clear:        "\x1b*",
-		AttrOff:      "\x1bG0",
-		Underline:    "\x1bG8",
-		Reverse:      "\x1bG4",
-		PadChar:      "\x00",
-		AltChars:     "b\tc\fd\re\ni\v",
-		EnterAcs:     "\x15",
-		ExitAcs:      "\x18",
-		SetCursor:    "\x1b=%p1%' '%+%c%p2%' '%+%c",
-		CursorBack1:  "\b",
-		CursorUp1:    "\v",
-		KeyUp:        "\v",
-		KeyDown:      "\x16",
-		KeyRight:     "\f",
-		KeyLeft:      "\b",
-		KeyInsert:    "\x1bQ",
-		KeyDelete:    "\x1bW",
-		KeyBackspace: "\b",
-		KeyHome:      "\x1e",
-		KeyF1:        "\x01@\r",
-		KeyF2:        "\x01A\r",
-		KeyF3:        "\x01B\r",
-		KeyF4:        "\x01C\r",
-		KeyF5:        "\x01D\r",
-		KeyF6:        "\x01E\r",
-		KeyF7:        "\x01F\r",
-		KeyF8:        "\x01G\r",
-		KeyF9:        "\x01H\r",
-		KeyClear:     "\x1b*",
-		KeyBacktab:   "\x1bI",
+		Name:           "tvi950",
+		Columns:        80,
+		Lines:          24,
+		Bell:           "\a",
+		Flash:          "\x1bb$<200/>\x1bd",
+		Clear:          "\x1b*",
+		AttrOff:        "\x1bG0",
+		Underline:      "\x1bG8",
+		Reverse:        "\x1bG4",
+		PadChar:        "\x00",
+		AltChars:       "b\tc\fd\re\ni\v",
+		EnterAcs:       "\x15",
+		ExitAcs:        "\x18",
+		SetCursor:      "\x1b=%p1%' '%+%c%p2%' '%+%c",
+		CursorBack1:    "\b",
+		CursorUp1:      "\v",
+		CursorDown1:    "\n",
+		CursorForward1: "\f",
+		CursorHome:     "\x1e",
+		CarriageReturn: "\r",
+		ClearToEOL:     "\x1bt",
+		ExitUnderline:  "\x1bG0",
+		KeyUp:          "\v",
+		KeyDown:        "\x16",
+		KeyRight:       "\f",
+		KeyLeft:        "\b",
+		KeyInsert:      "\x1bQ",
+		KeyDelete:      "\x1bW",
+		KeyBackspace:   "\b",
+		KeyHome:        "\x1e",
+		KeyF1:          "\x01@\r",
+		KeyF2:          "\x01A\r",
+		KeyF3:          "\x01B\r",
+		KeyF4:          "\x01C\r",
+		KeyF5:          "\x01D\r",
+		KeyF6:          "\x01E\r",
+		KeyF7:          "\x01F\r",
+		KeyF8:          "\x01G\r",
+		KeyF9:          "\x01H\r",
+		KeyClear:       "\x1b*",
+		KeyBacktab:     "\x1bI",
 	})
 	AddTerminfo(&Terminfo{
-		Name:         "tvi970",
-		Columns:      80,
-		Lines:        24,
-		Clear:        "\x1b[H\x1b[2J",
-		EnterCA:      "\x1b[?20l\x1b[?7h\x1b[1Q",
-		AttrOff:      "\x1b[m",
-		Underline:    "\x1b[4m",
-		PadChar:      "\x00",
-		EnterAcs:     "\x1b(B",
-		ExitAcs:      "\x1b(B",
-		SetCursor:    "\x1b[%i%p1%d;%p2%df",
-		CursorBack1:  "\b",
-		CursorUp1:    "\x1bM",
-		KeyUp:        "\x1b[A",
-		KeyDown:      "\x1b[B",
-		KeyRight:     "\x1b[C",
-		KeyLeft:      "\x1b[D",
-		KeyBackspace: "\b",
-		KeyHome:      "\x1b[H",
-		KeyF1:        "\x1b?a",
-		KeyF2:        "\x1b?b",
-		KeyF3:        "\x1b?c",
-		KeyF4:        "\x1b?d",
-		KeyF5:        "\x1b?e",
-		KeyF6:        "\x1b?f",
-		KeyF7:        "\x1b?g",
-		KeyF8:        "\x1b?h",
-		KeyF9:        "\x1b?i",
+		Name:           "tvi970",
+		Columns:        80,
+		Lines:          24,
+		Flash:          "\x1b[5m$<200/>\x1b[m",
+		Clear:          "\x1b[H\x1b[2J",
+		EnterCA:        "\x1b[?20l\x1b[?7h\x1b[1Q",
+		AttrOff:        "\x1b[m",
+		Underline:      "\x1b[4m",
+		PadChar:        "\x00",
+		EnterAcs:       "\x1b(B",
+		ExitAcs:        "\x1b(B",
+		SetCursor:      "\x1b[%i%p1%d;%p2%df",
+		CursorBack1:    "\b",
+		CursorUp1:      "\x1bM",
+		CursorDown1:    "\x1bD",
+		CursorForward1: "\x1b[C",
+		CursorHome:     "\x1b[H",
+		ColumnAddress:  "\x1b[%i%p1%dG",
+		RowAddress:     "\x1b[%i%p1%dd",
+		ClearToEOL:     "\x1b[K",
+		ExitUnderline:  "\x1b[m",
+		KeyUp:          "\x1b[A",
+		KeyDown:        "\x1b[B",
+		KeyRight:       "\x1b[C",
+		KeyLeft:        "\x1b[D",
+		KeyBackspace:   "\b",
+		KeyHome:        "\x1b[H",
+		KeyF1:          "\x1b?a",
+		KeyF2:          "\x1b?b",
+		KeyF3:          "\x1b?c",
+		KeyF4:          "\x1b?d",
+		KeyF5:          "\x1b?e",
+		KeyF6:          "\x1b?f",
+		KeyF7:          "\x1b?g",
+		KeyF8:          "\x1b?h",
+		KeyF9:          "\x1b?i",
 	})
 	AddTerminfo(&Terminfo{
-		Name:         "vt52",
-		Columns:      80,
-		Lines:        24,
-		Bell:         "\a",
-		Clear:        "\x1bH\x1bJ",
-		PadChar:      "\x00",
-		AltChars:     "ffgghhompoqqss.k",
-		EnterAcs:     "\x1bF",
-		ExitAcs:      "\x1bG",
-		SetCursor:    "\x1bY%p1%' '%+%c%p2%' '%+%c",
-		CursorBack1:  "\x1bD",
-		CursorUp1:    "\x1bA",
-		KeyUp:        "\x1bA",
-		KeyDown:      "\x1bB",
-		KeyRight:     "\x1bC",
-		KeyLeft:      "\x1bD",
-		KeyBackspace: "\b",
+		Name:           "vt52",
+		Columns:        80,
+		Lines:          24,
+		Bell:           "\a",
+		Clear:          "\x1bH\x1bJ",
+		PadChar:        "\x00",
+		AltChars:       "ffgghhompoqqss.k",
+		EnterAcs:       "\x1bF",
+		ExitAcs:        "\x1bG",
+		SetCursor:      "\x1bY%p1%' '%+%c%p2%' '%+%c",
+		CursorBack1:    "\x1bD",
+		CursorUp1:      "\x1bA",
+		CursorDown1:    "\x1bB",
+		CursorForward1: "\x1bC",
+		CursorHome:     "\x1bH",
+		CarriageReturn: "\r",
+		ClearToEOL:     "\x1bK",
+		KeyUp:          "\x1bA",
+		KeyDown:        "\x1bB",
+		KeyRight:       "\x1bC",
+		KeyLeft:        "\x1bD",
+		KeyBackspace:   "\b",
 	})
 	AddTerminfo(&Terminfo{
-		Name:         "vt100",
-		Aliases:      []string{"vt100-am"},
-		Columns:      80,
-		Lines:        24,
-		Bell:         "\a",
-		Clear:        "\x1b[H\x1b[J$<50>",
-		AttrOff:      "\x1b[m\x0f$<2>",
-		Underline:    "\x1b[4m$<2>",
-		Bold:         "\x1b[1m$<2>",
-		Blink:        "\x1b[5m$<2>",
-		Reverse:      "\x1b[7m$<2>",
-		EnterKeypad:  "\x1b[?1h\x1b=",
-		ExitKeypad:   "\x1b[?1l\x1b>",
-		PadChar:      "\x00",
-		AltChars:     "``aaffggjjkkllmmnnooppqqrrssttuuvvwwxxyyzz{{||}}~~",
-		EnterAcs:     "\x0e",
-		ExitAcs:      "\x0f",
-		EnableAcs:    "\x1b(B\x1b)0",
-		SetCursor:    "\x1b[%i%p1%d;%p2%dH$<5>",
-		CursorBack1:  "\b",
-		CursorUp1:    "\x1b[A$<2>",
-		KeyUp:        "\x1bOA",
-		KeyDown:      "\x1bOB",
-		KeyRight:     "\x1bOC",
-		KeyLeft:      "\x1bOD",
-		KeyBackspace: "\b",
-		KeyF1:        "\x1bOP",
-		KeyF2:        "\x1bOQ",
-		KeyF3:        "\x1bOR",
-		KeyF4:        "\x1bOS",
-		KeyF5:        "\x1bOt",
-		KeyF6:        "\x1bOu",
-		KeyF7:        "\x1bOv",
-		KeyF8:        "\x1bOl",
-		KeyF9:        "\x1bOw",
-		KeyF10:       "\x1bOx",
+		Name:           "vt100",
+		Aliases:        []string{"vt100-am"},
+		Columns:        80,
+		Lines:          24,
+		Bell:           "\a",
+		Clear:          "\x1b[H\x1b[J$<50>",
+		AttrOff:        "\x1b[m\x0f$<2>",
+		Underline:      "\x1b[4m$<2>",
+		Bold:           "\x1b[1m$<2>",
+		Blink:          "\x1b[5m$<2>",
+		Reverse:        "\x1b[7m$<2>",
+		EnterKeypad:    "\x1b[?1h\x1b=",
+		ExitKeypad:     "\x1b[?1l\x1b>",
+		PadChar:        "\x00",
+		AltChars:       "``aaffggjjkkllmmnnooppqqrrssttuuvvwwxxyyzz{{||}}~~",
+		EnterAcs:       "\x0e",
+		ExitAcs:        "\x0f",
+		EnableAcs:      "\x1b(B\x1b)0",
+		SetCursor:      "\x1b[%i%p1%d;%p2%dH$<5>",
+		CursorBack1:    "\b",
+		CursorUp1:      "\x1b[A$<2>",
+		CursorDown1:    "\n",
+		CursorForward1: "\x1b[C$<2>",
+		CursorBack:     "\x1b[%p1%dD",
+		CursorUp:       "\x1b[%p1%dA",
+		CursorDown:     "\x1b[%p1%dB",
+		CursorForward:  "\x1b[%p1%dC",
+		CursorHome:     "\x1b[H",
+		CarriageReturn: "\r",
+		ClearToEOL:     "\x1b[K$<3>",
+		ExitUnderline:  "\x1b[m$<2>",
+		KeyUp:          "\x1bOA",
+		KeyDown:        "\x1bOB",
+		KeyRight:       "\x1bOC",
+		KeyLeft:        "\x1bOD",
+		KeyBackspace:   "\b",
+		KeyF1:          "\x1bOP",
+		KeyF2:          "\x1bOQ",
+		KeyF3:          "\x1bOR",
+		KeyF4:          "\x1bOS",
+		KeyF5:          "\x1bOt",
+		KeyF6:          "\x1bOu",
+		KeyF7:          "\x1bOv",
+		KeyF8:          "\x1bOl",
+		KeyF9:          "\x1bOw",
+		KeyF10:         "\x1bOx",
 	})
 	AddTerminfo(&Terminfo{
-		Name:         "vt102",
-		Columns:      80,
-		Lines:        24,
-		Bell:         "\a",
-		Clear:        "\x1b[H\x1b[J$<50>",
-		AttrOff:      "\x1b[m\x0f$<2>",
-		Underline:    "\x1b[4m$<2>",
-		Bold:         "\x1b[1m$<2>",
-		Blink:        "\x1b[5m$<2>",
-		Reverse:      "\x1b[7m$<2>",
-		EnterKeypad:  "\x1b[?1h\x1b=",
-		ExitKeypad:   "\x1b[?1l\x1b>",
-		PadChar:      "\x00",
-		AltChars:     "``aaffggjjkkllmmnnooppqqrrssttuuvvwwxxyyzz{{||}}~~",
-		EnterAcs:     "\x0e",
-		ExitAcs:      "\x0f",
-		EnableAcs:    "\x1b(B\x1b)0",
-		SetCursor:    "\x1b[%i%p1%d;%p2%dH$<5>",
-		CursorBack1:  "\b",
-		CursorUp1:    "\x1b[A$<2>",
-		KeyUp:        "\x1bOA",
-		KeyDown:      "\x1bOB",
-		KeyRight:     "\x1bOC",
-		KeyLeft:      "\x1bOD",
-		KeyBackspace: "\b",
-		KeyF1:        "\x1bOP",
-		KeyF2:        "\x1bOQ",
-		KeyF3:        "\x1bOR",
-		KeyF4:        "\x1bOS",
-		KeyF5:        "\x1bOt",
-		KeyF6:        "\x1bOu",
-		KeyF7:        "\x1bOv",
-		KeyF8:        "\x1bOl",
-		KeyF9:        "\x1bOw",
-		KeyF10:       "\x1bOx",
+		Name:           "vt102",
+		Columns:        80,
+		Lines:          24,
+		Bell:           "\a",
+		Clear:          "\x1b[H\x1b[J$<50>",
+		AttrOff:        "\x1b[m\x0f$<2>",
+		Underline:      "\x1b[4m$<2>",
+		Bold:           "\x1b[1m$<2>",
+		Blink:          "\x1b[5m$<2>",
+		Reverse:        "\x1b[7m$<2>",
+		EnterKeypad:    "\x1b[?1h\x1b=",
+		ExitKeypad:     "\x1b[?1l\x1b>",
+		PadChar:        "\x00",
+		AltChars:       "``aaffggjjkkllmmnnooppqqrrssttuuvvwwxxyyzz{{||}}~~",
+		EnterAcs:       "\x0e",
+		ExitAcs:        "\x0f",
+		EnableAcs:      "\x1b(B\x1b)0",
+		SetCursor:      "\x1b[%i%p1%d;%p2%dH$<5>",
+		CursorBack1:    "\b",
+		CursorUp1:      "\x1b[A$<2>",
+		CursorDown1:    "\n",
+		CursorForward1: "\x1b[C$<2>",
+		CursorBack:     "\x1b[%p1%dD",
+		CursorUp:       "\x1b[%p1%dA",
+		CursorDown:     "\x1b[%p1%dB",
+		CursorForward:  "\x1b[%p1%dC",
+		CursorHome:     "\x1b[H",
+		CarriageReturn: "\r",
+		ClearToEOL:     "\x1b[K$<3>",
+		ExitUnderline:  "\x1b[m$<2>",
+		KeyUp:          "\x1bOA",
+		KeyDown:        "\x1bOB",
+		KeyRight:       "\x1bOC",
+		KeyLeft:        "\x1bOD",
+		KeyBackspace:   "\b",
+		KeyF1:          "\x1bOP",
+		KeyF2:          "\x1bOQ",
+		KeyF3:          "\x1bOR",
+		KeyF4:          "\x1bOS",
+		KeyF5:          "\x1bOt",
+		KeyF6:          "\x1bOu",
+		KeyF7:          "\x1bOv",
+		KeyF8:          "\x1bOl",
+		KeyF9:          "\x1bOw",
+		KeyF10:         "\x1bOx",
 	})
 	AddTerminfo(&Terminfo{
-		Name:         "vt220",
-		Aliases:      []string{"vt200"},
-		Columns:      80,
-		Lines:        24,
-		Bell:         "\a",
-		Clear:        "\x1b[H\x1b[J",
-		AttrOff:      "\x1b[m\x1b(B",
-		Underline:    "\x1b[4m",
-		Bold:         "\x1b[1m",
-		Blink:        "\x1b[5m",
-		Reverse:      "\x1b[7m",
-		PadChar:      "\x00",
-		AltChars:     "``aaffggjjkkllmmnnooppqqrrssttuuvvwwxxyyzz{{||}}~~",
-		EnterAcs:     "\x1b(0$<2>",
-		ExitAcs:      "\x1b(B$<4>",
-		EnableAcs:    "\x1b)0",
-		SetCursor:    "\x1b[%i%p1%d;%p2%dH",
-		CursorBack1:  "\b",
-		CursorUp1:    "\x1b[A",
-		KeyUp:        "\x1b[A",
-		KeyDown:      "\x1b[B",
-		KeyRight:     "\x1b[C",
-		KeyLeft:      "\x1b[D",
-		KeyInsert:    "\x1b[2~",
-		KeyBackspace: "\b",
-		KeyPgUp:      "\x1b[5~",
-		KeyPgDn:      "\x1b[6~",
-		KeyF1:        "\x1bOP",
-		KeyF2:        "\x1bOQ",
-		KeyF3:        "\x1bOR",
-		KeyF4:        "\x1bOS",
-		KeyF6:        "\x1b[17~",
-		KeyF7:        "\x1b[18~",
-		KeyF8:        "\x1b[19~",
-		KeyF9:        "\x1b[20~",
-		KeyF10:       "\x1b[21~",
-		KeyF11:       "\x1b[23~",
-		KeyF12:       "\x1b[24~",
-		KeyF13:       "\x1b[25~",
-		KeyF14:       "\x1b[26~",
-		KeyF17:       "\x1b[31~",
-		KeyF18:       "\x1b[32~",
-		KeyF19:       "\x1b[33~",
-		KeyF20:       "\x1b[34~",
-		KeyHelp:      "\x1b[28~",
+		Name:           "vt220",
+		Aliases:        []string{"vt200"},
+		Columns:        80,
+		Lines:          24,
+		Bell:           "\a",
+		Flash:          "\x1b[?5h$<200/>\x1b[?5l",
+		Clear:          "\x1b[H\x1b[J",
+		AttrOff:        "\x1b[m\x1b(B",
+		Underline:      "\x1b[4m",
+		Bold:           "\x1b[1m",
+		Blink:          "\x1b[5m",
+		Reverse:        "\x1b[7m",
+		PadChar:        "\x00",
+		AltChars:       "``aaffggjjkkllmmnnooppqqrrssttuuvvwwxxyyzz{{||}}~~",
+		EnterAcs:       "\x1b(0$<2>",
+		ExitAcs:        "\x1b(B$<4>",
+		EnableAcs:      "\x1b)0",
+		SetCursor:      "\x1b[%i%p1%d;%p2%dH",
+		CursorBack1:    "\b",
+		CursorUp1:      "\x1b[A",
+		CursorDown1:    "\n",
+		CursorForward1: "\x1b[C",
+		CursorBack:     "\x1b[%p1%dD",
+		CursorUp:       "\x1b[%p1%dA",
+		CursorDown:     "\x1b[%p1%dB",
+		CursorForward:  "\x1b[%p1%dC",
+		CursorHome:     "\x1b[H",
+		CarriageReturn: "\r",
+		ClearToEOL:     "\x1b[K",
+		EraseChars:     "\x1b[%p1%dX",
+		ExitUnderline:  "\x1b[24m",
+		KeyUp:          "\x1b[A",
+		KeyDown:        "\x1b[B",
+		KeyRight:       "\x1b[C",
+		KeyLeft:        "\x1b[D",
+		KeyInsert:      "\x1b[2~",
+		KeyBackspace:   "\b",
+		KeyPgUp:        "\x1b[5~",
+		KeyPgDn:        "\x1b[6~",
+		KeyF1:          "\x1bOP",
+		KeyF2:          "\x1bOQ",
+		KeyF3:          "\x1bOR",
+		KeyF4:          "\x1bOS",
+		KeyF6:          "\x1b[17~",
+		KeyF7:          "\x1b[18~",
+		KeyF8:          "\x1b[19~",
+		KeyF9:          "\x1b[20~",
+		KeyF10:         "\x1b[21~",
+		KeyF11:         "\x1b[23~",
+		KeyF12:         "\x1b[24~",
+		KeyF13:         "\x1b[25~",
+		KeyF14:         "\x1b[26~",
+		KeyF17:         "\x1b[31~",
+		KeyF18:         "\x1b[32~",
+		KeyF19:         "\x1b[33~",
+		KeyF20:         "\x1b[34~",
+		KeyHelp:        "\x1b[28~",
 	})
 	AddTerminfo(&Terminfo{
-		Name:         "vt320",
-		Aliases:      []string{"vt300"},
-		Columns:      80,
-		Lines:        24,
-		Bell:         "\a",
-		Clear:        "\x1b[H\x1b[2J",
-		ShowCursor:   "\x1b[?25h",
-		HideCursor:   "\x1b[?25l",
-		AttrOff:      "\x1b[m\x1b(B",
-		Underline:    "\x1b[4m",
-		Bold:         "\x1b[1m",
-		Blink:        "\x1b[5m",
-		Reverse:      "\x1b[7m",
-		EnterKeypad:  "\x1b[?1h\x1b=",
-		ExitKeypad:   "\x1b[?1l\x1b>",
-		PadChar:      "\x00",
-		AltChars:     "``aaffggjjkkllmmnnooppqqrrssttuuvvwwxxyyzz{{||}}~~",
-		EnterAcs:     "\x1b(0",
-		ExitAcs:      "\x1b(B",
-		SetCursor:    "\x1b[%i%p1%d;%p2%dH",
-		CursorBack1:  "\b",
-		CursorUp1:    "\x1b[A",
-		KeyUp:        "\x1bOA",
-		KeyDown:      "\x1bOB",
-		KeyRight:     "\x1bOC",
-		KeyLeft:      "\x1bOD",
-		KeyInsert:    "\x1b[2~",
-		KeyDelete:    "\x1b[3~",
-		KeyBackspace: "\u007f",
-		KeyHome:      "\x1b[1~",
-		KeyPgUp:      "\x1b[5~",
-		KeyPgDn:      "\x1b[6~",
-		KeyF1:        "\x1bOP",
-		KeyF2:        "\x1bOQ",
-		KeyF3:        "\x1bOR",
-		KeyF4:        "\x1bOS",
-		KeyF6:        "\x1b[17~",
-		KeyF7:        "\x1b[18~",
-		KeyF8:        "\x1b[19~",
-		KeyF9:        "\x1b[20~",
-		KeyF10:       "\x1b[21~",
-		KeyF11:       "\x1b[23~",
-		KeyF12:       "\x1b[24~",
-		KeyF13:       "\x1b[25~",
-		KeyF14:       "\x1b[26~",
-		KeyF15:       "\x1b[28~",
-		KeyF16:       "\x1b[29~",
-		KeyF17:       "\x1b[31~",
-		KeyF18:       "\x1b[32~",
-		KeyF19:       "\x1b[33~",
-		KeyF20:       "\x1b[34~",
+		Name:           "vt320",
+		Aliases:        []string{"vt300"},
+		Columns:        80,
+		Lines:          24,
+		Bell:           "\a",
+		Clear:          "\x1b[H\x1b[2J",
+		ShowCursor:     "\x1b[?25h",
+		HideCursor:     "\x1b[?25l",
+		AttrOff:        "\x1b[m\x1b(B",
+		Underline:      "\x1b[4m",
+		Bold:           "\x1b[1m",
+		Blink:          "\x1b[5m",
+		Reverse:        "\x1b[7m",
+		EnterKeypad:    "\x1b[?1h\x1b=",
+		ExitKeypad:     "\x1b[?1l\x1b>",
+		PadChar:        "\x00",
+		AltChars:       "``aaffggjjkkllmmnnooppqqrrssttuuvvwwxxyyzz{{||}}~~",
+		EnterAcs:       "\x1b(0",
+		ExitAcs:        "\x1b(B",
+		SetCursor:      "\x1b[%i%p1%d;%p2%dH",
+		CursorBack1:    "\b",
+		CursorUp1:      "\x1b[A",
+		CursorDown1:    "\n",
+		CursorForward1: "\x1b[C",
+		CursorBack:     "\x1b[%p1%dD",
+		CursorUp:       "\x1b[%p1%dA",
+		CursorDown:     "\x1b[%p1%dB",
+		CursorForward:  "\x1b[%p1%dC",
+		CursorHome:     "\x1b[H",
+		CarriageReturn: "\r",
+		ClearToEOL:     "\x1b[K",
+		EraseChars:     "\x1b[%p1%dX",
+		ExitUnderline:  "\x1b[m",
+		KeyUp:          "\x1bOA",
+		KeyDown:        "\x1bOB",
+		KeyRight:       "\x1bOC",
+		KeyLeft:        "\x1bOD",
+		KeyInsert:      "\x1b[2~",
+		KeyDelete:      "\x1b[3~",
+		KeyBackspace:   "\u007f",
+		KeyHome:        "\x1b[1~",
+		KeyPgUp:        "\x1b[5~",
+		KeyPgDn:        "\x1b[6~",
+		KeyF1:          "\x1bOP",
+		KeyF2:          "\x1bOQ",
+		KeyF3:          "\x1bOR",
+		KeyF4:          "\x1bOS",
+		KeyF6:          "\x1b[17~",
+		KeyF7:          "\x1b[18~",
+		KeyF8:          "\x1b[19~",
+		KeyF9:          "\x1b[20~",
+		KeyF10:         "\x1b[21~",
+		KeyF11:         "\x1b[23~",
+		KeyF12:         "\x1b[24~",
+		KeyF13:         "\x1b[25~",
+		KeyF14:         "\x1b[26~",
+		KeyF15:         "\x1b[28~",
+		KeyF16:         "\x1b[29~",
+		KeyF17:         "\x1b[31~",
+		KeyF18:         "\x1b[32~",
+		KeyF19:         "\x1b[33~",
+		KeyF20:         "\x1b[34~",
 	})
 	AddTerminfo(&Terminfo{
-		Name:         "vt400",
-		Aliases:      []string{"dec-vt400", "vt400-24"},
-		Columns:      80,
-		Lines:        24,
-		Clear:        "\x1b[H\x1b[J$<10/>",
-		ShowCursor:   "\x1b[?25h",
-		HideCursor:   "\x1b[?25l",
-		AttrOff:      "\x1b[m\x1b(B",
-		Underline:    "\x1b[4m",
-		Bold:         "\x1b[1m",
-		Blink:        "\x1b[5m",
-		Reverse:      "\x1b[7m",
-		EnterKeypad:  "\x1b[?1h\x1b=",
-		ExitKeypad:   "\x1b[?1l\x1b>",
-		PadChar:      "\x00",
-		AltChars:     "``aaffggjjkkllmmnnooppqqrrssttuuvvwwxxyyzz{{||}}~~",
-		EnterAcs:     "\x1b(0",
-		ExitAcs:      "\x1b(B",
-		SetCursor:    "\x1b[%i%p1%d;%p2%dH",
-		CursorBack1:  "\b",
-		CursorUp1:    "\x1b[A",
-		KeyUp:        "\x1bOA",
-		KeyDown:      "\x1bOB",
-		KeyRight:     "\x1bOC",
-		KeyLeft:      "\x1bOD",
-		KeyBackspace: "\b",
-		KeyF1:        "\x1bOP",
-		KeyF2:        "\x1bOQ",
-		KeyF3:        "\x1bOR",
-		KeyF4:        "\x1bOS",
-		KeyF6:        "\x1b[17~",
-		KeyF7:        "\x1b[18~",
-		KeyF8:        "\x1b[19~",
-		KeyF9:        "\x1b[20~",
+		Name:           "vt400",
+		Aliases:        []string{"dec-vt400", "vt400-24"},
+		Columns:        80,
+		Lines:          24,
+		Flash:          "\x1b[?5h$<200/>\x1b[?5l",
+		Clear:          "\x1b[H\x1b[J$<10/>",
+		ShowCursor:     "\x1b[?25h",
+		HideCursor:     "\x1b[?25l",
+		AttrOff:        "\x1b[m\x1b(B",
+		Underline:      "\x1b[4m",
+		Bold:           "\x1b[1m",
+		Blink:          "\x1b[5m",
+		Reverse:        "\x1b[7m",
+		EnterKeypad:    "\x1b[?1h\x1b=",
+		ExitKeypad:     "\x1b[?1l\x1b>",
+		PadChar:        "\x00",
+		AltChars:       "``aaffggjjkkllmmnnooppqqrrssttuuvvwwxxyyzz{{||}}~~",
+		EnterAcs:       "\x1b(0",
+		ExitAcs:        "\x1b(B",
+		SetCursor:      "\x1b[%i%p1%d;%p2%dH",
+		CursorBack1:    "\b",
+		CursorUp1:      "\x1b[A",
+		CursorDown1:    "\n",
+		CursorForward1: "\x1b[C",
+		CursorBack:     "\x1b[%p1%dD",
+		CursorUp:       "\x1b[%p1%dA",
+		CursorDown:     "\x1b[%p1%dB",
+		CursorForward:  "\x1b[%p1%dC",
+		CursorHome:     "\x1b[H",
+		CarriageReturn: "\r",
+		ClearToEOL:     "\x1b[K$<4/>",
+		ExitUnderline:  "\x1b[24m",
+		KeyUp:          "\x1bOA",
+		KeyDown:        "\x1bOB",
+		KeyRight:       "\x1bOC",
+		KeyLeft:        "\x1bOD",
+		KeyBackspace:   "\b",
+		KeyF1:          "\x1bOP",
+		KeyF2:          "\x1bOQ",
+		KeyF3:          "\x1bOR",
+		KeyF4:          "\x1bOS",
+		KeyF6:          "\x1b[17~",
+		KeyF7:          "\x1b[18~",
+		KeyF8:          "\x1b[19~",
+		KeyF9:          "\x1b[20~",
 	})
 	AddTerminfo(&Terminfo{
-		Name:         "vt420",
-		Columns:      80,
-		Lines:        24,
-		Bell:         "\a",
-		Clear:        "\x1b[H\x1b[2J$<50>",
-		AttrOff:      "\x1b[m$<2>",
-		Underline:    "\x1b[4m",
-		Bold:         "\x1b[1m$<2>",
-		Blink:        "\x1b[5m$<2>",
-		Reverse:      "\x1b[7m$<2>",
-		EnterKeypad:  "\x1b=",
-		ExitKeypad:   "\x1b>",
-		PadChar:      "\x00",
-		AltChars:     "``aaffggjjkkllmmnnooppqqrrssttuuvvwwxxyyzz{{||}}~~",
-		EnterAcs:     "\x1b(0$<2>",
-		ExitAcs:      "\x1b(B$<4>",
-		SetCursor:    "\x1b[%i%p1%d;%p2%dH$<10>",
-		CursorBack1:  "\b",
-		CursorUp1:    "\x1b[A",
-		KeyUp:        "\x1b[A",
-		KeyDown:      "\x1b[B",
-		KeyRight:     "\x1b[C",
-		KeyLeft:      "\x1b[D",
-		KeyInsert:    "\x1b[2~",
-		KeyDelete:    "\x1b[3~",
-		KeyBackspace: "\b",
-		KeyPgUp:      "\x1b[5~",
-		KeyPgDn:      "\x1b[6~",
-		KeyF1:        "\x1bOP",
-		KeyF2:        "\x1bOQ",
-		KeyF3:        "\x1bOR",
-		KeyF4:        "\x1bOS",
-		KeyF5:        "\x1b[17~",
-		KeyF6:        "\x1b[18~",
-		KeyF7:        "\x1b[19~",
-		KeyF8:        "\x1b[20~",
-		KeyF9:        "\x1b[21~",
-		KeyF10:       "\x1b[29~",
+		Name:           "vt420",
+		Columns:        80,
+		Lines:          24,
+		Bell:           "\a",
+		Flash:          "\x1b[?5h$<200/>\x1b[?5l",
+		Clear:          "\x1b[H\x1b[2J$<50>",
+		AttrOff:        "\x1b[m$<2>",
+		Underline:      "\x1b[4m",
+		Bold:           "\x1b[1m$<2>",
+		Blink:          "\x1b[5m$<2>",
+		Reverse:        "\x1b[7m$<2>",
+		EnterKeypad:    "\x1b=",
+		ExitKeypad:     "\x1b>",
+		PadChar:        "\x00",
+		AltChars:       "``aaffggjjkkllmmnnooppqqrrssttuuvvwwxxyyzz{{||}}~~",
+		EnterAcs:       "\x1b(0$<2>",
+		ExitAcs:        "\x1b(B$<4>",
+		SetCursor:      "\x1b[%i%p1%d;%p2%dH$<10>",
+		CursorBack1:    "\b",
+		CursorUp1:      "\x1b[A",
+		CursorDown1:    "\n",
+		CursorForward1: "\x1b[C",
+		CursorBack:     "\x1b[%p1%dD",
+		CursorUp:       "\x1b[%p1%dA",
+		CursorDown:     "\x1b[%p1%dB",
+		CursorForward:  "\x1b[%p1%dC",
+		CursorHome:     "\x1b[H",
+		CarriageReturn: "\r",
+		ClearToEOL:     "\x1b[K$<3>",
+		EraseChars:     "\x1b[%p1%dX",
+		ExitUnderline:  "\x1b[24m",
+		KeyUp:          "\x1b[A",
+		KeyDown:        "\x1b[B",
+		KeyRight:       "\x1b[C",
+		KeyLeft:        "\x1b[D",
+		KeyInsert:      "\x1b[2~",
+		KeyDelete:      "\x1b[3~",
+		KeyBackspace:   "\b",
+		KeyPgUp:        "\x1b[5~",
+		KeyPgDn:        "\x1b[6~",
+		KeyF1:          "\x1bOP",
+		KeyF2:          "\x1bOQ",
+		KeyF3:          "\x1bOR",
+		KeyF4:          "\x1bOS",
+		KeyF5:          "\x1b[17~",
+		KeyF6:          "\x1b[18~",
+		KeyF7:          "\x1b[19~",
+		KeyF8:          "\x1b[20~",
+		KeyF9:          "\x1b[21~",
+		KeyF10:         "\x1b[29~",
 	})
 	AddTerminfo(&Terminfo{
-		Name:         "wy50",
-		Aliases:      []string{"wyse50"},
-		Columns:      80,
-		Lines:        24,
-		Bell:         "\a",
-		Clear:        "\x1b+$<20>",
-		ShowCursor:   "\x1b`1",
-		HideCursor:   "\x1b`0",
-		AttrOff:      "\x1b(\x1bH\x03",
-		Dim:          "\x1b`7\x1b)",
-		Reverse:      "\x1b`6\x1b)",
-		PadChar:      "\x00",
-		AltChars:     "0wa_h[jukslrmqnxqzttuyv]wpxv",
-		EnterAcs:     "\x1bH\x02",
-		ExitAcs:      "\x1bH\x03",
-		SetCursor:    "\x1b=%p1%' '%+%c%p2%' '%+%c",
-		CursorBack1:  "\b",
-		CursorUp1:    "\v",
-		KeyUp:        "\v",
-		KeyDown:      "\n",
-		KeyRight:     "\f",
-		KeyLeft:      "\b",
-		KeyInsert:    "\x1bQ",
-		KeyDelete:    "\x1bW",
-		KeyBackspace: "\b",
-		KeyHome:      "\x1e",
-		KeyPgUp:      "\x1bJ",
-		KeyPgDn:      "\x1bK",
-		KeyF1:        "\x01@\r",
-		KeyF2:        "\x01A\r",
-		KeyF3:        "\x01B\r",
-		KeyF4:        "\x01C\r",
-		KeyF5:        "\x01D\r",
-		KeyF6:        "\x01E\r",
-		KeyF7:        "\x01F\r",
-		KeyF8:        "\x01G\r",
-		KeyF9:        "\x01H\r",
-		KeyF10:       "\x01I\r",
-		KeyF11:       "\x01J\r",
-		KeyF12:       "\x01K\r",
-		KeyF13:       "\x01L\r",
-		KeyF14:       "\x01M\r",
-		KeyF15:       "\x01N\r",
-		KeyF16:       "\x01O\r",
-		KeyPrint:     "\x1bP",
-		KeyBacktab:   "\x1bI",
-		KeyShfHome:   "\x1b{",
+		Name:           "wy50",
+		Aliases:        []string{"wyse50"},
+		Columns:        80,
+		Lines:          24,
+		Bell:           "\a",
+		Flash:          "\x1b`8$<100/>\x1b`9",
+		Clear:          "\x1b+$<20>",
+		ShowCursor:     "\x1b`1",
+		HideCursor:     "\x1b`0",
+		AttrOff:        "\x1b(\x1bH\x03",
+		Dim:            "\x1b`7\x1b)",
+		Reverse:        "\x1b`6\x1b)",
+		PadChar:        "\x00",
+		AltChars:       "0wa_h[jukslrmqnxqzttuyv]wpxv",
+		EnterAcs:       "\x1bH\x02",
+		ExitAcs:        "\x1bH\x03",
+		SetCursor:      "\x1b=%p1%' '%+%c%p2%' '%+%c",
+		CursorBack1:    "\b",
+		CursorUp1:      "\v",
+		CursorDown1:    "\n",
+		CursorForward1: "\f",
+		CursorHome:     "\x1e",
+		CarriageReturn: "\r",
+		ClearToEOL:     "\x1bT",
+		KeyUp:          "\v",
+		KeyDown:        "\n",
+		KeyRight:       "\f",
+		KeyLeft:        "\b",
+		KeyInsert:      "\x1bQ",
+		KeyDelete:      "\x1bW",
+		KeyBackspace:   "\b",
+		KeyHome:        "\x1e",
+		KeyPgUp:        "\x1bJ",
+		KeyPgDn:        "\x1bK",
+		KeyF1:          "\x01@\r",
+		KeyF2:          "\x01A\r",
+		KeyF3:          "\x01B\r",
+		KeyF4:          "\x01C\r",
+		KeyF5:          "\x01D\r",
+		KeyF6:          "\x01E\r",
+		KeyF7:          "\x01F\r",
+		KeyF8:          "\x01G\r",
+		KeyF9:          "\x01H\r",
+		KeyF10:         "\x01I\r",
+		KeyF11:         "\x01J\r",
+		KeyF12:         "\x01K\r",
+		KeyF13:         "\x01L\r",
+		KeyF14:         "\x01M\r",
+		KeyF15:         "\x01N\r",
+		KeyF16:         "\x01O\r",
+		KeyPrint:       "\x1bP",
+		KeyBacktab:     "\x1bI",
+		KeyShfHome:     "\x1b{",
 	})
 	AddTerminfo(&Terminfo{
-		Name:         "wy60",
-		Aliases:      []string{"wyse60"},
-		Columns:      80,
-		Lines:        24,
-		Bell:         "\a",
-		Clear:        "\x1b+$<100>",
-		EnterCA:      "\x1bw0",
-		ExitCA:       "\x1bw1",
-		ShowCursor:   "\x1b`1",
-		HideCursor:   "\x1b`0",
-		AttrOff:      "\x1b(\x1bH\x03\x1bG0\x1bcD",
-		Underline:    "\x1bG8",
-		Dim:          "\x1bGp",
-		Blink:        "\x1bG2",
-		Reverse:      "\x1bG4",
-		PadChar:      "\x00",
-		AltChars:     "+/,.0[iha2fxgqh1jYk?lZm@nEqDtCu4vAwBx3yszr{c~~",
-		EnterAcs:     "\x1bcE",
-		ExitAcs:      "\x1bcD",
-		SetCursor:    "\x1b=%p1%' '%+%c%p2%' '%+%c",
-		CursorBack1:  "\b",
-		CursorUp1:    "\v",
-		KeyUp:        "\v",
-		KeyDown:      "\n",
-		KeyRight:     "\f",
-		KeyLeft:      "\b",
-		KeyInsert:    "\x1bQ",
-		KeyDelete:    "\x1bW",
-		KeyBackspace: "\b",
-		KeyHome:      "\x1e",
-		KeyPgUp:      "\x1bJ",
-		KeyPgDn:      "\x1bK",
-		KeyF1:        "\x01@\r",
-		KeyF2:        "\x01A\r",
-		KeyF3:        "\x01B\r",
-		KeyF4:        "\x01C\r",
-		KeyF5:        "\x01D\r",
-		KeyF6:        "\x01E\r",
-		KeyF7:        "\x01F\r",
-		KeyF8:        "\x01G\r",
-		KeyF9:        "\x01H\r",
-		KeyF10:       "\x01I\r",
-		KeyF11:       "\x01J\r",
-		KeyF12:       "\x01K\r",
-		KeyF13:       "\x01L\r",
-		KeyF14:       "\x01M\r",
-		KeyF15:       "\x01N\r",
-		KeyF16:       "\x01O\r",
-		KeyPrint:     "\x1bP",
-		KeyBacktab:   "\x1bI",
-		KeyShfHome:   "\x1b{",
+		Name:           "wy60",
+		Aliases:        []string{"wyse60"},
+		Columns:        80,
+		Lines:          24,
+		Bell:           "\a",
+		Flash:          "\x1b`8$<100/>\x1b`9",
+		Clear:          "\x1b+$<100>",
+		EnterCA:        "\x1bw0",
+		ExitCA:         "\x1bw1",
+		ShowCursor:     "\x1b`1",
+		HideCursor:     "\x1b`0",
+		AttrOff:        "\x1b(\x1bH\x03\x1bG0\x1bcD",
+		Underline:      "\x1bG8",
+		Dim:            "\x1bGp",
+		Blink:          "\x1bG2",
+		Reverse:        "\x1bG4",
+		PadChar:        "\x00",
+		AltChars:       "+/,.0[iha2fxgqh1jYk?lZm@nEqDtCu4vAwBx3yszr{c~~",
+		EnterAcs:       "\x1bcE",
+		ExitAcs:        "\x1bcD",
+		SetCursor:      "\x1b=%p1%' '%+%c%p2%' '%+%c",
+		CursorBack1:    "\b",
+		CursorUp1:      "\v",
+		CursorDown1:    "\n",
+		CursorForward1: "\f",
+		CursorHome:     "\x1b{",
+		CarriageReturn: "\r",
+		ClearToEOL:     "\x1bT",
+		ExitUnderline:  "\x1bG0",
+		KeyUp:          "\v",
+		KeyDown:        "\n",
+		KeyRight:       "\f",
+		KeyLeft:        "\b",
+		KeyInsert:      "\x1bQ",
+		KeyDelete:      "\x1bW",
+		KeyBackspace:   "\b",
+		KeyHome:        "\x1e",
+		KeyPgUp:        "\x1bJ",
+		KeyPgDn:        "\x1bK",
+		KeyF1:          "\x01@\r",
+		KeyF2:          "\x01A\r",
+		KeyF3:          "\x01B\r",
+		KeyF4:          "\x01C\r",
+		KeyF5:          "\x01D\r",
+		KeyF6:          "\x01E\r",
+		KeyF7:          "\x01F\r",
+		KeyF8:          "\x01G\r",
+		KeyF9:          "\x01H\r",
+		KeyF10:         "\x01I\r",
+		KeyF11:         "\x01J\r",
+		KeyF12:         "\x01K\r",
+		KeyF13:         "\x01L\r",
+		KeyF14:         "\x01M\r",
+		KeyF15:         "\x01N\r",
+		KeyF16:         "\x01O\r",
+		KeyPrint:       "\x1bP",
+		KeyBacktab:     "\x1bI",
+		KeyShfHome:     "\x1b{",
 	})
 	AddTerminfo(&Terminfo{
-		Name:         "wy99-ansi",
-		Columns:      80,
-		Lines:        25,
-		Bell:         "\a",
-		Clear:        "\x1b[H\x1b[J$<200>",
-		ShowCursor:   "\x1b[34h\x1b[?25h",
-		HideCursor:   "\x1b[?25l",
-		AttrOff:      "\x1b[m\x0f\x1b[\"q",
-		Underline:    "\x1b[4m",
-		Bold:         "\x1b[1m",
-		Dim:          "\x1b[2m",
-		Blink:        "\x1b[5m",
-		Reverse:      "\x1b[7m",
-		EnterKeypad:  "\x1b[?1h",
-		ExitKeypad:   "\x1b[?1l",
-		PadChar:      "\x00",
-		AltChars:     "``aaffggjjkkllmmnnooqqssttuuvvwwxx{{||}}~~",
-		EnterAcs:     "\x0e",
-		ExitAcs:      "\x0f",
-		EnableAcs:    "\x1b)0",
-		SetCursor:    "\x1b[%i%p1%d;%p2%dH",
-		CursorBack1:  "\b$<1>",
-		CursorUp1:    "\x1bM",
-		KeyUp:        "\x1bOA",
-		KeyDown:      "\x1bOB",
-		KeyRight:     "\x1bOC",
-		KeyLeft:      "\x1bOD",
-		KeyBackspace: "\b",
-		KeyF1:        "\x1bOP",
-		KeyF2:        "\x1bOQ",
-		KeyF3:        "\x1bOR",
-		KeyF4:        "\x1bOS",
-		KeyF5:        "\x1b[M",
-		KeyF6:        "\x1b[17~",
-		KeyF7:        "\x1b[18~",
-		KeyF8:        "\x1b[19~",
-		KeyF9:        "\x1b[20~",
-		KeyF10:       "\x1b[21~",
-		KeyF11:       "\x1b[23~",
-		KeyF12:       "\x1b[24~",
-		KeyF17:       "\x1b[K",
-		KeyF18:       "\x1b[31~",
-		KeyF19:       "\x1b[32~",
-		KeyF20:       "\x1b[33~",
-		KeyF21:       "\x1b[34~",
-		KeyF22:       "\x1b[35~",
-		KeyF23:       "\x1b[1~",
-		KeyF24:       "\x1b[2~",
-		KeyBacktab:   "\x1b[z",
+		Name:           "wy99-ansi",
+		Columns:        80,
+		Lines:          25,
+		Bell:           "\a",
+		Flash:          "\x1b[?5h$<30/>\x1b[?5l",
+		Clear:          "\x1b[H\x1b[J$<200>",
+		ShowCursor:     "\x1b[34h\x1b[?25h",
+		HideCursor:     "\x1b[?25l",
+		AttrOff:        "\x1b[m\x0f\x1b[\"q",
+		Underline:      "\x1b[4m",
+		Bold:           "\x1b[1m",
+		Dim:            "\x1b[2m",
+		Blink:          "\x1b[5m",
+		Reverse:        "\x1b[7m",
+		EnterKeypad:    "\x1b[?1h",
+		ExitKeypad:     "\x1b[?1l",
+		PadChar:        "\x00",
+		AltChars:       "``aaffggjjkkllmmnnooqqssttuuvvwwxx{{||}}~~",
+		EnterAcs:       "\x0e",
+		ExitAcs:        "\x0f",
+		EnableAcs:      "\x1b)0",
+		SetCursor:      "\x1b[%i%p1%d;%p2%dH",
+		CursorBack1:    "\b$<1>",
+		CursorUp1:      "\x1bM",
+		CursorDown1:    "\x1bD",
+		CursorForward1: "\x1b[C$<1>",
+		CursorBack:     "\x1b[%p1%dD$<1>",
+		CursorUp:       "\x1b[%p1%dA",
+		CursorDown:     "\x1b[%p1%dB",
+		CursorForward:  "\x1b[%p1%dC$<1>",
+		CursorHome:     "\x1b[H",
+		CarriageReturn: "\r",
+		ColumnAddress:  "\x1b[%i%p1%dG",
+		RowAddress:     "\x1b[%i%p1%dd",
+		ClearToEOL:     "\x1b[K$<1>",
+		EraseChars:     "\x1b[%p1%dX",
+		ExitUnderline:  "\x1b[24m",
+		KeyUp:          "\x1bOA",
+		KeyDown:        "\x1bOB",
+		KeyRight:       "\x1bOC",
+		KeyLeft:        "\x1bOD",
+		KeyBackspace:   "\b",
+		KeyF1:          "\x1bOP",
+		KeyF2:          "\x1bOQ",
+		KeyF3:          "\x1bOR",
+		KeyF4:          "\x1bOS",
+		KeyF5:          "\x1b[M",
+		KeyF6:          "\x1b[17~",
+		KeyF7:          "\x1b[18~",
+		KeyF8:          "\x1b[19~",
+		KeyF9:          "\x1b[20~",
+		KeyF10:         "\x1b[21~",
+		KeyF11:         "\x1b[23~",
+		KeyF12:         "\x1b[24~",
+		KeyF17:         "\x1b[K",
+		KeyF18:         "\x1b[31~",
+		KeyF19:         "\x1b[32~",
+		KeyF20:         "\x1b[33~",
+		KeyF21:         "\x1b[34~",
+		KeyF22:         "\x1b[35~",
+		KeyF23:         "\x1b[1~",
+		KeyF24:         "\x1b[2~",
+		KeyBacktab:     "\x1b[z",
 	})
 	AddTerminfo(&Terminfo{
-		Name:         "wy99a-ansi",
-		Columns:      80,
-		Lines:        25,
-		Bell:         "\a",
-		Clear:        "\x1b[H\x1b[J$<200>",
-		ShowCursor:   "\x1b[34h\x1b[?25h",
-		HideCursor:   "\x1b[?25l",
-		AttrOff:      "\x1b[m\x0f\x1b[\"q",
-		Underline:    "\x1b[4m",
-		Bold:         "\x1b[1m",
-		Dim:          "\x1b[2m",
-		Blink:        "\x1b[5m",
-		Reverse:      "\x1b[7m",
-		EnterKeypad:  "\x1b[?1h",
-		ExitKeypad:   "\x1b[?1l",
-		PadChar:      "\x00",
-		AltChars:     "``aaffggjjkkllmmnnooqqssttuuvvwwxx{{||}}~~",
-		EnterAcs:     "\x0e",
-		ExitAcs:      "\x0f",
-		EnableAcs:    "\x1b)0",
-		SetCursor:    "\x1b[%i%p1%d;%p2%dH",
-		CursorBack1:  "\b$<1>",
-		CursorUp1:    "\x1bM",
-		KeyUp:        "\x1bOA",
-		KeyDown:      "\x1bOB",
-		KeyRight:     "\x1bOC",
-		KeyLeft:      "\x1bOD",
-		KeyBackspace: "\b",
-		KeyF1:        "\x1bOP",
-		KeyF2:        "\x1bOQ",
-		KeyF3:        "\x1bOR",
-		KeyF4:        "\x1bOS",
-		KeyF5:        "\x1b[M",
-		KeyF6:        "\x1b[17~",
-		KeyF7:        "\x1b[18~",
-		KeyF8:        "\x1b[19~",
-		KeyF9:        "\x1b[20~",
-		KeyF10:       "\x1b[21~",
-		KeyF11:       "\x1b[23~",
-		KeyF12:       "\x1b[24~",
-		KeyF17:       "\x1b[K",
-		KeyF18:       "\x1b[31~",
-		KeyF19:       "\x1b[32~",
-		KeyF20:       "\x1b[33~",
-		KeyF21:       "\x1b[34~",
-		KeyF22:       "\x1b[35~",
-		KeyF23:       "\x1b[1~",
-		KeyF24:       "\x1b[2~",
-		KeyBacktab:   "\x1b[z",
+		Name:           "wy99a-ansi",
+		Columns:        80,
+		Lines:          25,
+		Bell:           "\a",
+		Flash:          "\x1b[?5h$<30/>\x1b[?5l",
+		Clear:          "\x1b[H\x1b[J$<200>",
+		ShowCursor:     "\x1b[34h\x1b[?25h",
+		HideCursor:     "\x1b[?25l",
+		AttrOff:        "\x1b[m\x0f\x1b[\"q",
+		Underline:      "\x1b[4m",
+		Bold:           "\x1b[1m",
+		Dim:            "\x1b[2m",
+		Blink:          "\x1b[5m",
+		Reverse:        "\x1b[7m",
+		EnterKeypad:    "\x1b[?1h",
+		ExitKeypad:     "\x1b[?1l",
+		PadChar:        "\x00",
+		AltChars:       "``aaffggjjkkllmmnnooqqssttuuvvwwxx{{||}}~~",
+		EnterAcs:       "\x0e",
+		ExitAcs:        "\x0f",
+		EnableAcs:      "\x1b)0",
+		SetCursor:      "\x1b[%i%p1%d;%p2%dH",
+		CursorBack1:    "\b$<1>",
+		CursorUp1:      "\x1bM",
+		CursorDown1:    "\x1bD",
+		CursorForward1: "\x1b[C$<1>",
+		CursorBack:     "\x1b[%p1%dD$<1>",
+		CursorUp:       "\x1b[%p1%dA",
+		CursorDown:     "\x1b[%p1%dB",
+		CursorForward:  "\x1b[%p1%dC$<1>",
+		CursorHome:     "\x1b[H",
+		CarriageReturn: "\r",
+		ColumnAddress:  "\x1b[%i%p1%dG",
+		RowAddress:     "\x1b[%i%p1%dd",
+		ClearToEOL:     "\x1b[K$<1>",
+		EraseChars:     "\x1b[%p1%dX",
+		ExitUnderline:  "\x1b[24m",
+		KeyUp:          "\x1bOA",
+		KeyDown:        "\x1bOB",
+		KeyRight:       "\x1bOC",
+		KeyLeft:        "\x1bOD",
+		KeyBackspace:   "\b",
+		KeyF1:          "\x1bOP",
+		KeyF2:          "\x1bOQ",
+		KeyF3:          "\x1bOR",
+		KeyF4:          "\x1bOS",
+		KeyF5:          "\x1b[M",
+		KeyF6:          "\x1b[17~",
+		KeyF7:          "\x1b[18~",
+		KeyF8:          "\x1b[19~",
+		KeyF9:          "\x1b[20~",
+		KeyF10:         "\x1b[21~",
+		KeyF11:         "\x1b[23~",
+		KeyF12:         "\x1b[24~",
+		KeyF17:         "\x1b[K",
+		KeyF18:         "\x1b[31~",
+		KeyF19:         "\x1b[32~",
+		KeyF20:         "\x1b[33~",
+		KeyF21:         "\x1b[34~",
+		KeyF22:         "\x1b[35~",
+		KeyF23:         "\x1b[1~",
+		KeyF24:         "\x1b[2~",
+		KeyBacktab:     "\x1b[z",
 	})
 	AddTerminfo(&Terminfo{
 		Name:            "xfce",
@@ -2692,6 +3338,7 @@ func init() {
 		Lines:           24,
 		Colors:          8,
 		Bell:            "\a",
+		Flash:           "\x1b[?5h$<100/>\x1b[?5l",
 		Clear:           "\x1b[H\x1b[2J",
 		EnterCA:         "\x1b7\x1b[?47h",
 		ExitCA:          "\x1b[2J\x1b[?47l\x1b8",
@@ -2716,6 +3363,29 @@ func init() {
 		SetCursor:       "\x1b[%i%p1%d;%p2%dH",
 		CursorBack1:     "\b",
 		CursorUp1:       "\x1b[A",
+		CursorDown1:     "\n",
+		CursorForward1:  "\x1b[C",
+		CursorBack:      "\x1b[%p1%dD",
+		CursorUp:        "\x1b[%p1%dA",
+		CursorDown:      "\x1b[%p1%dB",
+		CursorForward:   "\x1b[%p1%dC",
+		CursorHome:      "\x1b[H",
+		CarriageReturn:  "\r",
+		ColumnAddress:   "\x1b[%i%p1%dG",
+		RowAddress:      "\x1b[%i%p1%dd",
+		ClearToEOL:      "\x1b[K",
+		EraseChars:      "\x1b[%p1%dX",
+		BackColorErase:  true,
+		ExitUnderline:   "\x1b[m",
+		ResetFgBg:       "\x1b[39;49m",
+		EnablePaste:     "\x1b[?2004h",
+		DisablePaste:    "\x1b[?2004l",
+		PasteStart:      "\x1b[200~",
+		PasteEnd:        "\x1b[201~",
+		EnableFocus:     "\x1b[?1004h",
+		DisableFocus:    "\x1b[?1004l",
+		FocusIn:         "\x1b[I",
+		FocusOut:        "\x1b[O",
 		KeyUp:           "\x1bOA",
 		KeyDown:         "\x1bOB",
 		KeyRight:        "\x1bOC",
@@ -2833,29 +3503,41 @@ func init() {
 		KeyAltShfEnd:    "\x1b[1;4F",
 	})
 	AddTerminfo(&Terminfo{
-		Name:         "xnuppc",
-		Columns:      -1,
-		Lines:        -1,
-		Colors:       8,
-		Clear:        "\x1b[H\x1b[J",
-		AttrOff:      "\x1b[m\x0f",
-		Underline:    "\x1b[4m",
-		Bold:         "\x1b[1m",
-		Reverse:      "\x1b[7m",
-		EnterKeypad:  "\x1b[?1h\x1b=",
-		ExitKeypad:   "\x1b[?1l\x1b>",
-		SetFg:        "\x1b[3%p1%dm",
-		SetBg:        "\x1b[4%p1%dm",
-		SetFgBg:      "\x1b[3%p1%d;4%p2%dm",
-		PadChar:      "\x00",
-		SetCursor:    "\x1b[%i%p1%d;%p2%dH",
-		CursorBack1:  "\x1b[D",
-		CursorUp1:    "\x1b[A",
-		KeyUp:        "\x1bOA",
-		KeyDown:      "\x1bOB",
-		KeyRight:     "\x1bOC",
-		KeyLeft:      "\x1bOD",
-		KeyBackspace: "\u007f",
+		Name:           "xnuppc",
+		Columns:        -1,
+		Lines:          -1,
+		Colors:         8,
+		Clear:          "\x1b[H\x1b[J",
+		AttrOff:        "\x1b[m\x0f",
+		Underline:      "\x1b[4m",
+		Bold:           "\x1b[1m",
+		Reverse:        "\x1b[7m",
+		EnterKeypad:    "\x1b[?1h\x1b=",
+		ExitKeypad:     "\x1b[?1l\x1b>",
+		SetFg:          "\x1b[3%p1%dm",
+		SetBg:          "\x1b[4%p1%dm",
+		SetFgBg:        "\x1b[3%p1%d;4%p2%dm",
+		PadChar:        "\x00",
+		SetCursor:      "\x1b[%i%p1%d;%p2%dH",
+		CursorBack1:    "\x1b[D",
+		CursorUp1:      "\x1b[A",
+		CursorDown1:    "\x1b[B",
+		CursorForward1: "\x1b[C",
+		CursorBack:     "\x1b[%p1%dD",
+		CursorUp:       "\x1b[%p1%dA",
+		CursorDown:     "\x1b[%p1%dB",
+		CursorForward:  "\x1b[%p1%dC",
+		CursorHome:     "\x1b[H",
+		CarriageReturn: "\r",
+		ClearToEOL:     "\x1b[K",
+		BackColorErase: true,
+		ExitUnderline:  "\x1b[m",
+		ResetFgBg:      "\x1b[37;40m",
+		KeyUp:          "\x1bOA",
+		KeyDown:        "\x1bOB",
+		KeyRight:       "\x1bOC",
+		KeyLeft:        "\x1bOD",
+		KeyBackspace:   "\u007f",
 	})
 	AddTerminfo(&Terminfo{
 		Name:            "xterm",
@@ -2863,6 +3545,7 @@ func init() {
 		Lines:           24,
 		Colors:          8,
 		Bell:            "\a",
+		Flash:           "\x1b[?5h$<100/>\x1b[?5l",
 		Clear:           "\x1b[H\x1b[2J",
 		EnterCA:         "\x1b[?1049h",
 		ExitCA:          "\x1b[?1049l",
@@ -2886,6 +3569,35 @@ func init() {
 		SetCursor:       "\x1b[%i%p1%d;%p2%dH",
 		CursorBack1:     "\b",
 		CursorUp1:       "\x1b[A",
+		CursorDown1:     "\n",
+		CursorForward1:  "\x1b[C",
+		CursorBack:      "\x1b[%p1%dD",
+		CursorUp:        "\x1b[%p1%dA",
+		CursorDown:      "\x1b[%p1%dB",
+		CursorForward:   "\x1b[%p1%dC",
+		CursorHome:      "\x1b[H",
+		CarriageReturn:  "\r",
+		ColumnAddress:   "\x1b[%i%p1%dG",
+		RowAddress:      "\x1b[%i%p1%dd",
+		ClearToEOL:      "\x1b[K",
+		EraseChars:      "\x1b[%p1%dX",
+		RepeatChar:      "%p1%c\x1b[%p2%{1}%-%db",
+		BackColorErase:  true,
+		ExitUnderline:   "\x1b[24m",
+		ResetFgBg:       "\x1b[39;49m",
+		EnablePaste:     "\x1b[?2004h",
+		DisablePaste:    "\x1b[?2004l",
+		PasteStart:      "\x1b[200~",
+		PasteEnd:        "\x1b[201~",
+		EnableFocus:     "\x1b[?1004h",
+		DisableFocus:    "\x1b[?1004l",
+		FocusIn:         "\x1b[I",
+		FocusOut:        "\x1b[O",
+		SetClipboard:    "\x1b]52;%p1%s;%p2%s\a",
+		TitleStart:      "\x1b]2;",
+		TitleEnd:        "\a",
+		PushTitle:       "\x1b[22;0t",
+		PopTitle:        "\x1b[23;0t",
 		KeyUp:           "\x1bOA",
 		KeyDown:         "\x1bOB",
 		KeyRight:        "\x1bOC",
@@ -3008,6 +3720,7 @@ func init() {
 		Lines:           24,
 		Colors:          256,
 		Bell:            "\a",
+		Flash:           "\x1b[?5h$<100/>\x1b[?5l",
 		Clear:           "\x1b[H\x1b[2J",
 		EnterCA:         "\x1b[?1049h",
 		ExitCA:          "\x1b[?1049l",
@@ -3031,6 +3744,35 @@ func init() {
 		SetCursor:       "\x1b[%i%p1%d;%p2%dH",
 		CursorBack1:     "\b",
 		CursorUp1:       "\x1b[A",
+		CursorDown1:     "\n",
+		CursorForward1:  "\x1b[C",
+		CursorBack:      "\x1b[%p1%dD",
+		CursorUp:        "\x1b[%p1%dA",
+		CursorDown:      "\x1b[%p1%dB",
+		CursorForward:   "\x1b[%p1%dC",
+		CursorHome:      "\x1b[H",
+		CarriageReturn:  "\r",
+		ColumnAddress:   "\x1b[%i%p1%dG",
+		RowAddress:      "\x1b[%i%p1%dd",
+		ClearToEOL:      "\x1b[K",
+		EraseChars:      "\x1b[%p1%dX",
+		RepeatChar:      "%p1%c\x1b[%p2%{1}%-%db",
+		BackColorErase:  true,
+		ExitUnderline:   "\x1b[24m",
+		ResetFgBg:       "\x1b[39;49m",
+		EnablePaste:     "\x1b[?2004h",
+		DisablePaste:    "\x1b[?2004l",
+		PasteStart:      "\x1b[200~",
+		PasteEnd:        "\x1b[201~",
+		EnableFocus:     "\x1b[?1004h",
+		DisableFocus:    "\x1b[?1004l",
+		FocusIn:         "\x1b[I",
+		FocusOut:        "\x1b[O",
+		SetClipboard:    "\x1b]52;%p1%s;%p2%s\a",
+		TitleStart:      "\x1b]2;",
+		TitleEnd:        "\a",
+		PushTitle:       "\x1b[22;0t",
+		PopTitle:        "\x1b[23;0t",
 		KeyUp:           "\x1bOA",
 		KeyDown:         "\x1bOB",
 		KeyRight:        "\x1bOC",
@@ -3153,6 +3895,7 @@ func init() {
 		Lines:           24,
 		Colors:          8,
 		Bell:            "\a",
+		Flash:           "\x1b[?5h$<100/>\x1b[?5l",
 		Clear:           "\x1b[H\x1b[2J",
 		EnterCA:         "\x1b[?1049h",
 		ExitCA:          "\x1b[?1049l",
@@ -3176,6 +3919,35 @@ func init() {
 		SetCursor:       "\x1b[%i%p1%d;%p2%dH",
 		CursorBack1:     "\b",
 		CursorUp1:       "\x1b[A",
+		CursorDown1:     "\n",
+		CursorForward1:  "\x1b[C",
+		CursorBack:      "\x1b[%p1%dD",
+		CursorUp:        "\x1b[%p1%dA",
+		CursorDown:      "\x1b[%p1%dB",
+		CursorForward:   "\x1b[%p1%dC",
+		CursorHome:      "\x1b[H",
+		CarriageReturn:  "\r",
+		ColumnAddress:   "\x1b[%i%p1%dG",
+		RowAddress:      "\x1b[%i%p1%dd",
+		ClearToEOL:      "\x1b[K",
+		EraseChars:      "\x1b[%p1%dX",
+		RepeatChar:      "%p1%c\x1b[%p2%{1}%-%db",
+		BackColorErase:  true,
+		ExitUnderline:   "\x1b[24m",
+		ResetFgBg:       "\x1b[39;49m",
+		EnablePaste:     "\x1b[?2004h",
+		DisablePaste:    "\x1b[?2004l",
+		PasteStart:      "\x1b[200~",
+		PasteEnd:        "\x1b[201~",
+		EnableFocus:     "\x1b[?1004h",
+		DisableFocus:    "\x1b[?1004l",
+		FocusIn:         "\x1b[I",
+		FocusOut:        "\x1b[O",
+		SetClipboard:    "\x1b]52;%p1%s;%p2%s\a",
+		TitleStart:      "\x1b]2;",
+		TitleEnd:        "\a",
+		PushTitle:       "\x1b[22;0t",
+		PopTitle:        "\x1b[23;0t",
 		KeyUp:           "\x1bOA",
 		KeyDown:         "\x1bOB",
 		KeyRight:        "\x1bOC",
@@ -3298,6 +4070,7 @@ func init() {
 		Lines:           24,
 		Colors:          256,
 		Bell:            "\a",
+		Flash:           "\x1b[?5h$<100/>\x1b[?5l",
 		Clear:           "\x1b[H\x1b[2J",
 		EnterCA:         "\x1b[?1049h",
 		ExitCA:          "\x1b[?1049l",
@@ -3321,6 +4094,35 @@ func init() {
 		SetCursor:       "\x1b[%i%p1%d;%p2%dH",
 		CursorBack1:     "\b",
 		CursorUp1:       "\x1b[A",
+		CursorDown1:     "\n",
+		CursorForward1:  "\x1b[C",
+		CursorBack:      "\x1b[%p1%dD",
+		CursorUp:        "\x1b[%p1%dA",
+		CursorDown:      "\x1b[%p1%dB",
+		CursorForward:   "\x1b[%p1%dC",
+		CursorHome:      "\x1b[H",
+		CarriageReturn:  "\r",
+		ColumnAddress:   "\x1b[%i%p1%dG",
+		RowAddress:      "\x1b[%i%p1%dd",
+		ClearToEOL:      "\x1b[K",
+		EraseChars:      "\x1b[%p1%dX",
+		RepeatChar:      "%p1%c\x1b[%p2%{1}%-%db",
+		BackColorErase:  true,
+		ExitUnderline:   "\x1b[24m",
+		ResetFgBg:       "\x1b[39;49m",
+		EnablePaste:     "\x1b[?2004h",
+		DisablePaste:    "\x1b[?2004l",
+		PasteStart:      "\x1b[200~",
+		PasteEnd:        "\x1b[201~",
+		EnableFocus:     "\x1b[?1004h",
+		DisableFocus:    "\x1b[?1004l",
+		FocusIn:         "\x1b[I",
+		FocusOut:        "\x1b[O",
+		SetClipboard:    "\x1b]52;%p1%s;%p2%s\a",
+		TitleStart:      "\x1b]2;",
+		TitleEnd:        "\a",
+		PushTitle:       "\x1b[22;0t",
+		PopTitle:        "\x1b[23;0t",
 		KeyUp:           "\x1bOA",
 		KeyDown:         "\x1bOB",
 		KeyRight:        "\x1bOC",
@@ -3443,6 +4245,7 @@ func init() {
 		Lines:           24,
 		Colors:          256,
 		Bell:            "\a",
+		Flash:           "\x1b[?5h$<100/>\x1b[?5l",
 		Clear:           "\x1b[H\x1b[2J",
 		EnterCA:         "\x1b[?1049h",
 		ExitCA:          "\x1b[?1049l",
@@ -3469,6 +4272,35 @@ func init() {
 		SetCursor:       "\x1b[%i%p1%d;%p2%dH",
 		CursorBack1:     "\b",
 		CursorUp1:       "\x1b[A",
+		CursorDown1:     "\n",
+		CursorForward1:  "\x1b[C",
+		CursorBack:      "\x1b[%p1%dD",
+		CursorUp:        "\x1b[%p1%dA",
+		CursorDown:      "\x1b[%p1%dB",
+		CursorForward:   "\x1b[%p1%dC",
+		CursorHome:      "\x1b[H",
+		CarriageReturn:  "\r",
+		ColumnAddress:   "\x1b[%i%p1%dG",
+		RowAddress:      "\x1b[%i%p1%dd",
+		ClearToEOL:      "\x1b[K",
+		EraseChars:      "\x1b[%p1%dX",
+		RepeatChar:      "%p1%c\x1b[%p2%{1}%-%db",
+		BackColorErase:  true,
+		ExitUnderline:   "\x1b[24m",
+		ResetFgBg:       "\x1b[39;49m",
+		EnablePaste:     "\x1b[?2004h",
+		DisablePaste:    "\x1b[?2004l",
+		PasteStart:      "\x1b[200~",
+		PasteEnd:        "\x1b[201~",
+		EnableFocus:     "\x1b[?1004h",
+		DisableFocus:    "\x1b[?1004l",
+		FocusIn:         "\x1b[I",
+		FocusOut:        "\x1b[O",
+		SetClipboard:    "\x1b]52;%p1%s;%p2%s\a",
+		TitleStart:      "\x1b]2;",
+		TitleEnd:        "\a",
+		PushTitle:       "\x1b[22;0t",
+		PopTitle:        "\x1b[23;0t",
 		KeyUp:           "\x1bOA",
 		KeyDown:         "\x1bOB",
 		KeyRight:        "\x1bOC",
@@ -128,6 +128,35 @@ func GetEncoding(charset string) encoding.Encoding {
 	return nil
 }
 
+// LocaleCharset returns the character set named by the POSIX locale,
+// whose environment variables are looked up with getenv.  As POSIX says,
+// LC_ALL is tried first, then LC_CTYPE, and finally LANG; the first one
+// set wins.  This is how the character set of a local terminal is found,
+// and it serves equally well for the environment of a remote client.
+func LocaleCharset(getenv func(string) string) string {
+	locale := ""
+	if locale = getenv("LC_ALL"); locale == "" {
+		if locale = getenv("LC_CTYPE"); locale == "" {
+			locale = getenv("LANG")
+		}
+	}
+	if locale == "POSIX" || locale == "C" {
+		return "US-ASCII"
+	}
+	if i := strings.IndexRune(locale, '@'); i >= 0 {
+		locale = locale[:i]
+	}
+	if i := strings.IndexRune(locale, '.'); i >= 0 {
+		locale = locale[i+1:]
+	} else {
+		// Default assumption, and on Linux we can see LC_ALL
+		// without a character set, which we assume implies UTF-8.
+		return "UTF-8"
+	}
+	// XXX: add support for aliases
+	return locale
+}
+
 func init() {
 	// We always support UTF-8 and ASCII.
 	encodings = make(map[string]encoding.Encoding)
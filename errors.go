@@ -46,6 +46,10 @@ var (
 	// ErrEventQFull indicates that the event queue is full, and
 	// cannot accept more events.
 	ErrEventQFull = errors.New("event queue full")
+
+	// ErrScreenFini indicates that the screen has already been
+	// finalized, and can no longer be used.
+	ErrScreenFini = errors.New("screen already finalized")
 )
 
 // An EventError is an event representing some sort of error, and carries
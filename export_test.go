@@ -0,0 +1,37 @@
+// Copyright 2017 The TCell Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use file except in compliance with the License.
+// You may obtain a copy of the license at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tcell
+
+import (
+	"sort"
+)
+
+// This file exposes internals to the tests in package tcell_test, which
+// can import packages (such as vt) that themselves depend on tcell.
+
+// BuiltinTerminfos returns the names of the terminals in the built-in
+// database, leaving out aliases.
+func BuiltinTerminfos() []string {
+	dblock.Lock()
+	defer dblock.Unlock()
+	var names []string
+	for name, ti := range terminfos {
+		if ti.Name == name {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,45 @@
+// Copyright 2017 The TCell Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use file except in compliance with the License.
+// You may obtain a copy of the license at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tcell
+
+import (
+	"time"
+)
+
+// EventFocus is sent when the terminal window gains or loses the focus,
+// once EnableFocus has been called, if the terminal reports that.
+// Applications can use it to stop doing work, such as animating, that
+// nobody is looking at.
+type EventFocus struct {
+	t       time.Time
+	focused bool
+}
+
+// NewEventFocus creates an EventFocus, for gaining the focus if focused is
+// true, and for losing it otherwise.
+func NewEventFocus(focused bool) *EventFocus {
+	return &EventFocus{t: time.Now(), focused: focused}
+}
+
+// When returns the time when this event was created.
+func (ev *EventFocus) When() time.Time {
+	return ev.t
+}
+
+// Focused returns true if the window gained the focus, and false if it
+// lost it.
+func (ev *EventFocus) Focused() bool {
+	return ev.focused
+}
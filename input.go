@@ -0,0 +1,742 @@
+// Copyright 2017 The TCell Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use file except in compliance with the License.
+// You may obtain a copy of the license at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tcell
+
+import (
+	"bytes"
+	"encoding/base64"
+	"sync"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/transform"
+)
+
+// InputDecoder turns the bytes sent by a terminal, as described by its
+// terminfo entry, into key and mouse events.  It is what the terminfo and
+// quasi screens use to decode their input, but it can be used with any
+// transport: write the bytes received to it as they arrive, and call
+// Flush once no more have arrived for a short while (the screens wait
+// 50 milliseconds), so that a lone escape key can be told apart from the
+// start of an escape sequence.
+//
+// Text pasted while the terminal is in bracketed paste mode is collected
+// until the terminal marks its end, however long that takes, and then
+// delivered as a single EventPaste; only a paste of more than a megabyte
+// arrives in several.  Likewise, the contents of the clipboard, sent in
+// reply to GetClipboard, are waited for until they have all arrived, but
+// are dropped if they come to more than a megabyte.
+//
+// Events are passed to a function as they are decoded.  That function is
+// called with the decoder locked, so it must not call the decoder.
+type InputDecoder struct {
+	ti       *Terminfo
+	keys     *keyTable
+	decoder  transform.Transformer
+	post     func(Event)
+	buf      bytes.Buffer
+	w        int
+	h        int
+	escaped  bool
+	wasbtn   bool
+	buttondn bool
+	pasting  bool
+	paste    bytes.Buffer
+	clipping bool
+	clipdrop bool // the clipboard reply is too large to keep
+	failed   bool // some input was not understood
+
+	sync.Mutex
+}
+
+// NewInputDecoder returns a decoder for input from the given terminal,
+// in the given character encoding, which passes each event decoded to
+// post.  If the encoding is nil, UTF-8 is assumed.
+func NewInputDecoder(ti *Terminfo, enc encoding.Encoding, post func(Event)) *InputDecoder {
+	if enc == nil {
+		enc = GetEncoding("UTF-8")
+	}
+	d := &InputDecoder{
+		ti:      ti,
+		keys:    keyTableFor(ti),
+		decoder: enc.NewDecoder(),
+		post:    post,
+	}
+	return d
+}
+
+// Write decodes the bytes given, posting an event for each complete key
+// or mouse report.  Any incomplete escape sequence at the end is kept,
+// to be completed by further input or delivered as it is by Flush.  It
+// never fails.
+func (d *InputDecoder) Write(b []byte) (int, error) {
+	d.Lock()
+	d.buf.Write(b)
+	d.scan(&d.buf, false)
+	d.Unlock()
+	return len(b), nil
+}
+
+// Flush delivers any incomplete input as it stands.  A lone escape byte
+// becomes the escape key, for example.
+func (d *InputDecoder) Flush() {
+	d.Lock()
+	d.scan(&d.buf, true)
+	d.Unlock()
+}
+
+// understood reports whether all of the input since the last call was
+// understood, rather than some of it being discarded or delivered as it
+// stood.
+func (d *InputDecoder) understood() bool {
+	d.Lock()
+	defer d.Unlock()
+	ok := !d.failed
+	d.failed = false
+	return ok
+}
+
+// Pending reports whether there is incomplete input, waiting for more to
+// arrive or for Flush to be called.  Pasted text and clipboard contents
+// can only be ended by the terminal, not by Flush, so they do not count.
+func (d *InputDecoder) Pending() bool {
+	d.Lock()
+	defer d.Unlock()
+	return d.buf.Len() != 0 && !d.pasting && !d.clipping
+}
+
+// Reset discards any incomplete input.
+func (d *InputDecoder) Reset() {
+	d.Lock()
+	d.buf.Reset()
+	d.escaped = false
+	d.pasting = false
+	d.paste.Reset()
+	d.clipping = false
+	d.clipdrop = false
+	d.Unlock()
+}
+
+// SetSize sets the size of the screen.  Mouse positions outside of it are
+// moved to its edge, as some terminals report positions beyond the window
+// while dragging.  Until the size is set, only negative positions are
+// adjusted.
+func (d *InputDecoder) SetSize(w, h int) {
+	d.Lock()
+	d.w, d.h = w, h
+	d.Unlock()
+}
+
+// HasKey reports whether the terminal can send the given key.
+func (d *InputDecoder) HasKey(k Key) bool {
+	if k == KeyRune {
+		return true
+	}
+	return d.keys.exist[k]
+}
+
+func (d *InputDecoder) clip(x, y int) (int, int) {
+	w, h := d.w, d.h
+	if x < 0 {
+		x = 0
+	}
+	if y < 0 {
+		y = 0
+	}
+	if x > w-1 && w > 0 {
+		x = w - 1
+	}
+	if y > h-1 && h > 0 {
+		y = h - 1
+	}
+	return x, y
+}
+
+func (d *InputDecoder) postMouseEvent(x, y, btn int) {
+
+	// XTerm mouse events only report at most one button at a time,
+	// which may include a wheel button.  Wheel motion events are
+	// reported as single impulses, while other button events are reported
+	// as separate press & release events.
+
+	button := ButtonNone
+	mod := ModNone
+
+	// Mouse wheel has bit 6 set, no release events.  It should be noted
+	// that wheel events are sometimes misdelivered as mouse button events
+	// during a click-drag, so we debounce these, considering them to be
+	// button press events unless we see an intervening release event.
+	switch btn & 0x43 {
+	case 0:
+		button = Button1
+		d.wasbtn = true
+	case 1:
+		button = Button2
+		d.wasbtn = true
+	case 2:
+		button = Button3
+		d.wasbtn = true
+	case 3:
+		button = ButtonNone
+		d.wasbtn = false
+	case 0x40:
+		if !d.wasbtn {
+			button = WheelUp
+		} else {
+			button = Button1
+		}
+	case 0x41:
+		if !d.wasbtn {
+			button = WheelDown
+		} else {
+			button = Button2
+		}
+	}
+
+	if btn&0x4 != 0 {
+		mod |= ModShift
+	}
+	if btn&0x8 != 0 {
+		mod |= ModAlt
+	}
+	if btn&0x10 != 0 {
+		mod |= ModCtrl
+	}
+
+	// Some terminals will report mouse coordinates outside the
+	// screen, especially with click-drag events.  Clip the coordinates
+	// to the screen in that case.
+	x, y = d.clip(x, y)
+
+	ev := NewEventMouse(x, y, button, mod)
+	d.post(ev)
+}
+
+// parseSgrMouse attempts to locate an SGR mouse record at the start of the
+// buffer.  It returns true, true if it found one, and the associated bytes
+// be removed from the buffer.  It returns true, false if the buffer might
+// contain such an event, but more bytes are necessary (partial match), and
+// false, false if the content is definitely *not* an SGR mouse record.
+func (d *InputDecoder) parseSgrMouse(buf *bytes.Buffer) (bool, bool) {
+
+	b := buf.Bytes()
+
+	var x, y, btn, state int
+	dig := false
+	neg := false
+	motion := false
+	i := 0
+	val := 0
+
+	for i = range b {
+		switch b[i] {
+		case '\x1b':
+			if state != 0 {
+				return false, false
+			}
+			state = 1
+
+		case '\x9b':
+			if state != 0 {
+				return false, false
+			}
+			state = 2
+
+		case '[':
+			if state != 1 {
+				return false, false
+			}
+			state = 2
+
+		case '<':
+			if state != 2 {
+				return false, false
+			}
+			val = 0
+			dig = false
+			neg = false
+			state = 3
+
+		case '-':
+			if state != 3 && state != 4 && state != 5 {
+				return false, false
+			}
+			if dig || neg {
+				return false, false
+			}
+			neg = true // stay in state
+
+		case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
+			if state != 3 && state != 4 && state != 5 {
+				return false, false
+			}
+			val *= 10
+			val += int(b[i] - '0')
+			dig = true // stay in state
+
+		case ';':
+			if neg {
+				val = -val
+			}
+			switch state {
+			case 3:
+				btn, val = val, 0
+				neg, dig, state = false, false, 4
+			case 4:
+				x, val = val-1, 0
+				neg, dig, state = false, false, 5
+			default:
+				return false, false
+			}
+
+		case 'm', 'M':
+			if state != 5 {
+				return false, false
+			}
+			if neg {
+				val = -val
+			}
+			y = val - 1
+
+			motion = (btn & 32) != 0
+			btn &^= 32
+			if b[i] == 'm' {
+				// mouse release, clear all buttons
+				btn |= 3
+				btn &^= 0x40
+				d.buttondn = false
+			} else if motion {
+				/*
+				 * Some broken terminals appear to send
+				 * mouse button one motion events, instead of
+				 * encoding 35 (no buttons) into these events.
+				 * We resolve these by looking for a non-motion
+				 * event first.
+				 */
+				if !d.buttondn {
+					btn |= 3
+					btn &^= 0x40
+				}
+			} else {
+				d.buttondn = true
+			}
+			// consume the event bytes
+			for i >= 0 {
+				buf.ReadByte()
+				i--
+			}
+			d.postMouseEvent(x, y, btn)
+			return true, true
+		}
+	}
+
+	// incomplete & inconclusve at this point
+	return true, false
+}
+
+// parseXtermMouse is like parseSgrMouse, but it parses a legacy
+// X11 mouse record.
+func (d *InputDecoder) parseXtermMouse(buf *bytes.Buffer) (bool, bool) {
+
+	b := buf.Bytes()
+
+	state := 0
+	btn := 0
+	x := 0
+	y := 0
+
+	for i := range b {
+		switch state {
+		case 0:
+			switch b[i] {
+			case '\x1b':
+				state = 1
+			case '\x9b':
+				state = 2
+			default:
+				return false, false
+			}
+		case 1:
+			if b[i] != '[' {
+				return false, false
+			}
+			state = 2
+		case 2:
+			if b[i] != 'M' {
+				return false, false
+			}
+			state++
+		case 3:
+			btn = int(b[i])
+			state++
+		case 4:
+			x = int(b[i]) - 32 - 1
+			state++
+		case 5:
+			y = int(b[i]) - 32 - 1
+			for i >= 0 {
+				buf.ReadByte()
+				i--
+			}
+			d.postMouseEvent(x, y, btn)
+			return true, true
+		}
+	}
+	return true, false
+}
+
+// parsePaste looks for the marker that starts pasted text, in the same way
+// as parseSgrMouse looks for a mouse record.
+func (d *InputDecoder) parsePaste(buf *bytes.Buffer) (bool, bool) {
+	b := buf.Bytes()
+	start := []byte(d.ti.PasteStart)
+	if !bytes.HasPrefix(b, start) {
+		return bytes.HasPrefix(start, b), false
+	}
+	buf.Next(len(start))
+	d.pasting = true
+	d.escaped = false
+	return true, true
+}
+
+// clipboardReply starts the terminal's reply to a request for the
+// clipboard.  It ends with BEL, or sometimes with ST.
+const clipboardReply = "\x1b]52;"
+
+// maxPaste is the most pasted text that is collected before it is
+// delivered, and maxClipboard the size of the largest clipboard reply
+// that is kept, so that a terminal that never ends either cannot use up
+// all of our memory.
+const (
+	maxPaste     = 1 << 20
+	maxClipboard = 1 << 20
+)
+
+// parseClipboard looks for the start of the contents of the clipboard.
+func (d *InputDecoder) parseClipboard(buf *bytes.Buffer) (bool, bool) {
+	b := buf.Bytes()
+	if !bytes.HasPrefix(b, []byte(clipboardReply)) {
+		return bytes.HasPrefix([]byte(clipboardReply), b), false
+	}
+	buf.Next(len(clipboardReply))
+	d.clipping = true
+	d.escaped = false
+	return true, true
+}
+
+// scanClipboard posts the contents of the clipboard, once they have all
+// arrived, and returns false until then.  They are the name of the
+// selection, a semicolon, and the data in base64; anything that is not
+// valid is dropped.
+func (d *InputDecoder) scanClipboard(buf *bytes.Buffer) bool {
+	b := buf.Bytes()
+	i, n := bytes.IndexByte(b, '\a'), 1
+	if j := bytes.Index(b, []byte("\x1b\\")); j >= 0 && (i < 0 || j < i) {
+		i, n = j, 2
+	}
+	if i < 0 {
+		// A reply too large to keep is dropped as it arrives, all
+		// but an escape that could start ST.
+		if len(b) > maxClipboard || d.clipdrop {
+			n := len(b)
+			if b[n-1] == '\x1b' {
+				n--
+			}
+			buf.Next(n)
+			d.clipdrop = true
+		}
+		return false
+	}
+	reply := b[:i]
+	d.clipping = false
+	if d.clipdrop {
+		d.clipdrop = false
+	} else if j := bytes.IndexByte(reply, ';'); j >= 0 {
+		reply = reply[j+1:]
+		data := make([]byte, base64.StdEncoding.DecodedLen(len(reply)))
+		if l, e := base64.StdEncoding.Decode(data, reply); e == nil {
+			d.post(NewEventClipboard(data[:l]))
+		}
+	}
+	buf.Next(i + n)
+	return true
+}
+
+// parseFocus looks for a report that the window has gained or lost the
+// focus.
+func (d *InputDecoder) parseFocus(buf *bytes.Buffer) (bool, bool) {
+	b := buf.Bytes()
+	partial := false
+	for _, f := range []struct {
+		seq     string
+		focused bool
+	}{{d.ti.FocusIn, true}, {d.ti.FocusOut, false}} {
+		if f.seq == "" {
+			continue
+		}
+		seq := []byte(f.seq)
+		if bytes.HasPrefix(b, seq) {
+			buf.Next(len(seq))
+			d.escaped = false
+			d.post(NewEventFocus(f.focused))
+			return true, true
+		}
+		if bytes.HasPrefix(seq, b) {
+			partial = true
+		}
+	}
+	return partial, false
+}
+
+// scanPaste collects pasted text, up to the marker that ends it, and then
+// posts it.  It returns false if the end has not arrived yet; anything
+// that could be the start of the marker is left in the buffer, even when
+// flushing, as the rest of the marker is sure to follow.
+func (d *InputDecoder) scanPaste(buf *bytes.Buffer) bool {
+	b := buf.Bytes()
+	end := []byte(d.ti.PasteEnd)
+	if i := bytes.Index(b, end); i >= 0 {
+		d.paste.Write(b[:i])
+		buf.Next(i + len(end))
+		d.pasting = false
+		d.postPaste(true)
+		return true
+	}
+	n := len(b)
+	for l := len(end) - 1; l > 0; l-- {
+		if bytes.HasSuffix(b, end[:l]) {
+			n -= l
+			break
+		}
+	}
+	d.paste.Write(b[:n])
+	buf.Next(n)
+	if d.paste.Len() >= maxPaste {
+		d.postPaste(false)
+	}
+	return false
+}
+
+// postPaste posts the pasted text collected so far.  Unless the paste is
+// over, a character that has not all arrived yet is kept for the next
+// event.
+func (d *InputDecoder) postPaste(over bool) {
+	src := d.paste.Bytes()
+	text := make([]byte, 0, len(src))
+	dst := make([]byte, 4096)
+	d.decoder.Reset()
+	for {
+		n, used, e := d.decoder.Transform(dst, src, over)
+		text = append(text, dst[:n]...)
+		src = src[used:]
+		if e != transform.ErrShortDst {
+			break
+		}
+	}
+	rest := append([]byte(nil), src...)
+	d.paste.Reset()
+	if !over {
+		d.paste.Write(rest)
+	}
+	d.post(NewEventPaste(string(text)))
+}
+
+// parseFunctionKey looks for a key sequence at the start of the buffer,
+// preferring the longest one.  Unless expire is true, it waits for more
+// input when the buffer could be the start of a longer sequence.
+func (d *InputDecoder) parseFunctionKey(buf *bytes.Buffer, expire bool) (bool, bool) {
+	b := buf.Bytes()
+	k, l, more := d.keys.match(b)
+	if k == nil || (more && !expire) {
+		return more, false
+	}
+	var r rune
+	if l == 1 {
+		r = rune(b[0])
+	}
+	mod := k.mod
+	if d.escaped {
+		mod |= ModAlt
+		d.escaped = false
+	}
+	ev := NewEventKey(k.key, r, mod)
+	d.post(ev)
+	buf.Next(l)
+	return true, true
+}
+
+func (d *InputDecoder) parseRune(buf *bytes.Buffer) (bool, bool) {
+	b := buf.Bytes()
+	if b[0] >= ' ' && b[0] <= 0x7F {
+		// printable ASCII easy to deal with -- no encodings
+		mod := ModNone
+		if d.escaped {
+			mod = ModAlt
+			d.escaped = false
+		}
+		ev := NewEventKey(KeyRune, rune(b[0]), mod)
+		d.post(ev)
+		buf.ReadByte()
+		return true, true
+	}
+
+	if b[0] < 0x80 {
+		// Low numbered values are control keys, not runes.
+		return false, false
+	}
+
+	utfb := make([]byte, 12)
+	for l := 1; l <= len(b); l++ {
+		d.decoder.Reset()
+		nout, nin, e := d.decoder.Transform(utfb, b[:l], true)
+		if e == transform.ErrShortSrc {
+			continue
+		}
+		if nout != 0 {
+			r, _ := utf8.DecodeRune(utfb[:nout])
+			if r != utf8.RuneError {
+				mod := ModNone
+				if d.escaped {
+					mod = ModAlt
+					d.escaped = false
+				}
+				ev := NewEventKey(KeyRune, r, mod)
+				d.post(ev)
+			} else {
+				d.failed = true
+			}
+			for nin > 0 {
+				buf.ReadByte()
+				nin--
+			}
+			return true, true
+		}
+	}
+	// Looks like potential escape
+	return true, false
+}
+
+// scan parses as much of the buffer as it can, posting the events found,
+// and leaving any incomplete sequence at the end of the buffer to be
+// parsed once more bytes arrive.  If expire is true, no more bytes are
+// expected, and incomplete sequences are delivered as they are.
+func (d *InputDecoder) scan(buf *bytes.Buffer, expire bool) {
+	for {
+		b := buf.Bytes()
+		if len(b) == 0 {
+			buf.Reset()
+			return
+		}
+
+		if d.pasting {
+			if !d.scanPaste(buf) {
+				break
+			}
+			continue
+		}
+
+		if d.clipping {
+			if !d.scanClipboard(buf) {
+				break
+			}
+			continue
+		}
+
+		partials := 0
+
+		if part, comp := d.parseRune(buf); comp {
+			continue
+		} else if part {
+			partials++
+		}
+
+		if d.ti.PasteStart != "" && d.ti.PasteEnd != "" {
+			if part, comp := d.parsePaste(buf); comp {
+				continue
+			} else if part {
+				partials++
+			}
+		}
+
+		if d.ti.SetClipboard != "" {
+			if part, comp := d.parseClipboard(buf); comp {
+				continue
+			} else if part {
+				partials++
+			}
+		}
+
+		if part, comp := d.parseFocus(buf); comp {
+			continue
+		} else if part {
+			partials++
+		}
+
+		if part, comp := d.parseFunctionKey(buf, expire); comp {
+			continue
+		} else if part {
+			partials++
+		}
+
+		// Only parse mouse records if this term claims to have
+		// mouse support
+
+		if d.ti.Mouse != "" {
+			if part, comp := d.parseXtermMouse(buf); comp {
+				continue
+			} else if part {
+				partials++
+			}
+
+			if part, comp := d.parseSgrMouse(buf); comp {
+				continue
+			} else if part {
+				partials++
+			}
+		}
+
+		if partials == 0 || expire {
+			if b[0] == '\x1b' {
+				if len(b) == 1 {
+					ev := NewEventKey(KeyEsc, 0, ModNone)
+					d.post(ev)
+					d.escaped = false
+				} else {
+					d.escaped = true
+				}
+				buf.ReadByte()
+				continue
+			}
+			// Nothing was going to match, or we timed out
+			// waiting for more data -- just deliver the characters
+			// to the app & let them sort it out.  Possibly we
+			// should only do this for control characters like ESC.
+			by, _ := buf.ReadByte()
+			d.failed = true
+			mod := ModNone
+			if d.escaped {
+				d.escaped = false
+				mod = ModAlt
+			}
+			ev := NewEventKey(KeyRune, rune(by), mod)
+			d.post(ev)
+			continue
+		}
+
+		// well we have some partial data, wait until we get
+		// some more
+		break
+	}
+}
@@ -0,0 +1,266 @@
+// Copyright 2017 The TCell Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use file except in compliance with the License.
+// You may obtain a copy of the license at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tcell
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// describeEvent describes the events that input can produce as text, for
+// comparison.
+func describeEvent(ev Event) string {
+	switch ev := ev.(type) {
+	case *EventKey:
+		return ev.Name()
+	case *EventMouse:
+		x, y := ev.Position()
+		return fmt.Sprintf("Mouse(%d,%d,%d,%d)",
+			x, y, ev.Buttons(), ev.Modifiers())
+	case *EventPaste:
+		return fmt.Sprintf("Paste(%q)", ev.Text())
+	case *EventFocus:
+		return fmt.Sprintf("Focus(%v)", ev.Focused())
+	case *EventClipboard:
+		return fmt.Sprintf("Clipboard(%q)", ev.Data())
+	}
+	return fmt.Sprintf("%T", ev)
+}
+
+func TestInputDecoder(t *testing.T) {
+
+	Convey("Decoding xterm input", t, func() {
+		ti, e := LookupTerminfo("xterm")
+		So(e, ShouldBeNil)
+		var evs []string
+		d := NewInputDecoder(ti, nil, func(ev Event) {
+			evs = append(evs, describeEvent(ev))
+		})
+
+		Convey("Complete input is decoded at once", func() {
+			n, e := d.Write([]byte("a\x1b[Aé\x1b[1;5D"))
+			So(e, ShouldBeNil)
+			So(n, ShouldEqual, 12)
+			So(evs, ShouldResemble,
+				[]string{"Rune[a]", "Up", "Rune[é]", "Ctrl+Left"})
+			So(d.Pending(), ShouldBeFalse)
+		})
+
+		Convey("Sequences may be split across writes", func() {
+			d.Write([]byte("\x1b["))
+			So(evs, ShouldBeEmpty)
+			So(d.Pending(), ShouldBeTrue)
+			d.Write([]byte("B\xc3"))
+			So(evs, ShouldResemble, []string{"Down"})
+			d.Write([]byte("\xa9"))
+			So(evs, ShouldResemble, []string{"Down", "Rune[é]"})
+		})
+
+		Convey("Flush delivers a lone escape", func() {
+			d.Write([]byte("\x1b"))
+			So(evs, ShouldBeEmpty)
+			d.Flush()
+			So(evs, ShouldResemble, []string{"Esc"})
+			So(d.Pending(), ShouldBeFalse)
+		})
+
+		Convey("Reset discards incomplete input", func() {
+			d.Write([]byte("\x1b["))
+			d.Reset()
+			So(d.Pending(), ShouldBeFalse)
+			d.Flush()
+			So(evs, ShouldBeEmpty)
+		})
+
+		Convey("Mouse positions are clipped to the screen", func() {
+			d.Write([]byte("\x1b[<0;100;3M"))
+			d.SetSize(20, 10)
+			d.Write([]byte("\x1b[<0;100;30M"))
+			So(evs, ShouldResemble,
+				[]string{"Mouse(99,2,1,0)", "Mouse(19,9,1,0)"})
+		})
+
+		Convey("Pasted text is delivered at once", func() {
+			d.Write([]byte("a\x1b[200~b\x1b[A\rc\x1b[201~d"))
+			So(evs, ShouldResemble,
+				[]string{"Rune[a]", "Paste(\"b\\x1b[A\\rc\")", "Rune[d]"})
+		})
+
+		Convey("Paste markers may be split across writes", func() {
+			d.Write([]byte("\x1b[20"))
+			So(d.Pending(), ShouldBeTrue)
+			d.Write([]byte("0~ab\x1b[20"))
+			So(evs, ShouldBeEmpty)
+			d.Write([]byte("1~"))
+			So(evs, ShouldResemble, []string{"Paste(\"ab\")"})
+			So(d.Pending(), ShouldBeFalse)
+		})
+
+		Convey("Flush does not end a paste", func() {
+			d.Write([]byte("\x1b[200~a\x1b"))
+			d.Flush()
+			So(evs, ShouldBeEmpty)
+			d.Write([]byte("b\x1b[201~"))
+			So(evs, ShouldResemble, []string{"Paste(\"a\\x1bb\")"})
+		})
+
+		Convey("Flush keeps a split end marker", func() {
+			d.Write([]byte("\x1b[200~hello\x1b[20"))
+			d.Flush()
+			So(evs, ShouldBeEmpty)
+			// Flushing again would not help, so there is no
+			// need to wait for it.
+			So(d.Pending(), ShouldBeFalse)
+			d.Write([]byte("1~x"))
+			d.Flush()
+			So(evs, ShouldResemble, []string{"Paste(\"hello\")", "Rune[x]"})
+			So(d.Pending(), ShouldBeFalse)
+		})
+
+		Convey("Long pastes arrive in parts", func() {
+			long := strings.Repeat("a", maxPaste-1)
+			d.Write([]byte("\x1b[200~" + long + "\xc3"))
+			So(evs, ShouldResemble, []string{fmt.Sprintf("Paste(%q)", long)})
+			So(d.paste.Len(), ShouldEqual, 1)
+			d.Write([]byte("\xa9b\x1b[201~"))
+			So(evs[1:], ShouldResemble, []string{"Paste(\"éb\")"})
+		})
+
+		Convey("Reset discards an unfinished paste", func() {
+			d.Write([]byte("\x1b[200~ab"))
+			d.Reset()
+			So(d.Pending(), ShouldBeFalse)
+			d.Write([]byte("c\x1b[201~"))
+			So(evs, ShouldResemble,
+				[]string{"Rune[c]", "Alt+Rune[[]", "Rune[2]", "Rune[0]", "Rune[1]", "Rune[~]"})
+		})
+
+		Convey("Focus reports are decoded", func() {
+			d.Write([]byte("\x1b[Ia\x1b["))
+			d.Write([]byte("O"))
+			So(evs, ShouldResemble,
+				[]string{"Focus(true)", "Rune[a]", "Focus(false)"})
+			So(d.Pending(), ShouldBeFalse)
+		})
+
+		Convey("The clipboard's contents are decoded", func() {
+			d.Write([]byte("\x1b]52;c;aGVs"))
+			d.Flush()
+			So(evs, ShouldBeEmpty)
+			d.Write([]byte("bG8=\x07a\x1b]52;p;\x1b\\"))
+			d.Write([]byte("\x1b]52;c;!!\x07b"))
+			So(evs, ShouldResemble, []string{"Clipboard(\"hello\")",
+				"Rune[a]", "Clipboard(\"\")", "Rune[b]"})
+		})
+
+		Convey("Large clipboard replies are dropped", func() {
+			d.Write([]byte("\x1b]52;c;"))
+			d.Write([]byte(strings.Repeat("A", maxClipboard)))
+			d.Write([]byte("AAAA\x1b"))
+			So(d.buf.Len(), ShouldEqual, 1)
+			So(d.Pending(), ShouldBeFalse)
+			d.Write([]byte("\\x\x1b]52;c;aGk=\x07"))
+			So(evs, ShouldResemble, []string{"Rune[x]", "Clipboard(\"hi\")"})
+		})
+
+		Convey("Keys are known", func() {
+			So(d.HasKey(KeyRune), ShouldBeTrue)
+			So(d.HasKey(KeyF12), ShouldBeTrue)
+			So(d.HasKey(KeyCtrlA), ShouldBeTrue)
+			So(d.HasKey(KeyF64), ShouldBeFalse)
+		})
+	})
+
+	Convey("Bytes invalid in the encoding are dropped", t, func() {
+		ti, e := LookupTerminfo("xterm")
+		So(e, ShouldBeNil)
+		var evs []string
+		d := NewInputDecoder(ti, GetEncoding("US-ASCII"), func(ev Event) {
+			evs = append(evs, describeEvent(ev))
+		})
+		d.Write([]byte("a\xe9b"))
+		d.Flush()
+		So(evs, ShouldResemble, []string{"Rune[a]", "Rune[b]"})
+	})
+
+	Convey("The longest key sequence wins", t, func() {
+		ti := &Terminfo{Name: "overlap", KeyF1: "\x1b[1", KeyF2: "\x1b[12"}
+		var evs []string
+		d := NewInputDecoder(ti, nil, func(ev Event) {
+			evs = append(evs, describeEvent(ev))
+		})
+		d.Write([]byte("\x1b[12\x1b[13\x1b[1"))
+		So(evs, ShouldResemble, []string{"F2", "F1", "Rune[3]"})
+		So(d.Pending(), ShouldBeTrue)
+		d.Flush()
+		So(evs, ShouldResemble, []string{"F2", "F1", "Rune[3]", "F1"})
+	})
+
+	Convey("Key tables are shared by database entries", t, func() {
+		ti, e := LookupTerminfo("xterm")
+		So(e, ShouldBeNil)
+		So(keyTableFor(ti), ShouldEqual, keyTableFor(ti))
+		nti := *ti
+		So(keyTableFor(&nti), ShouldNotEqual, keyTableFor(ti))
+	})
+}
+
+// benchmarkInput measures decoding the given input for xterm, written
+// in chunks the size that the screens read.
+func benchmarkInput(b *testing.B, input []byte) {
+	ti, e := LookupTerminfo("xterm")
+	if e != nil {
+		b.Fatal(e)
+	}
+	d := NewInputDecoder(ti, nil, func(Event) {})
+	d.SetSize(200, 100)
+	b.SetBytes(int64(len(input)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for in := input; len(in) > 0; {
+			n := 128
+			if n > len(in) {
+				n = len(in)
+			}
+			d.Write(in[:n])
+			in = in[n:]
+		}
+		d.Flush()
+	}
+}
+
+func BenchmarkInputPaste(b *testing.B) {
+	input := []byte(strings.Repeat("The quick brown fox jumps "+
+		"over the lazy dog, käse ½ 世界.\r", 400))
+	benchmarkInput(b, input)
+}
+
+func BenchmarkInputKeys(b *testing.B) {
+	input := []byte(strings.Repeat("\x1b[A\x1b[B\x1bOP\x1b[15~"+
+		"\x1b[1;5C\x1b[3~\x1bx\x7f", 1000))
+	benchmarkInput(b, input)
+}
+
+func BenchmarkInputMouseMotion(b *testing.B) {
+	buf := &bytes.Buffer{}
+	for i := 0; i < 2000; i++ {
+		fmt.Fprintf(buf, "\x1b[<35;%d;%dM", i%200+1, i%100+1)
+	}
+	benchmarkInput(b, buf.Bytes())
+}
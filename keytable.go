@@ -0,0 +1,315 @@
+// Copyright 2017 The TCell Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use file except in compliance with the License.
+// You may obtain a copy of the license at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tcell
+
+import (
+	"sync"
+)
+
+// tKeyCode represents a combination of a key code and modifiers.
+type tKeyCode struct {
+	key Key
+	mod ModMask
+}
+
+// keyNode is a node of a keyTable's trie.  The path from the root to a
+// node spells out a sequence of input bytes, and code is the key that
+// the sequence stands for, if it is a whole key.
+type keyNode struct {
+	next map[byte]*keyNode
+	code *tKeyCode
+}
+
+// keyTable holds the keys that a terminal can send, in a trie of the
+// byte sequences for them, so that input can be matched a byte at a time
+// instead of comparing it against every sequence.
+type keyTable struct {
+	ti    *Terminfo
+	root  *keyNode
+	exist map[Key]bool
+}
+
+var (
+	keyTableLock sync.Mutex
+	keyTables    = make(map[*Terminfo]*keyTable)
+)
+
+// keyTableFor returns the key table for the given terminal.  The tables
+// for entries in the terminfo database are built just once; others may
+// be modified copies that come and go, so they get a table of their own
+// each time.
+func keyTableFor(ti *Terminfo) *keyTable {
+	dblock.Lock()
+	shared := terminfos[ti.Name] == ti
+	dblock.Unlock()
+	if !shared {
+		return newKeyTable(ti)
+	}
+
+	keyTableLock.Lock()
+	defer keyTableLock.Unlock()
+	kt := keyTables[ti]
+	if kt == nil {
+		kt = newKeyTable(ti)
+		keyTables[ti] = kt
+	}
+	return kt
+}
+
+func newKeyTable(ti *Terminfo) *keyTable {
+	kt := &keyTable{
+		ti:    ti,
+		root:  &keyNode{next: make(map[byte]*keyNode)},
+		exist: make(map[Key]bool),
+	}
+	kt.prepareKeys()
+	return kt
+}
+
+// match finds the longest key sequence at the start of b.  It returns the
+// key, if any, and its length.  It also reports whether b ends part way
+// into a longer sequence, in which case more input may make for a longer
+// match.  A lone escape is never matched, as it usually starts a longer
+// sequence.
+func (kt *keyTable) match(b []byte) (*tKeyCode, int, bool) {
+	var code *tKeyCode
+	l := 0
+	n := kt.root
+	for i := 0; i < len(b); i++ {
+		if n = n.next[b[i]]; n == nil {
+			return code, l, false
+		}
+		if n.code != nil && (i > 0 || b[0] != '\x1b') {
+			code, l = n.code, i+1
+		}
+	}
+	return code, l, len(n.next) != 0
+}
+
+func (kt *keyTable) prepareKeyMod(key Key, mod ModMask, val string) {
+	if val == "" {
+		return
+	}
+	n := kt.root
+	for i := 0; i < len(val); i++ {
+		next := n.next[val[i]]
+		if next == nil {
+			next = &keyNode{}
+			if n.next == nil {
+				n.next = make(map[byte]*keyNode)
+			}
+			n.next[val[i]] = next
+		}
+		n = next
+	}
+	// Do not overrride codes that already exist
+	if n.code == nil {
+		kt.exist[key] = true
+		n.code = &tKeyCode{key: key, mod: mod}
+	}
+}
+
+func (kt *keyTable) prepareKey(key Key, val string) {
+	kt.prepareKeyMod(key, ModNone, val)
+}
+
+func (kt *keyTable) prepareKeys() {
+	ti := kt.ti
+	kt.prepareKey(KeyBackspace, ti.KeyBackspace)
+	kt.prepareKey(KeyF1, ti.KeyF1)
+	kt.prepareKey(KeyF2, ti.KeyF2)
+	kt.prepareKey(KeyF3, ti.KeyF3)
+	kt.prepareKey(KeyF4, ti.KeyF4)
+	kt.prepareKey(KeyF5, ti.KeyF5)
+	kt.prepareKey(KeyF6, ti.KeyF6)
+	kt.prepareKey(KeyF7, ti.KeyF7)
+	kt.prepareKey(KeyF8, ti.KeyF8)
+	kt.prepareKey(KeyF9, ti.KeyF9)
+	kt.prepareKey(KeyF10, ti.KeyF10)
+	kt.prepareKey(KeyF11, ti.KeyF11)
+	kt.prepareKey(KeyF12, ti.KeyF12)
+	kt.prepareKey(KeyF13, ti.KeyF13)
+	kt.prepareKey(KeyF14, ti.KeyF14)
+	kt.prepareKey(KeyF15, ti.KeyF15)
+	kt.prepareKey(KeyF16, ti.KeyF16)
+	kt.prepareKey(KeyF17, ti.KeyF17)
+	kt.prepareKey(KeyF18, ti.KeyF18)
+	kt.prepareKey(KeyF19, ti.KeyF19)
+	kt.prepareKey(KeyF20, ti.KeyF20)
+	kt.prepareKey(KeyF21, ti.KeyF21)
+	kt.prepareKey(KeyF22, ti.KeyF22)
+	kt.prepareKey(KeyF23, ti.KeyF23)
+	kt.prepareKey(KeyF24, ti.KeyF24)
+	kt.prepareKey(KeyF25, ti.KeyF25)
+	kt.prepareKey(KeyF26, ti.KeyF26)
+	kt.prepareKey(KeyF27, ti.KeyF27)
+	kt.prepareKey(KeyF28, ti.KeyF28)
+	kt.prepareKey(KeyF29, ti.KeyF29)
+	kt.prepareKey(KeyF30, ti.KeyF30)
+	kt.prepareKey(KeyF31, ti.KeyF31)
+	kt.prepareKey(KeyF32, ti.KeyF32)
+	kt.prepareKey(KeyF33, ti.KeyF33)
+	kt.prepareKey(KeyF34, ti.KeyF34)
+	kt.prepareKey(KeyF35, ti.KeyF35)
+	kt.prepareKey(KeyF36, ti.KeyF36)
+	kt.prepareKey(KeyF37, ti.KeyF37)
+	kt.prepareKey(KeyF38, ti.KeyF38)
+	kt.prepareKey(KeyF39, ti.KeyF39)
+	kt.prepareKey(KeyF40, ti.KeyF40)
+	kt.prepareKey(KeyF41, ti.KeyF41)
+	kt.prepareKey(KeyF42, ti.KeyF42)
+	kt.prepareKey(KeyF43, ti.KeyF43)
+	kt.prepareKey(KeyF44, ti.KeyF44)
+	kt.prepareKey(KeyF45, ti.KeyF45)
+	kt.prepareKey(KeyF46, ti.KeyF46)
+	kt.prepareKey(KeyF47, ti.KeyF47)
+	kt.prepareKey(KeyF48, ti.KeyF48)
+	kt.prepareKey(KeyF49, ti.KeyF49)
+	kt.prepareKey(KeyF50, ti.KeyF50)
+	kt.prepareKey(KeyF51, ti.KeyF51)
+	kt.prepareKey(KeyF52, ti.KeyF52)
+	kt.prepareKey(KeyF53, ti.KeyF53)
+	kt.prepareKey(KeyF54, ti.KeyF54)
+	kt.prepareKey(KeyF55, ti.KeyF55)
+	kt.prepareKey(KeyF56, ti.KeyF56)
+	kt.prepareKey(KeyF57, ti.KeyF57)
+	kt.prepareKey(KeyF58, ti.KeyF58)
+	kt.prepareKey(KeyF59, ti.KeyF59)
+	kt.prepareKey(KeyF60, ti.KeyF60)
+	kt.prepareKey(KeyF61, ti.KeyF61)
+	kt.prepareKey(KeyF62, ti.KeyF62)
+	kt.prepareKey(KeyF63, ti.KeyF63)
+	kt.prepareKey(KeyF64, ti.KeyF64)
+	kt.prepareKey(KeyInsert, ti.KeyInsert)
+	kt.prepareKey(KeyDelete, ti.KeyDelete)
+	kt.prepareKey(KeyHome, ti.KeyHome)
+	kt.prepareKey(KeyEnd, ti.KeyEnd)
+	kt.prepareKey(KeyUp, ti.KeyUp)
+	kt.prepareKey(KeyDown, ti.KeyDown)
+	kt.prepareKey(KeyLeft, ti.KeyLeft)
+	kt.prepareKey(KeyRight, ti.KeyRight)
+	kt.prepareKey(KeyPgUp, ti.KeyPgUp)
+	kt.prepareKey(KeyPgDn, ti.KeyPgDn)
+	kt.prepareKey(KeyHelp, ti.KeyHelp)
+	kt.prepareKey(KeyPrint, ti.KeyPrint)
+	kt.prepareKey(KeyCancel, ti.KeyCancel)
+	kt.prepareKey(KeyExit, ti.KeyExit)
+	kt.prepareKey(KeyBacktab, ti.KeyBacktab)
+
+	kt.prepareKeyMod(KeyRight, ModShift, ti.KeyShfRight)
+	kt.prepareKeyMod(KeyLeft, ModShift, ti.KeyShfLeft)
+	kt.prepareKeyMod(KeyUp, ModShift, ti.KeyShfUp)
+	kt.prepareKeyMod(KeyDown, ModShift, ti.KeyShfDown)
+	kt.prepareKeyMod(KeyHome, ModShift, ti.KeyShfHome)
+	kt.prepareKeyMod(KeyEnd, ModShift, ti.KeyShfEnd)
+
+	kt.prepareKeyMod(KeyRight, ModCtrl, ti.KeyCtrlRight)
+	kt.prepareKeyMod(KeyLeft, ModCtrl, ti.KeyCtrlLeft)
+	kt.prepareKeyMod(KeyUp, ModCtrl, ti.KeyCtrlUp)
+	kt.prepareKeyMod(KeyDown, ModCtrl, ti.KeyCtrlDown)
+	kt.prepareKeyMod(KeyHome, ModCtrl, ti.KeyCtrlHome)
+	kt.prepareKeyMod(KeyEnd, ModCtrl, ti.KeyCtrlEnd)
+
+	kt.prepareKeyMod(KeyRight, ModAlt, ti.KeyAltRight)
+	kt.prepareKeyMod(KeyLeft, ModAlt, ti.KeyAltLeft)
+	kt.prepareKeyMod(KeyUp, ModAlt, ti.KeyAltUp)
+	kt.prepareKeyMod(KeyDown, ModAlt, ti.KeyAltDown)
+	kt.prepareKeyMod(KeyHome, ModAlt, ti.KeyAltHome)
+	kt.prepareKeyMod(KeyEnd, ModAlt, ti.KeyAltEnd)
+
+	kt.prepareKeyMod(KeyRight, ModAlt, ti.KeyMetaRight)
+	kt.prepareKeyMod(KeyLeft, ModAlt, ti.KeyMetaLeft)
+	kt.prepareKeyMod(KeyUp, ModAlt, ti.KeyMetaUp)
+	kt.prepareKeyMod(KeyDown, ModAlt, ti.KeyMetaDown)
+	kt.prepareKeyMod(KeyHome, ModAlt, ti.KeyMetaHome)
+	kt.prepareKeyMod(KeyEnd, ModAlt, ti.KeyMetaEnd)
+
+	kt.prepareKeyMod(KeyRight, ModAlt|ModShift, ti.KeyAltShfRight)
+	kt.prepareKeyMod(KeyLeft, ModAlt|ModShift, ti.KeyAltShfLeft)
+	kt.prepareKeyMod(KeyUp, ModAlt|ModShift, ti.KeyAltShfUp)
+	kt.prepareKeyMod(KeyDown, ModAlt|ModShift, ti.KeyAltShfDown)
+	kt.prepareKeyMod(KeyHome, ModAlt|ModShift, ti.KeyAltShfHome)
+	kt.prepareKeyMod(KeyEnd, ModAlt|ModShift, ti.KeyAltShfEnd)
+
+	kt.prepareKeyMod(KeyRight, ModAlt|ModShift, ti.KeyMetaShfRight)
+	kt.prepareKeyMod(KeyLeft, ModAlt|ModShift, ti.KeyMetaShfLeft)
+	kt.prepareKeyMod(KeyUp, ModAlt|ModShift, ti.KeyMetaShfUp)
+	kt.prepareKeyMod(KeyDown, ModAlt|ModShift, ti.KeyMetaShfDown)
+	kt.prepareKeyMod(KeyHome, ModAlt|ModShift, ti.KeyMetaShfHome)
+	kt.prepareKeyMod(KeyEnd, ModAlt|ModShift, ti.KeyMetaShfEnd)
+
+	kt.prepareKeyMod(KeyRight, ModCtrl|ModShift, ti.KeyCtrlShfRight)
+	kt.prepareKeyMod(KeyLeft, ModCtrl|ModShift, ti.KeyCtrlShfLeft)
+	kt.prepareKeyMod(KeyUp, ModCtrl|ModShift, ti.KeyCtrlShfUp)
+	kt.prepareKeyMod(KeyDown, ModCtrl|ModShift, ti.KeyCtrlShfDown)
+	kt.prepareKeyMod(KeyHome, ModCtrl|ModShift, ti.KeyCtrlShfHome)
+	kt.prepareKeyMod(KeyEnd, ModCtrl|ModShift, ti.KeyCtrlShfEnd)
+
+	// Sadly, xterm handling of keycodes is somewhat erratic.  In
+	// particular, different codes are sent depending on application
+	// mode is in use or not, and the entries for many of these are
+	// simply absent from terminfo on many systems.  So we insert
+	// a number of escape sequences if they are not already used, in
+	// order to have the widest correct usage.  Note that prepareKey
+	// will not inject codes if the escape sequence is already known.
+	// We also only do this for terminals that have the application
+	// mode present.
+
+	// Cursor mode
+	if ti.EnterKeypad != "" {
+		kt.prepareKey(KeyUp, "\x1b[A")
+		kt.prepareKey(KeyDown, "\x1b[B")
+		kt.prepareKey(KeyRight, "\x1b[C")
+		kt.prepareKey(KeyLeft, "\x1b[D")
+		kt.prepareKey(KeyEnd, "\x1b[F")
+		kt.prepareKey(KeyHome, "\x1b[H")
+		kt.prepareKey(KeyDelete, "\x1b[3~")
+		kt.prepareKey(KeyHome, "\x1b[1~")
+		kt.prepareKey(KeyEnd, "\x1b[4~")
+		kt.prepareKey(KeyPgUp, "\x1b[5~")
+		kt.prepareKey(KeyPgDn, "\x1b[6~")
+
+		// Application mode
+		kt.prepareKey(KeyUp, "\x1bOA")
+		kt.prepareKey(KeyDown, "\x1bOB")
+		kt.prepareKey(KeyRight, "\x1bOC")
+		kt.prepareKey(KeyLeft, "\x1bOD")
+		kt.prepareKey(KeyHome, "\x1bOH")
+	}
+
+	// Add key mappings for control keys.
+	for i := 0; i < ' '; i++ {
+		// Do not insert direct key codes for ambiguous keys.
+		// For example, ESC is used for lots of other keys, so
+		// when parsing this we don't want to fast path handling
+		// of it, but instead wait a bit before parsing it as in
+		// isolation.
+		if kt.root.next[byte(i)] != nil {
+			continue
+		}
+
+		kt.exist[Key(i)] = true
+
+		mod := ModCtrl
+		switch Key(i) {
+		case KeyBS, KeyTAB, KeyESC, KeyCR:
+			// directly typeable- no control sequence
+			mod = ModNone
+		}
+		kt.root.next[byte(i)] = &keyNode{
+			code: &tKeyCode{key: Key(i), mod: mod},
+		}
+	}
+}
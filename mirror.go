@@ -0,0 +1,433 @@
+// Copyright 2017 The TCell Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use file except in compliance with the License.
+// You may obtain a copy of the license at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tcell
+
+import (
+	"sync"
+)
+
+// MirrorScreen is a Screen that shows everything drawn on it to any number
+// of spectators, in addition to the primary screen that the application
+// normally draws on.  This is useful for letting others watch a session,
+// for example when debugging in pairs.
+//
+// Each spectator is a Screen of its own, normally a quasi screen serving a
+// remote client, with its own terminal type, character set and size.  A
+// spectator only redraws the cells that have changed since its last
+// frame, just as the primary does.  When a spectator is smaller than the
+// primary screen, the view is clipped at the right and bottom; when it is
+// larger, the view is centered, and the area around it is filled with the
+// letterbox.
+//
+// Spectators are drawn on in the background, each by a goroutine of its
+// own, so Show does not wait for them.  A spectator that cannot keep up
+// skips frames, being shown the latest one once it is ready again.  The
+// frames are copied for the spectators only while there are any, so
+// mirroring costs next to nothing while nobody is watching.
+type MirrorScreen interface {
+	Screen
+
+	// AddSpectator initializes the screen, and starts showing the
+	// application to it.  If input is true, keys and mouse events from
+	// the spectator are delivered to the application just like those
+	// from the primary screen; otherwise they are discarded.
+	AddSpectator(s Screen, input bool) error
+
+	// RemoveSpectator stops showing the application to the screen, and
+	// finalizes it.  Spectators whose screen is finalized by other means
+	// (for example, because their client went away) are removed
+	// automatically.
+	RemoveSpectator(s Screen)
+
+	// Spectators returns the number of spectators.
+	Spectators() int
+
+	// SetLetterbox sets the content shown around the application on
+	// spectators that are larger than the primary screen.  The default
+	// is blank cells in the default style.
+	SetLetterbox(mainc rune, style Style)
+}
+
+// NewMirrorScreen returns a MirrorScreen that draws on the primary screen.
+// Init and Fini apply to the primary screen (and Fini to all spectators as
+// well), so a primary screen that is already initialized can be mirrored
+// by simply carrying on without calling Init again.  Events come from the
+// primary screen, along with any forwarded from spectators.
+func NewMirrorScreen(primary Screen) MirrorScreen {
+	return &mirror{
+		Screen:  primary,
+		lbmainc: ' ',
+		lbstyle: StyleDefault,
+		cursorx: -1,
+		cursory: -1,
+	}
+}
+
+// mirrorCell is a cell of a frame shown on the primary screen.
+type mirrorCell struct {
+	mainc rune
+	combc []rune
+	style Style
+}
+
+// mirrorFrame is a copy of a frame shown on the primary screen, along
+// with the letterbox to show around it.  A frame is never changed once it
+// has been made, so spectators can draw it without holding the lock.
+type mirrorFrame struct {
+	cells   []mirrorCell
+	w       int
+	h       int
+	cursorx int
+	cursory int
+	lbmainc rune
+	lbstyle Style
+}
+
+// spectator is a screen watching the mirror.  Each spectator is drawn on
+// by a goroutine of its own, so that a slow one holds up neither the
+// application nor the other spectators.  It is woken up for each frame,
+// and draws the latest one when it gets around to it, so any that come
+// and go while it is still drawing are never shown.  The offsets are the
+// position of the primary screen's origin on the spectator's screen.
+type spectator struct {
+	s     Screen
+	input bool
+	ox    int
+	oy    int
+	full  bool // the next frame needs a full redraw
+	wake  chan struct{}
+	quit  chan struct{}
+}
+
+type mirror struct {
+	Screen
+
+	frame   *mirrorFrame
+	stale   bool // the primary has shown a frame that is not yet copied
+	cursorx int
+	cursory int
+	lbmainc rune
+	lbstyle Style
+	specs   []*spectator
+
+	sync.Mutex
+}
+
+func (m *mirror) AddSpectator(s Screen, input bool) error {
+	if e := s.Init(); e != nil {
+		return e
+	}
+	sp := &spectator{
+		s:     s,
+		input: input,
+		wake:  make(chan struct{}, 1),
+		quit:  make(chan struct{}),
+	}
+	m.Lock()
+	m.specs = append(m.specs, sp)
+	if m.frame == nil {
+		m.capture()
+	}
+	m.post(sp, false)
+	m.Unlock()
+
+	go m.draw(sp)
+	go m.watch(sp)
+	return nil
+}
+
+func (m *mirror) RemoveSpectator(s Screen) {
+	if m.drop(s) {
+		s.Fini()
+	}
+}
+
+// drop removes the spectator for the screen, and reports whether it was
+// present.
+func (m *mirror) drop(s Screen) bool {
+	m.Lock()
+	defer m.Unlock()
+	for i, sp := range m.specs {
+		if sp.s == s {
+			m.specs = append(m.specs[:i], m.specs[i+1:]...)
+			close(sp.quit)
+			if len(m.specs) == 0 {
+				// This lets go of the last frame.
+				m.capture()
+			}
+			return true
+		}
+	}
+	return false
+}
+
+func (m *mirror) Spectators() int {
+	m.Lock()
+	defer m.Unlock()
+	return len(m.specs)
+}
+
+func (m *mirror) SetLetterbox(mainc rune, style Style) {
+	m.Lock()
+	m.lbmainc, m.lbstyle = mainc, style
+	if m.frame != nil {
+		f := *m.frame
+		f.lbmainc, f.lbstyle = mainc, style
+		m.frame = &f
+		for _, sp := range m.specs {
+			m.post(sp, false)
+		}
+	}
+	m.Unlock()
+}
+
+func (m *mirror) Fini() {
+	m.Lock()
+	specs := m.specs
+	m.specs = nil
+	for _, sp := range specs {
+		close(sp.quit)
+	}
+	m.capture()
+	m.Unlock()
+
+	for _, sp := range specs {
+		sp.s.Fini()
+	}
+	m.Screen.Fini()
+}
+
+func (m *mirror) ShowCursor(x, y int) {
+	m.Lock()
+	m.changing()
+	m.cursorx, m.cursory = x, y
+	m.Unlock()
+	m.Screen.ShowCursor(x, y)
+}
+
+func (m *mirror) HideCursor() {
+	m.ShowCursor(-1, -1)
+}
+
+// The primary screen's content is changed only through these, so that a
+// frame that has been shown but not yet copied can be copied first.
+
+func (m *mirror) Clear() {
+	m.Lock()
+	m.changing()
+	m.Unlock()
+	m.Screen.Clear()
+}
+
+func (m *mirror) Fill(r rune, style Style) {
+	m.Lock()
+	m.changing()
+	m.Unlock()
+	m.Screen.Fill(r, style)
+}
+
+func (m *mirror) SetCell(x, y int, style Style, ch ...rune) {
+	m.Lock()
+	m.changing()
+	m.Unlock()
+	m.Screen.SetCell(x, y, style, ch...)
+}
+
+func (m *mirror) SetContent(x, y int, mainc rune, combc []rune, style Style) {
+	m.Lock()
+	m.changing()
+	m.Unlock()
+	m.Screen.SetContent(x, y, mainc, combc, style)
+}
+
+func (m *mirror) Resize(x, y, w, h int) {
+	m.Lock()
+	m.changing()
+	m.Unlock()
+	m.Screen.Resize(x, y, w, h)
+}
+
+func (m *mirror) Show() {
+	m.Screen.Show()
+	m.Lock()
+	m.shown(false)
+	m.Unlock()
+}
+
+func (m *mirror) Sync() {
+	m.Screen.Sync()
+	m.Lock()
+	m.shown(true)
+	m.Unlock()
+}
+
+// shown wakes the spectators up for a frame that the primary screen has
+// just shown.  The frame is not copied yet: that is left to the first
+// spectator that is ready to draw it, or to the application changing the
+// screen before then, so a frame is copied at most once, and not at all
+// without spectators.  The caller must hold the lock.
+func (m *mirror) shown(full bool) {
+	if len(m.specs) == 0 {
+		return
+	}
+	m.stale = true
+	for _, sp := range m.specs {
+		m.post(sp, full)
+	}
+}
+
+// changing copies a frame that has been shown, but not yet copied, before
+// the application starts on the next one.  The caller must hold the lock.
+func (m *mirror) changing() {
+	if m.stale {
+		m.capture()
+	}
+}
+
+// capture takes a copy of the primary screen's content, which is what
+// the spectators are shown until the next frame.  Spectators that resize
+// in between are redrawn from this copy, so they never see a frame that
+// the application is still in the middle of drawing.  Without spectators,
+// there is nobody to take a copy for.  The caller must hold the lock.
+func (m *mirror) capture() {
+	m.stale = false
+	if len(m.specs) == 0 {
+		m.frame = nil
+		return
+	}
+	w, h := m.Screen.Size()
+	f := &mirrorFrame{
+		cells:   make([]mirrorCell, w*h),
+		w:       w,
+		h:       h,
+		cursorx: m.cursorx,
+		cursory: m.cursory,
+		lbmainc: m.lbmainc,
+		lbstyle: m.lbstyle,
+	}
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			mainc, combc, style, _ := m.Screen.GetContent(x, y)
+			f.cells[y*w+x] = mirrorCell{mainc, combc, style}
+		}
+	}
+	m.frame = f
+}
+
+// post wakes a spectator up to draw the latest frame.  The caller must
+// hold the lock.
+func (m *mirror) post(sp *spectator, full bool) {
+	sp.full = sp.full || full
+	select {
+	case sp.wake <- struct{}{}:
+	default:
+	}
+}
+
+// draw shows the frames posted to a spectator, until it is removed.
+func (m *mirror) draw(sp *spectator) {
+	for {
+		select {
+		case <-sp.wake:
+		case <-sp.quit:
+			return
+		}
+		m.Lock()
+		if m.stale {
+			m.capture()
+		}
+		f, full := m.frame, sp.full
+		sp.full = false
+		m.Unlock()
+		if f != nil {
+			m.render(sp, f, full)
+		}
+	}
+}
+
+// render copies a frame to a spectator, and shows it there, with a full
+// redraw if requested.  The caller must not hold the lock.
+func (m *mirror) render(sp *spectator, f *mirrorFrame, full bool) {
+	sw, sh := sp.s.Size()
+	ox, oy := 0, 0
+	if sw > f.w {
+		ox = (sw - f.w) / 2
+	}
+	if sh > f.h {
+		oy = (sh - f.h) / 2
+	}
+	m.Lock()
+	sp.ox, sp.oy = ox, oy
+	m.Unlock()
+	for y := 0; y < sh; y++ {
+		for x := 0; x < sw; x++ {
+			px, py := x-ox, y-oy
+			if px < 0 || py < 0 || px >= f.w || py >= f.h {
+				sp.s.SetContent(x, y, f.lbmainc, nil, f.lbstyle)
+				continue
+			}
+			c := &f.cells[py*f.w+px]
+			sp.s.SetContent(x, y, c.mainc, c.combc, c.style)
+		}
+	}
+	if f.cursorx >= 0 && f.cursory >= 0 &&
+		f.cursorx < f.w && f.cursory < f.h {
+		sp.s.ShowCursor(f.cursorx+ox, f.cursory+oy)
+	} else {
+		sp.s.HideCursor()
+	}
+	if full {
+		sp.s.Sync()
+	} else {
+		sp.s.Show()
+	}
+}
+
+// watch handles the events from a spectator, until its screen is
+// finalized.
+func (m *mirror) watch(sp *spectator) {
+	for {
+		var fwd Event
+		switch ev := sp.s.PollEvent().(type) {
+		case nil:
+			m.drop(sp.s)
+			return
+		case *EventResize:
+			m.Lock()
+			for _, other := range m.specs {
+				// It may have been removed meanwhile.
+				if other == sp {
+					m.post(sp, true)
+				}
+			}
+			m.Unlock()
+		case *EventKey:
+			fwd = ev
+		case *EventMouse:
+			x, y := ev.Position()
+			m.Lock()
+			x, y = x-sp.ox, y-sp.oy
+			inside := m.frame != nil && x >= 0 && y >= 0 &&
+				x < m.frame.w && y < m.frame.h
+			m.Unlock()
+			if inside {
+				fwd = NewEventMouse(x, y, ev.Buttons(), ev.Modifiers())
+			}
+		}
+		if fwd != nil && sp.input {
+			m.Screen.PostEvent(fwd)
+		}
+	}
+}
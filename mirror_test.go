@@ -0,0 +1,305 @@
+// Copyright 2017 The TCell Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use file except in compliance with the License.
+// You may obtain a copy of the license at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tcell
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// spySpectator is a simulation screen that keeps a copy of each frame
+// shown on it, since the mirror draws on spectators from goroutines of
+// their own.
+type spySpectator struct {
+	SimulationScreen
+	cells   []SimCell
+	w       int
+	h       int
+	cursorx int
+	cursory int
+	visible bool
+	syncs   int
+	sync.Mutex
+}
+
+func newSpySpectator() *spySpectator {
+	return &spySpectator{SimulationScreen: NewSimulationScreen("")}
+}
+
+func (s *spySpectator) Show() {
+	s.SimulationScreen.Show()
+	s.snap(false)
+}
+
+func (s *spySpectator) Sync() {
+	s.SimulationScreen.Sync()
+	s.snap(true)
+}
+
+func (s *spySpectator) snap(full bool) {
+	cells, w, h := s.SimulationScreen.GetContents()
+	x, y, visible := s.SimulationScreen.GetCursor()
+	s.Lock()
+	s.cells = append([]SimCell{}, cells...)
+	s.w, s.h = w, h
+	s.cursorx, s.cursory, s.visible = x, y, visible
+	if full {
+		s.syncs++
+	}
+	s.Unlock()
+}
+
+// text returns the text of the last frame shown, from n cells starting
+// at x, y.
+func (s *spySpectator) text(x, y, n int) string {
+	s.Lock()
+	defer s.Unlock()
+	str := ""
+	for i := 0; i < n && y*s.w+x+i < len(s.cells); i++ {
+		str += string(s.cells[y*s.w+x+i].Runes)
+	}
+	return str
+}
+
+// waitFor waits for the condition to hold, giving up after a second.
+func waitFor(cond func() bool) bool {
+	for deadline := time.Now().Add(time.Second); !cond(); {
+		if time.Now().After(deadline) {
+			return false
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	return true
+}
+
+// waitText waits for the spectator to show the text at x, y.
+func (s *spySpectator) waitText(x, y int, want string) bool {
+	return waitFor(func() bool {
+		return s.text(x, y, len([]rune(want))) == want
+	})
+}
+
+// waitSync waits for the spectator to be redrawn in full, at the given
+// size.
+func (s *spySpectator) waitSync(w, h int) bool {
+	return waitFor(func() bool {
+		s.Lock()
+		defer s.Unlock()
+		return s.syncs != 0 && s.w == w && s.h == h
+	})
+}
+
+// slowSpectator is a spectator that cannot show anything until its gate
+// is opened.
+type slowSpectator struct {
+	*spySpectator
+	gate  chan struct{}
+	shows int
+}
+
+func (s *slowSpectator) Show() {
+	<-s.gate
+	s.spySpectator.Show()
+	s.Lock()
+	s.shows++
+	s.Unlock()
+}
+
+// readScreen is a simulation screen that counts the cells read from it.
+type readScreen struct {
+	SimulationScreen
+	reads int32
+}
+
+func (s *readScreen) GetContent(x, y int) (rune, []rune, Style, int) {
+	atomic.AddInt32(&s.reads, 1)
+	return s.SimulationScreen.GetContent(x, y)
+}
+
+func (s *readScreen) count() int {
+	return int(atomic.LoadInt32(&s.reads))
+}
+
+func simText(s SimulationScreen, x, y, n int) string {
+	cells, w, _ := s.GetContents()
+	str := ""
+	for i := 0; i < n; i++ {
+		str += string(cells[y*w+x+i].Runes)
+	}
+	return str
+}
+
+func TestMirrorScreen(t *testing.T) {
+
+	Convey("Mirror screen", t, WithScreen(t, "", func(p SimulationScreen) {
+		m := NewMirrorScreen(p)
+		for i, r := range "hello" {
+			m.SetContent(i, 0, r, nil, StyleDefault)
+		}
+		m.Show()
+
+		sp := newSpySpectator()
+		So(m.AddSpectator(sp, false), ShouldBeNil)
+		So(m.Spectators(), ShouldEqual, 1)
+		Reset(func() {
+			m.RemoveSpectator(sp)
+		})
+
+		Convey("Spectators see the last frame", func() {
+			So(sp.waitText(0, 0, "hello"), ShouldBeTrue)
+			m.SetContent(0, 1, 'X', nil, StyleDefault)
+			So(sp.text(0, 1, 1), ShouldEqual, " ")
+			m.Show()
+			So(sp.waitText(0, 1, "X"), ShouldBeTrue)
+			So(simText(p, 0, 1, 1), ShouldEqual, "X")
+		})
+
+		Convey("Small spectators are clipped", func() {
+			sp.SetSize(3, 2)
+			m.Show()
+			So(sp.waitSync(3, 2), ShouldBeTrue)
+			So(sp.text(0, 0, 3), ShouldEqual, "hel")
+		})
+
+		Convey("Large spectators are letterboxed", func() {
+			m.SetLetterbox('.', StyleDefault)
+			sp.SetSize(84, 27)
+			m.Show()
+			So(sp.waitSync(84, 27), ShouldBeTrue)
+			So(sp.waitText(0, 1, "..hello"), ShouldBeTrue)
+			So(sp.text(0, 0, 3), ShouldEqual, "...")
+			So(sp.text(82, 26, 2), ShouldEqual, "..")
+
+			m.ShowCursor(1, 0)
+			m.Show()
+			So(waitFor(func() bool {
+				sp.Lock()
+				defer sp.Unlock()
+				return sp.visible && sp.cursorx == 3 && sp.cursory == 1
+			}), ShouldBeTrue)
+		})
+
+		Convey("A slow spectator holds up nobody", func() {
+			slow := &slowSpectator{
+				spySpectator: newSpySpectator(),
+				gate:         make(chan struct{}),
+			}
+			So(m.AddSpectator(slow, false), ShouldBeNil)
+			opened := false
+			Reset(func() {
+				if !opened {
+					close(slow.gate)
+				}
+				m.RemoveSpectator(slow)
+			})
+
+			for _, r := range "abc" {
+				m.SetContent(0, 1, r, nil, StyleDefault)
+				m.Show()
+			}
+			So(sp.waitText(0, 1, "c"), ShouldBeTrue)
+			So(slow.text(0, 1, 1), ShouldEqual, "")
+
+			// Only the latest frame is shown once it catches up.
+			close(slow.gate)
+			opened = true
+			So(slow.waitText(0, 1, "c"), ShouldBeTrue)
+			slow.Lock()
+			So(slow.shows, ShouldBeLessThanOrEqualTo, 2)
+			slow.Unlock()
+		})
+
+		Convey("Input is discarded by default", func() {
+			sp.PostEvent(NewEventKey(KeyRune, 'x', ModNone))
+			So(pollEventTimeout(p, 100*time.Millisecond), ShouldBeNil)
+		})
+
+		Convey("Input can be forwarded", func() {
+			fwd := newSpySpectator()
+			So(m.AddSpectator(fwd, true), ShouldBeNil)
+			Reset(func() {
+				m.RemoveSpectator(fwd)
+			})
+			fwd.SetSize(84, 27)
+			m.Show()
+			So(fwd.waitSync(84, 27), ShouldBeTrue)
+
+			fwd.PostEvent(NewEventKey(KeyRune, 'x', ModNone))
+			ev := pollEventTimeout(m, time.Second)
+			So(ev, ShouldHaveSameTypeAs, &EventKey{})
+			So(ev.(*EventKey).Rune(), ShouldEqual, 'x')
+
+			// Mouse positions are relative to the application, and
+			// clicks on the letterbox go nowhere.
+			fwd.PostEvent(NewEventMouse(0, 0, Button1, ModNone))
+			fwd.PostEvent(NewEventMouse(3, 2, Button1, ModNone))
+			ev = pollEventTimeout(m, time.Second)
+			So(ev, ShouldHaveSameTypeAs, &EventMouse{})
+			x, y := ev.(*EventMouse).Position()
+			So(x, ShouldEqual, 1)
+			So(y, ShouldEqual, 1)
+		})
+
+		Convey("Spectators can be removed", func() {
+			m.RemoveSpectator(sp)
+			So(m.Spectators(), ShouldEqual, 0)
+			So(sp.PollEvent(), ShouldBeNil)
+		})
+
+		Convey("Finalized spectators are dropped", func() {
+			sp.Fini()
+			for i := 0; i < 100 && m.Spectators() != 0; i++ {
+				time.Sleep(10 * time.Millisecond)
+			}
+			So(m.Spectators(), ShouldEqual, 0)
+		})
+	}))
+
+	Convey("Frames are only copied for spectators", t, func() {
+		p := &readScreen{SimulationScreen: NewSimulationScreen("")}
+		So(p.Init(), ShouldBeNil)
+		m := NewMirrorScreen(p)
+		Reset(m.Fini)
+		w, h := m.Size()
+
+		m.SetContent(0, 0, 'a', nil, StyleDefault)
+		m.Show()
+		m.Sync()
+		So(p.count(), ShouldEqual, 0)
+
+		slow := &slowSpectator{
+			spySpectator: newSpySpectator(),
+			gate:         make(chan struct{}),
+		}
+		So(m.AddSpectator(slow, false), ShouldBeNil)
+		So(p.count(), ShouldEqual, w*h)
+
+		// While the spectator is busy, a frame is only copied when
+		// the application goes on to change it.
+		m.Show()
+		m.Show()
+		So(p.count(), ShouldEqual, w*h)
+		m.SetContent(0, 0, 'b', nil, StyleDefault)
+		So(p.count(), ShouldEqual, 2*w*h)
+		m.Show()
+		close(slow.gate)
+		So(slow.waitText(0, 0, "b"), ShouldBeTrue)
+		So(p.count(), ShouldEqual, 3*w*h)
+	})
+}
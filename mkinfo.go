@@ -162,6 +162,7 @@ func getinfo(name string) (*tcell.Terminfo, error) {
 	t.Columns = tigetnum("cols")
 	t.Lines = tigetnum("lines")
 	t.Bell = tigetstr("bel")
+	t.Flash = tigetstr("flash")
 	t.Clear = tigetstr("clear")
 	t.EnterCA = tigetstr("smcup")
 	t.ExitCA = tigetstr("rmcup")
@@ -180,6 +181,22 @@ func getinfo(name string) (*tcell.Terminfo, error) {
 	t.SetCursor = tigetstr("cup")
 	t.CursorBack1 = tigetstr("cub1")
 	t.CursorUp1 = tigetstr("cuu1")
+	t.CursorDown1 = tigetstr("cud1")
+	t.CursorForward1 = tigetstr("cuf1")
+	t.CursorBack = tigetstr("cub")
+	t.CursorUp = tigetstr("cuu")
+	t.CursorDown = tigetstr("cud")
+	t.CursorForward = tigetstr("cuf")
+	t.CursorHome = tigetstr("home")
+	t.CarriageReturn = tigetstr("cr")
+	t.ColumnAddress = tigetstr("hpa")
+	t.RowAddress = tigetstr("vpa")
+	t.ClearToEOL = tigetstr("el")
+	t.EraseChars = tigetstr("ech")
+	t.RepeatChar = tigetstr("rep")
+	t.BackColorErase = tigetflag("bce")
+	t.ExitUnderline = tigetstr("rmul")
+	t.ResetFgBg = tigetstr("op")
 	t.KeyF1 = tigetstr("kf1")
 	t.KeyF2 = tigetstr("kf2")
 	t.KeyF3 = tigetstr("kf3")
@@ -354,6 +371,66 @@ func getinfo(name string) (*tcell.Terminfo, error) {
 			"\x1b[?1000%ga%c\x1b[?1002%ga%c\x1b[?1003%ga%c\x1b[?1006%ga%c"
 	}
 
+	// Bracketed paste is another XTerm extension, described by BE, BD,
+	// PS and PE in newer terminfo databases.  Terminals that emulate
+	// XTerm well enough to have kmous mostly support it too, and we
+	// expect any that do not to ignore the mode.
+	t.EnablePaste = tigetstr("BE")
+	t.DisablePaste = tigetstr("BD")
+	t.PasteStart = tigetstr("PS")
+	t.PasteEnd = tigetstr("PE")
+	if t.Mouse != "" && t.EnablePaste == "" {
+		t.EnablePaste = "\x1b[?2004h"
+		t.DisablePaste = "\x1b[?2004l"
+		t.PasteStart = "\x1b[200~"
+		t.PasteEnd = "\x1b[201~"
+	}
+
+	// Focus reporting is yet another, described by fe, fd, kxIN and
+	// kxOUT, and is fabricated on the same grounds.
+	t.EnableFocus = tigetstr("fe")
+	t.DisableFocus = tigetstr("fd")
+	t.FocusIn = tigetstr("kxIN")
+	t.FocusOut = tigetstr("kxOUT")
+	if t.Mouse != "" && t.EnableFocus == "" {
+		t.EnableFocus = "\x1b[?1004h"
+		t.DisableFocus = "\x1b[?1004l"
+		t.FocusIn = "\x1b[I"
+		t.FocusOut = "\x1b[O"
+	}
+
+	// Setting the clipboard is not fabricated though, as terminals that
+	// allow it at all often turn it off by default.
+	t.SetClipboard = tigetstr("Ms")
+
+	// The window title is set with TS, another XTerm extension, or with
+	// tsl on the terminal emulators that only have that.  Real status
+	// lines are left alone, as they can be anywhere, and writing to them
+	// can disturb the screen; those of terminal emulators are operating
+	// system commands.  Entries for XTerm itself get its sequence if they
+	// have neither, and only XTerm is known to have the title stack.
+	xterm := strings.HasPrefix(name, "xterm")
+	t.TitleStart = tigetstr("TS")
+	t.TitleEnd = tigetstr("fsl")
+	if tsl := tigetstr("tsl"); t.TitleStart == "" &&
+		strings.HasPrefix(tsl, "\x1b]") && len(tsl) > 2 {
+		t.TitleStart = tsl
+	}
+	if xterm && t.TitleStart == "" {
+		t.TitleStart = "\x1b]2;"
+		t.TitleEnd = "\x07"
+	}
+	switch {
+	case t.TitleStart == "":
+		t.TitleEnd = ""
+	case t.TitleEnd == "":
+		t.TitleEnd = "\x07"
+	}
+	if xterm && (t.TitleStart == "\x1b]0;" || t.TitleStart == "\x1b]2;") {
+		t.PushTitle = "\x1b[22;0t"
+		t.PopTitle = "\x1b[23;0t"
+	}
+
 	// We only support colors in ANSI 8 or 256 color mode.
 	if t.Colors < 8 || t.SetFg == "" {
 		t.Colors = 0
@@ -409,6 +486,14 @@ func dotGoAddStr(w io.Writer, n string, s string) {
 	fmt.Fprintf(w, "		%-13s %q,\n", n+":", s)
 }
 
+func dotGoAddBool(w io.Writer, n string, b bool) {
+	if !b {
+		// initialized to false, ignore
+		return
+	}
+	fmt.Fprintf(w, "		%-13s true,\n", n+":")
+}
+
 func dotGoAddArr(w io.Writer, n string, a []string) {
 	if len(a) == 0 {
 		return
@@ -449,6 +534,7 @@ func dotGoInfo(w io.Writer, t *tcell.Terminfo) {
 	dotGoAddInt(w, "Lines", t.Lines)
 	dotGoAddInt(w, "Colors", t.Colors)
 	dotGoAddStr(w, "Bell", t.Bell)
+	dotGoAddStr(w, "Flash", t.Flash)
 	dotGoAddStr(w, "Clear", t.Clear)
 	dotGoAddStr(w, "EnterCA", t.EnterCA)
 	dotGoAddStr(w, "ExitCA", t.ExitCA)
@@ -478,6 +564,35 @@ func dotGoInfo(w io.Writer, t *tcell.Terminfo) {
 	dotGoAddStr(w, "SetCursor", t.SetCursor)
 	dotGoAddStr(w, "CursorBack1", t.CursorBack1)
 	dotGoAddStr(w, "CursorUp1", t.CursorUp1)
+	dotGoAddStr(w, "CursorDown1", t.CursorDown1)
+	dotGoAddStr(w, "CursorForward1", t.CursorForward1)
+	dotGoAddStr(w, "CursorBack", t.CursorBack)
+	dotGoAddStr(w, "CursorUp", t.CursorUp)
+	dotGoAddStr(w, "CursorDown", t.CursorDown)
+	dotGoAddStr(w, "CursorForward", t.CursorForward)
+	dotGoAddStr(w, "CursorHome", t.CursorHome)
+	dotGoAddStr(w, "CarriageReturn", t.CarriageReturn)
+	dotGoAddStr(w, "ColumnAddress", t.ColumnAddress)
+	dotGoAddStr(w, "RowAddress", t.RowAddress)
+	dotGoAddStr(w, "ClearToEOL", t.ClearToEOL)
+	dotGoAddStr(w, "EraseChars", t.EraseChars)
+	dotGoAddStr(w, "RepeatChar", t.RepeatChar)
+	dotGoAddBool(w, "BackColorErase", t.BackColorErase)
+	dotGoAddStr(w, "ExitUnderline", t.ExitUnderline)
+	dotGoAddStr(w, "ResetFgBg", t.ResetFgBg)
+	dotGoAddStr(w, "EnablePaste", t.EnablePaste)
+	dotGoAddStr(w, "DisablePaste", t.DisablePaste)
+	dotGoAddStr(w, "PasteStart", t.PasteStart)
+	dotGoAddStr(w, "PasteEnd", t.PasteEnd)
+	dotGoAddStr(w, "EnableFocus", t.EnableFocus)
+	dotGoAddStr(w, "DisableFocus", t.DisableFocus)
+	dotGoAddStr(w, "FocusIn", t.FocusIn)
+	dotGoAddStr(w, "FocusOut", t.FocusOut)
+	dotGoAddStr(w, "SetClipboard", t.SetClipboard)
+	dotGoAddStr(w, "TitleStart", t.TitleStart)
+	dotGoAddStr(w, "TitleEnd", t.TitleEnd)
+	dotGoAddStr(w, "PushTitle", t.PushTitle)
+	dotGoAddStr(w, "PopTitle", t.PopTitle)
 	dotGoAddStr(w, "KeyUp", t.KeyUp)
 	dotGoAddStr(w, "KeyDown", t.KeyDown)
 	dotGoAddStr(w, "KeyRight", t.KeyRight)
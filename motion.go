@@ -0,0 +1,139 @@
+// Copyright 2017 The TCell Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use file except in compliance with the License.
+// You may obtain a copy of the license at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tcell
+
+import (
+	"bytes"
+	"strings"
+)
+
+// motion holds the terminal's single step cursor motions, with any
+// padding they need already applied.  A motion that the terminal lacks,
+// or that cannot be relied upon, is empty.
+type motion struct {
+	cr    string
+	home  string
+	left  string
+	right string
+	up    string
+	down  string
+}
+
+// prepareMotion works out the single step motions, which only depend on
+// the terminal and the line speed.
+func (r *Renderer) prepareMotion() {
+	ti := r.ti
+	r.mv = motion{
+		cr:    r.padded(ti.CarriageReturn),
+		home:  r.padded(ti.CursorHome),
+		left:  r.padded(ti.CursorBack1),
+		right: r.padded(ti.CursorForward1),
+		up:    r.padded(ti.CursorUp1),
+		down:  r.padded(ti.CursorDown1),
+	}
+	if ti.CursorDown1 == "\n" {
+		// The far end may well turn a line feed into a carriage
+		// return and line feed, which loses the column.
+		r.mv.down = ""
+	}
+}
+
+// padded returns the string with the padding it needs at our line speed.
+func (r *Renderer) padded(s string) string {
+	if s == "" {
+		return ""
+	}
+	buf := &bytes.Buffer{}
+	r.ti.TPuts(buf, s, r.baud)
+	return buf.String()
+}
+
+// steps returns the cheaper way of moving n cells in one direction, either
+// by repeating the single step motion, or with the parameterized motion.
+func (r *Renderer) steps(one string, many string, n int) (string, bool) {
+	if n == 0 {
+		return "", true
+	}
+	best, ok := "", false
+	if many != "" {
+		best, ok = r.padded(r.ti.TParm(many, n)), true
+	}
+	if one != "" && (!ok || len(one)*n < len(best)) {
+		best, ok = strings.Repeat(one, n), true
+	}
+	return best, ok
+}
+
+// horizontal returns the cheapest relative motion between two columns.
+func (r *Renderer) horizontal(from, to int) (string, bool) {
+	if to < from {
+		return r.steps(r.mv.left, r.ti.CursorBack, from-to)
+	}
+	return r.steps(r.mv.right, r.ti.CursorForward, to-from)
+}
+
+// vertical returns the cheapest relative motion between two rows.
+func (r *Renderer) vertical(from, to int) (string, bool) {
+	if to < from {
+		return r.steps(r.mv.up, r.ti.CursorUp, from-to)
+	}
+	return r.steps(r.mv.down, r.ti.CursorDown, to-from)
+}
+
+// goTo moves the cursor to the given cell of a w by h screen, choosing
+// whichever way of getting there takes the fewest bytes, much as mvcur
+// does in curses.  Apart from absolute addressing, the cursor can go
+// home first, or to the start of the line, or to the right column or
+// row, and from there make its way with relative motions.  Relative
+// motions are only used when we know where the cursor is; when it has
+// just been drawn in the last column, for example, we do not, as
+// terminals differ on what happens at the right margin.
+func (r *Renderer) goTo(x, y, w, h int) {
+	ti := r.ti
+	best := r.padded(ti.TGoto(x, y))
+	try := func(s string, ok bool) {
+		if ok && len(s) < len(best) {
+			best = s
+		}
+	}
+	join := func(a string, aok bool, b string, bok bool) (string, bool) {
+		return a + b, aok && bok
+	}
+
+	if r.cx >= 0 && r.cy >= 0 && r.cx < w && r.cy < h {
+		v, vok := r.vertical(r.cy, y)
+		hz, hok := r.horizontal(r.cx, x)
+		try(join(v, vok, hz, hok))
+		if r.mv.cr != "" {
+			hz, hok := r.horizontal(0, x)
+			try(join(r.mv.cr+v, vok, hz, hok))
+		}
+		if ti.ColumnAddress != "" {
+			try(join(v, vok, r.padded(ti.TParm(ti.ColumnAddress, x)), true))
+		}
+		if ti.RowAddress != "" {
+			try(join(r.padded(ti.TParm(ti.RowAddress, y)), true, hz, hok))
+		}
+	}
+	if r.mv.home != "" {
+		v, vok := r.vertical(0, y)
+		hz, hok := r.horizontal(0, x)
+		try(join(r.mv.home+v, vok, hz, hok))
+	}
+
+	r.buf.WriteString(best)
+	r.cx = x
+	r.cy = y
+}
@@ -0,0 +1,46 @@
+// Copyright 2017 The TCell Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use file except in compliance with the License.
+// You may obtain a copy of the license at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tcell
+
+import (
+	"time"
+)
+
+// EventPaste is sent when text is pasted into the terminal, once
+// EnablePaste has been called, if the terminal supports bracketed paste.
+// The whole of the text arrives in a single event, rather than as a key
+// event for each character, so a line break in it does not press Enter.
+// Only text of more than a megabyte is split across several events.
+// Line breaks are as the terminal sent them, which is usually as carriage
+// returns.
+type EventPaste struct {
+	t    time.Time
+	text string
+}
+
+// NewEventPaste creates an EventPaste with the given text.
+func NewEventPaste(text string) *EventPaste {
+	return &EventPaste{t: time.Now(), text: text}
+}
+
+// When returns the time when this event was created.
+func (ev *EventPaste) When() time.Time {
+	return ev.t
+}
+
+// Text returns the text that was pasted.
+func (ev *EventPaste) Text() string {
+	return ev.text
+}
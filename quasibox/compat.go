@@ -30,6 +30,7 @@ import (
 type Quasibox struct {
 	screen tcell.Screen
 	outMode OutputMode
+	pending []Event // yet to be returned by PollEvent
 }
 
 // Init initializes the screen for use, with the specified backing in/out, terminal type, and size.
@@ -392,6 +393,28 @@ func makeEvent(tev tcell.Event) Event {
 	}
 }
 
+// makeEvents is like makeEvent, except that pasted text, which quasibox
+// has no event for, becomes a key event for each character, just as if
+// it had been typed.
+func makeEvents(tev tcell.Event) []Event {
+	pev, ok := tev.(*tcell.EventPaste)
+	if !ok {
+		return []Event{makeEvent(tev)}
+	}
+	var evs []Event
+	for _, r := range pev.Text() {
+		k := tcell.KeyRune
+		if r < ' ' || r == 0x7f {
+			k, r = tcell.Key(r), 0
+		}
+		evs = append(evs, makeEvent(tcell.NewEventKey(k, r, tcell.ModNone)))
+	}
+	if len(evs) == 0 {
+		evs = append(evs, Event{Type: EventNone})
+	}
+	return evs
+}
+
 // ParseEvent is not supported.
 func ParseEvent(data []byte) Event {
 	// Not supported
@@ -406,8 +429,12 @@ func PollRawEvent(data []byte) Event {
 
 // PollEvent blocks until an event is ready, and then returns it.
 func (qb *Quasibox) PollEvent() Event {
-	ev := qb.screen.PollEvent()
-	return makeEvent(ev)
+	if len(qb.pending) == 0 {
+		qb.pending = makeEvents(qb.screen.PollEvent())
+	}
+	ev := qb.pending[0]
+	qb.pending = qb.pending[1:]
+	return ev
 }
 
 // Interrupt posts an interrupt event.
@@ -0,0 +1,476 @@
+// Copyright 2017 The TCell Authors
+// Copyright 2017 Daniel Selifonov
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use file except in compliance with the License.
+// You may obtain a copy of the license at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tcell
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// quasiOutput collects everything written to a quasi screen.
+type quasiOutput struct {
+	buf    bytes.Buffer
+	writes int
+	closed bool
+	err    error
+	sync.Mutex
+}
+
+func (o *quasiOutput) Write(b []byte) (int, error) {
+	o.Lock()
+	defer o.Unlock()
+	if o.err != nil {
+		return 0, o.err
+	}
+	o.writes++
+	return o.buf.Write(b)
+}
+
+// Fail causes subsequent writes to fail with the given error.
+func (o *quasiOutput) Fail(e error) {
+	o.Lock()
+	o.err = e
+	o.Unlock()
+}
+
+func (o *quasiOutput) Close() error {
+	o.Lock()
+	o.closed = true
+	o.Unlock()
+	return nil
+}
+
+func (o *quasiOutput) String() string {
+	o.Lock()
+	defer o.Unlock()
+	return o.buf.String()
+}
+
+// Reset discards the output collected so far.
+func (o *quasiOutput) Reset() {
+	o.Lock()
+	o.buf.Reset()
+	o.writes = 0
+	o.Unlock()
+}
+
+func (o *quasiOutput) Writes() int {
+	o.Lock()
+	defer o.Unlock()
+	return o.writes
+}
+
+func WithQuasiScreen(t *testing.T, opts QuasiScreenOptions, fn func(s Screen, in io.Writer, out *quasiOutput)) func() {
+	return func() {
+		pr, pw := io.Pipe()
+		out := &quasiOutput{}
+		if opts.Term == "" && opts.Terminfo == nil {
+			opts.Terminfo = testTerminfo
+		}
+		s, e := NewQuasiScreenWithOptions(pr, out, opts)
+		So(e, ShouldBeNil)
+		So(s, ShouldNotBeNil)
+		So(s.Init(), ShouldBeNil)
+		Reset(func() {
+			pw.Close()
+			s.Fini()
+		})
+		fn(s, pw, out)
+	}
+}
+
+func pollEventTimeout(s Screen, d time.Duration) Event {
+	evch := make(chan Event, 1)
+	go func() {
+		evch <- s.PollEvent()
+	}()
+	select {
+	case ev := <-evch:
+		return ev
+	case <-time.After(d):
+		return nil
+	}
+}
+
+func nextKeyEvent(s Screen, d time.Duration) *EventKey {
+	for {
+		switch ev := pollEventTimeout(s, d).(type) {
+		case *EventKey:
+			return ev
+		case nil:
+			return nil
+		}
+	}
+}
+
+func TestQuasiScreenOptions(t *testing.T) {
+
+	Convey("Quasi screen options", t, func() {
+
+		Convey("Charset comes from the options", WithQuasiScreen(t,
+			QuasiScreenOptions{Charset: "US-ASCII"},
+			func(s Screen, _ io.Writer, _ *quasiOutput) {
+				So(s.CharacterSet(), ShouldEqual, "US-ASCII")
+				So(s.CanDisplay('é', false), ShouldBeFalse)
+			}))
+
+		Convey("Charset defaults to UTF-8", WithQuasiScreen(t,
+			QuasiScreenOptions{},
+			func(s Screen, _ io.Writer, _ *quasiOutput) {
+				So(s.CharacterSet(), ShouldEqual, "UTF-8")
+				So(s.CanDisplay('é', false), ShouldBeTrue)
+			}))
+
+		Convey("Size comes from the options", WithQuasiScreen(t,
+			QuasiScreenOptions{Width: 132, Height: 43},
+			func(s Screen, _ io.Writer, _ *quasiOutput) {
+				w, h := s.Size()
+				So(w, ShouldEqual, 132)
+				So(h, ShouldEqual, 43)
+			}))
+
+		Convey("Truecolor hint is honored", WithQuasiScreen(t,
+			QuasiScreenOptions{ColorTerm: "truecolor"},
+			func(s Screen, _ io.Writer, _ *quasiOutput) {
+				So(s.Colors(), ShouldEqual, 1<<24)
+				So(testTerminfo.SetFgRGB, ShouldEqual, "")
+			}))
+
+		Convey("Forced colors are honored", WithQuasiScreen(t,
+			QuasiScreenOptions{ColorTerm: "truecolor", Colors: 16},
+			func(s Screen, _ io.Writer, _ *quasiOutput) {
+				So(s.Colors(), ShouldEqual, 16)
+			}))
+	})
+}
+
+func TestQuasiScreenEscapeDelay(t *testing.T) {
+
+	opts := QuasiScreenOptions{
+		Term:        "xterm",
+		EscapeDelay: time.Millisecond * 20,
+	}
+	Convey("Escape handling", t, WithQuasiScreen(t, opts,
+		func(s Screen, in io.Writer, _ *quasiOutput) {
+
+			Convey("A lone ESC is delivered after the delay", func() {
+				in.Write([]byte{'\x1b'})
+				ev := nextKeyEvent(s, time.Second)
+				So(ev, ShouldNotBeNil)
+				So(ev.Key(), ShouldEqual, KeyEsc)
+			})
+
+			Convey("A split sequence is still recognized", func() {
+				in.Write([]byte("\x1b["))
+				in.Write([]byte("A"))
+				ev := nextKeyEvent(s, time.Second)
+				So(ev, ShouldNotBeNil)
+				So(ev.Key(), ShouldEqual, KeyUp)
+			})
+		}))
+}
+
+func TestQuasiScreenFrames(t *testing.T) {
+
+	Convey("Frame buffering", t, func() {
+
+		Convey("Show writes a frame at once", WithQuasiScreen(t,
+			QuasiScreenOptions{},
+			func(s Screen, _ io.Writer, out *quasiOutput) {
+				out.Reset()
+				for x := 0; x < 40; x++ {
+					st := StyleDefault.Foreground(Color(x % 8))
+					s.SetContent(x, x%10, 'A'+rune(x%26), nil, st)
+				}
+				s.Show()
+				So(out.Writes(), ShouldEqual, 1)
+				So(out.String(), ShouldContainSubstring, "A")
+
+				out.Reset()
+				s.Sync()
+				So(out.Writes(), ShouldEqual, 1)
+			}))
+
+		Convey("Synchronized updates wrap the frame", WithQuasiScreen(t,
+			QuasiScreenOptions{SynchronizedUpdates: true},
+			func(s Screen, _ io.Writer, out *quasiOutput) {
+				out.Reset()
+				s.SetContent(1, 1, 'Z', nil, StyleDefault)
+				s.Show()
+				So(out.Writes(), ShouldEqual, 1)
+				str := out.String()
+				So(str, ShouldStartWith, "\x1b[?2026h")
+				So(str, ShouldEndWith, "\x1b[?2026l")
+				So(str, ShouldContainSubstring, "Z")
+			}))
+	})
+}
+
+func TestQuasiScreenBell(t *testing.T) {
+
+	Convey("The bell is rung at once", t, WithQuasiScreen(t,
+		QuasiScreenOptions{},
+		func(s Screen, _ io.Writer, out *quasiOutput) {
+			out.Reset()
+			s.SetContent(0, 0, 'X', nil, StyleDefault)
+			s.Beep()
+			So(out.String(), ShouldEqual, "\a")
+			s.Show()
+			So(out.String(), ShouldContainSubstring, "X")
+		}))
+
+	Convey("A flash does not hold up drawing", t, WithQuasiScreen(t,
+		QuasiScreenOptions{Term: "xterm"},
+		func(s Screen, _ io.Writer, out *quasiOutput) {
+			out.Reset()
+			start := time.Now()
+			s.Flash()
+			So(out.String(), ShouldEqual, "\x1b[?5h")
+			s.SetContent(0, 0, 'X', nil, StyleDefault)
+			s.Show()
+			So(time.Since(start), ShouldBeLessThan, 100*time.Millisecond)
+			So(out.String(), ShouldContainSubstring, "X")
+			So(out.String(), ShouldNotContainSubstring, "\x1b[?5l")
+
+			deadline := time.Now().Add(time.Second)
+			for !strings.HasSuffix(out.String(), "\x1b[?5l") &&
+				time.Now().Before(deadline) {
+				time.Sleep(10 * time.Millisecond)
+			}
+			So(out.String(), ShouldEndWith, "\x1b[?5l")
+		}))
+
+	Convey("Detaching ends a flash", t, WithQuasiScreen(t,
+		QuasiScreenOptions{Term: "xterm"},
+		func(s Screen, _ io.Writer, out *quasiOutput) {
+			out.Reset()
+			s.Flash()
+			s.(QuasiScreen).Detach()
+			So(out.String(), ShouldStartWith, "\x1b[?5h\x1b[?5l")
+		}))
+}
+
+func TestQuasiScreenTitle(t *testing.T) {
+
+	Convey("Titles are set, and put back", t, WithQuasiScreen(t,
+		QuasiScreenOptions{Term: "xterm"},
+		func(s Screen, _ io.Writer, out *quasiOutput) {
+			qs := s.(QuasiScreen)
+			out.Reset()
+			s.SetTitle("one")
+			So(out.String(), ShouldEqual, "\x1b[22;0t\x1b]2;one\x07")
+			out.Reset()
+			s.SetTitle("two")
+			So(out.String(), ShouldEqual, "\x1b]2;two\x07")
+			qs.Detach()
+			So(out.String(), ShouldEndWith, "\x1b[23;0t")
+
+			// Each new client has its title saved, and set.
+			pr, pw := io.Pipe()
+			Reset(func() {
+				pw.Close()
+			})
+			out = &quasiOutput{}
+			So(qs.Attach(pr, out, QuasiScreenOptions{Term: "xterm"}),
+				ShouldBeNil)
+			So(out.String(), ShouldContainSubstring,
+				"\x1b[22;0t\x1b]2;two\x07")
+		}))
+
+	Convey("Titles are not set after Fini", t, WithQuasiScreen(t,
+		QuasiScreenOptions{Term: "xterm"},
+		func(s Screen, _ io.Writer, out *quasiOutput) {
+			s.Fini()
+			s.SetTitle("late")
+			So(s.(*qScreen).titled, ShouldBeFalse)
+		}))
+}
+
+func TestQuasiScreenErrors(t *testing.T) {
+
+	nextError := func(s Screen) *EventError {
+		for {
+			switch ev := pollEventTimeout(s, time.Second).(type) {
+			case *EventError:
+				return ev
+			case nil:
+				return nil
+			}
+		}
+	}
+
+	Convey("I/O errors", t, func() {
+
+		Convey("Read errors are reported", WithQuasiScreen(t,
+			QuasiScreenOptions{},
+			func(s Screen, in io.Writer, _ *quasiOutput) {
+				in.(*io.PipeWriter).Close()
+				ev := nextError(s)
+				So(ev, ShouldNotBeNil)
+				So(ev.Error(), ShouldEqual, io.EOF.Error())
+			}))
+
+		Convey("Write errors are reported", WithQuasiScreen(t,
+			QuasiScreenOptions{},
+			func(s Screen, _ io.Writer, out *quasiOutput) {
+				out.Fail(errors.New("broken pipe"))
+				s.SetContent(0, 0, 'X', nil, StyleDefault)
+				s.Show()
+				ev := nextError(s)
+				So(ev, ShouldNotBeNil)
+				So(ev.Error(), ShouldEqual, "broken pipe")
+			}))
+
+		Convey("Errors can finalize the screen", WithQuasiScreen(t,
+			QuasiScreenOptions{FiniOnError: true},
+			func(s Screen, in io.Writer, out *quasiOutput) {
+				in.(*io.PipeWriter).Close()
+				ev := pollEventTimeout(s, time.Second)
+				_, ok := ev.(*EventError)
+				So(ok, ShouldBeTrue)
+				So(pollEventTimeout(s, time.Second), ShouldBeNil)
+				out.Lock()
+				So(out.closed, ShouldBeTrue)
+				out.Unlock()
+			}))
+	})
+}
+
+func TestQuasiScreenAttach(t *testing.T) {
+
+	Convey("Detaching and attaching clients", t, func() {
+
+		Convey("Detach closes the client and stops output", WithQuasiScreen(t,
+			QuasiScreenOptions{},
+			func(s Screen, _ io.Writer, out *quasiOutput) {
+				qs := s.(QuasiScreen)
+				qs.Detach()
+				out.Lock()
+				So(out.closed, ShouldBeTrue)
+				out.Unlock()
+
+				out.Reset()
+				s.SetContent(0, 0, 'X', nil, StyleDefault)
+				s.Show()
+				s.Sync()
+				So(out.Writes(), ShouldEqual, 0)
+
+				// The closed input is not an error.
+				ev := pollEventTimeout(s, 100*time.Millisecond)
+				_, ok := ev.(*EventError)
+				So(ok, ShouldBeFalse)
+			}))
+
+		Convey("Attach redraws for the new client", WithQuasiScreen(t,
+			QuasiScreenOptions{},
+			func(s Screen, _ io.Writer, _ *quasiOutput) {
+				qs := s.(QuasiScreen)
+				for i, r := range "hello" {
+					s.SetContent(i, 0, r, nil, StyleDefault)
+				}
+				s.EnableMouse()
+				s.Show()
+				qs.Detach()
+				s.SetContent(6, 0, 'X', nil, StyleDefault)
+				s.Show()
+
+				pr, pw := io.Pipe()
+				out := &quasiOutput{}
+				Reset(func() {
+					pw.Close()
+				})
+				So(qs.Attach(pr, out, QuasiScreenOptions{
+					Terminfo: testTerminfo,
+					Width:    20,
+					Height:   5,
+				}), ShouldBeNil)
+				So(out.String(), ShouldContainSubstring, "\x1b[?1000h")
+				So(out.String(), ShouldContainSubstring, "hello")
+				So(out.String(), ShouldContainSubstring, "X")
+
+				w, h := s.Size()
+				So(w, ShouldEqual, 20)
+				So(h, ShouldEqual, 5)
+				var resize *EventResize
+				for resize == nil {
+					ev := pollEventTimeout(s, time.Second)
+					So(ev, ShouldNotBeNil)
+					resize, _ = ev.(*EventResize)
+				}
+				w, h = resize.Size()
+				So(w, ShouldEqual, 20)
+				So(h, ShouldEqual, 5)
+
+				// Input comes from the new client.
+				io.WriteString(pw, "z")
+				ev := nextKeyEvent(s, time.Second)
+				So(ev, ShouldNotBeNil)
+				So(ev.Rune(), ShouldEqual, 'z')
+			}))
+
+		Convey("A failed attach leaves the client alone", WithQuasiScreen(t,
+			QuasiScreenOptions{},
+			func(s Screen, _ io.Writer, out *quasiOutput) {
+				qs := s.(QuasiScreen)
+				e := qs.Attach(nil, nil, QuasiScreenOptions{
+					Terminfo: testTerminfo,
+					Charset:  "no-such-charset",
+				})
+				So(e, ShouldEqual, ErrNoCharset)
+				out.Lock()
+				So(out.closed, ShouldBeFalse)
+				out.Unlock()
+			}))
+
+		Convey("Clients need not have input", func() {
+			out := &quasiOutput{}
+			s, e := NewQuasiScreenWithOptions(nil, out,
+				QuasiScreenOptions{Terminfo: testTerminfo})
+			So(e, ShouldBeNil)
+			So(s.Init(), ShouldBeNil)
+			s.Detach()
+			out.Lock()
+			So(out.closed, ShouldBeTrue)
+			out.Unlock()
+
+			out = &quasiOutput{}
+			So(s.Attach(nil, out, QuasiScreenOptions{
+				Terminfo: testTerminfo,
+			}), ShouldBeNil)
+			s.Fini()
+			out.Lock()
+			So(out.closed, ShouldBeTrue)
+			out.Unlock()
+		})
+
+		Convey("Attach fails after Fini", WithQuasiScreen(t,
+			QuasiScreenOptions{},
+			func(s Screen, _ io.Writer, _ *quasiOutput) {
+				s.Fini()
+				e := s.(QuasiScreen).Attach(nil, nil, QuasiScreenOptions{
+					Terminfo: testTerminfo,
+				})
+				So(e, ShouldEqual, ErrScreenFini)
+			}))
+	})
+}
@@ -0,0 +1,449 @@
+// Copyright 2017 The TCell Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use file except in compliance with the License.
+// You may obtain a copy of the license at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tcell_test
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/mattn/go-runewidth"
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/thyth/tcell"
+	"github.com/thyth/tcell/vt"
+	"golang.org/x/text/encoding"
+)
+
+// renderMismatch describes a cell that a terminal displayed differently
+// from how it was drawn.
+type renderMismatch struct {
+	x    int
+	y    int
+	what string
+	want string
+	got  string
+}
+
+func (m renderMismatch) String() string {
+	return fmt.Sprintf("(%d,%d) %s: want %s, got %s",
+		m.x, m.y, m.what, m.want, m.got)
+}
+
+// renderModel predicts what a terminal displays, given what was drawn,
+// taking the limitations of the terminal and its character set into
+// account.
+type renderModel struct {
+	ti        *tcell.Terminfo
+	charset   string
+	enc       encoding.Encoding
+	acs       map[rune]bool // true if the line drawing can be verified
+	attrs     map[tcell.AttrMask]tcell.AttrMask
+	palette   []tcell.Color
+	truecolor bool
+}
+
+// lineDrawing lists the runes of the VT100 line drawing set, by the byte
+// that selects them.
+var lineDrawing = map[byte]rune{
+	'+': tcell.RuneRArrow, ',': tcell.RuneLArrow, '-': tcell.RuneUArrow,
+	'.': tcell.RuneDArrow, '0': tcell.RuneBlock, '`': tcell.RuneDiamond,
+	'a': tcell.RuneCkBoard, 'f': tcell.RuneDegree, 'g': tcell.RunePlMinus,
+	'h': tcell.RuneBoard, 'i': tcell.RuneLantern, 'j': tcell.RuneLRCorner,
+	'k': tcell.RuneURCorner, 'l': tcell.RuneULCorner, 'm': tcell.RuneLLCorner,
+	'n': tcell.RunePlus, 'o': tcell.RuneS1, 'p': tcell.RuneS3,
+	'q': tcell.RuneHLine, 'r': tcell.RuneS7, 's': tcell.RuneS9,
+	't': tcell.RuneLTee, 'u': tcell.RuneRTee, 'v': tcell.RuneBTee,
+	'w': tcell.RuneTTee, 'x': tcell.RuneVLine, 'y': tcell.RuneLEqual,
+	'z': tcell.RuneGEqual, '{': tcell.RunePi, '|': tcell.RuneNEqual,
+	'}': tcell.RuneSterling, '~': tcell.RuneBullet,
+}
+
+func newRenderModel(ti *tcell.Terminfo, charset string) *renderModel {
+	m := &renderModel{
+		ti:      ti,
+		charset: charset,
+		enc:     tcell.GetEncoding(charset),
+		acs:     make(map[rune]bool),
+		attrs:   make(map[tcell.AttrMask]tcell.AttrMask),
+	}
+
+	// Only the VT100 line drawing set can be checked.  Characters that
+	// are mapped to other codes, or drawn in some other way (such as
+	// with the PC character set on the console), are not verified.
+	dec := strings.Contains(ti.EnterAcs, "\x1b(0") ||
+		(ti.EnterAcs == "\x0e" && strings.Contains(ti.EnableAcs, "\x1b)0"))
+	for i := 0; i+1 < len(ti.AltChars); i += 2 {
+		if r, ok := lineDrawing[ti.AltChars[i]]; ok {
+			m.acs[r] = dec && ti.AltChars[i] == ti.AltChars[i+1]
+		}
+	}
+
+	// Some terminals show attributes differently from what their names
+	// suggest; the d210 uses reverse underline for bold, for example.
+	// So the attributes expected are whatever the capability displays.
+	for attr, seq := range map[tcell.AttrMask]string{
+		tcell.AttrBold:      ti.Bold,
+		tcell.AttrUnderline: ti.Underline,
+		tcell.AttrReverse:   ti.Reverse,
+		tcell.AttrBlink:     ti.Blink,
+		tcell.AttrDim:       ti.Dim,
+	} {
+		term := vt.NewTerminal(1, 1)
+		term.Write([]byte(seq + "x"))
+		_, _, st, _ := term.GetContent(0, 0)
+		_, _, m.attrs[attr] = st.Decompose()
+	}
+
+	m.truecolor = ti.SetFgBgRGB != "" || ti.SetFgRGB != "" ||
+		ti.SetBgRGB != ""
+	for i := 0; i < ti.Colors; i++ {
+		m.palette = append(m.palette, tcell.Color(i))
+	}
+	return m
+}
+
+func (m *renderModel) encodes(r rune) bool {
+	b, e := m.enc.NewEncoder().Bytes([]byte(string(r)))
+	return e == nil && len(b) != 0 && b[0] != '\x1a'
+}
+
+// verifiable reports whether a character can be checked.  Those that are
+// drawn with anything other than the VT100 line drawing set cannot be, and
+// may even be sent as control characters.
+func (m *renderModel) verifiable(r rune) bool {
+	verified, isacs := m.acs[r]
+	return !isacs || verified || m.encodes(r)
+}
+
+// text returns the text expected in each of the cells covered by a
+// character, and whether it should be shown as a wide character.
+func (m *renderModel) text(x, w int, mainc rune, combc []rune) ([]string, bool) {
+	width := runewidth.RuneWidth(mainc)
+	if width < 1 {
+		width = 1
+	}
+	if x > w-width {
+		return []string{" "}, false
+	}
+	s := ""
+	switch fb, ok := tcell.RuneFallbacks[mainc]; {
+	case m.encodes(mainc), m.acs[mainc]:
+		s = string(mainc)
+	case ok:
+		s = fb
+	default:
+		s = "?"
+	}
+	for _, r := range combc {
+		if m.encodes(r) {
+			s += string(r)
+		}
+	}
+	if width > 1 && s == "?" {
+		return []string{"?", " "}, false
+	}
+	return []string{s}, width > 1
+}
+
+func (m *renderModel) color(c tcell.Color) tcell.Color {
+	switch {
+	case c == tcell.ColorDefault || m.ti.Colors == 0:
+		return tcell.ColorDefault
+	case m.truecolor:
+		r, g, b := c.RGB()
+		return tcell.NewRGBColor(r, g, b)
+	case c&tcell.ColorIsRGB == 0 && int(c) < len(m.palette):
+		return c
+	}
+	return tcell.FindColor(c, m.palette)
+}
+
+func (m *renderModel) style(st tcell.Style) tcell.Style {
+	fg, bg, attrs := st.Decompose()
+	shown := tcell.AttrMask(0)
+	for attr, as := range m.attrs {
+		if attrs&attr != 0 {
+			shown |= as
+		}
+	}
+	return tcell.StyleDefault.Foreground(m.color(fg)).
+		Background(m.color(bg)).
+		Bold(shown&tcell.AttrBold != 0).
+		Underline(shown&tcell.AttrUnderline != 0).
+		Reverse(shown&tcell.AttrReverse != 0).
+		Blink(shown&tcell.AttrBlink != 0).
+		Dim(shown&tcell.AttrDim != 0)
+}
+
+// isANSI reports whether the terminal uses ANSI control sequences, which
+// are the only ones the vt package understands.  (Any padding after the
+// cursor motion is fine, since TPuts removes it.)
+func isANSI(ti *tcell.Terminfo) bool {
+	return strings.HasPrefix(ti.TGoto(3, 5), "\x1b[6;4H")
+}
+
+// verifyRender draws the contents of cb with the terminal description and
+// character set of the model, interprets the output with a vt.Terminal, and
+// returns the cells that came out differently than the model predicts.  If
+// change is not nil, it is called once the first frame has been drawn, and
+// a second frame, with just the cells that it changed, is drawn over it.
+func verifyRender(m *renderModel, cb *tcell.CellBuffer, change func()) ([]renderMismatch, error) {
+	buf := &bytes.Buffer{}
+	r := tcell.NewRenderer(m.ti, m.enc, buf)
+	// This is what the screens send when they start.
+	r.TPuts(m.ti.EnterCA)
+	r.TPuts(m.ti.HideCursor)
+	r.TPuts(m.ti.EnableAcs)
+	r.TPuts(m.ti.Clear)
+	r.Draw(cb)
+	if change != nil {
+		change()
+		r.Draw(cb)
+	}
+	r.Flush()
+	out, e := m.enc.NewDecoder().Bytes(buf.Bytes())
+	if e != nil {
+		return nil, e
+	}
+	w, h := cb.Size()
+	term := vt.NewTerminal(w, h)
+	term.Write(out)
+
+	var bad []renderMismatch
+	report := func(x, y int, what string, want, got interface{}) {
+		bad = append(bad, renderMismatch{x, y, what,
+			fmt.Sprintf("%q", want), fmt.Sprintf("%q", got)})
+	}
+	describe := func(st tcell.Style) string {
+		fg, bg, attrs := st.Decompose()
+		return fmt.Sprintf("fg=%x bg=%x attrs=%x", fg, bg, attrs>>25)
+	}
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; {
+			mainc, combc, style, width := cb.GetContent(x, y)
+			texts, wide := m.text(x, w, mainc, combc)
+			want := m.style(style)
+			for i, text := range texts {
+				gmainc, gcombc, gstyle, gwidth := term.GetContent(x+i, y)
+				got := string(append([]rune{gmainc}, gcombc...))
+				if got != text {
+					report(x+i, y, "rune", text, got)
+				} else if wide && gwidth != 2 {
+					report(x+i, y, "width", 2, gwidth)
+				}
+				if gstyle != want {
+					report(x+i, y, "style",
+						describe(want), describe(gstyle))
+				}
+			}
+			if len(texts) == 1 && !wide {
+				width = 1
+			}
+			x += width
+		}
+	}
+	return bad, nil
+}
+
+var (
+	wideRunes      = []rune("世界中文字")
+	latinRunes     = []rune("éñüßøÅ£")
+	combiningRunes = []rune{'\u0301', '\u0308'}
+	specialRunes   []rune
+)
+
+func init() {
+	for r := range tcell.RuneFallbacks {
+		specialRunes = append(specialRunes, r)
+	}
+	sort.Slice(specialRunes, func(i, j int) bool {
+		return specialRunes[i] < specialRunes[j]
+	})
+}
+
+func randomColor(rnd *rand.Rand) tcell.Color {
+	switch rnd.Intn(4) {
+	case 0:
+		return tcell.ColorDefault
+	case 1:
+		return tcell.Color(rnd.Intn(16))
+	case 2:
+		return tcell.Color(rnd.Intn(256))
+	}
+	return tcell.NewRGBColor(rnd.Int31n(256), rnd.Int31n(256),
+		rnd.Int31n(256))
+}
+
+func randomStyle(rnd *rand.Rand) tcell.Style {
+	if rnd.Intn(4) == 0 {
+		return tcell.StyleDefault
+	}
+	return tcell.StyleDefault.
+		Foreground(randomColor(rnd)).
+		Background(randomColor(rnd)).
+		Bold(rnd.Intn(4) == 0).
+		Underline(rnd.Intn(4) == 0).
+		Reverse(rnd.Intn(6) == 0).
+		Blink(rnd.Intn(8) == 0).
+		Dim(rnd.Intn(8) == 0)
+}
+
+// randomCells fills a CellBuffer with a random mix of text, line drawing
+// characters, wide and combining characters, in random styles.  Only
+// characters that the model can verify are used.
+func randomCells(rnd *rand.Rand, w, h int, m *renderModel) *tcell.CellBuffer {
+	cb := &tcell.CellBuffer{}
+	cb.Resize(w, h)
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			st := randomStyle(rnd)
+			var combc []rune
+			var mainc rune
+			switch n := rnd.Intn(20); {
+			case n < 10:
+				mainc = rune(' ' + rnd.Intn(95))
+			case n < 12:
+				mainc = latinRunes[rnd.Intn(len(latinRunes))]
+			case n < 15:
+				mainc = specialRunes[rnd.Intn(len(specialRunes))]
+			case n < 17:
+				mainc = wideRunes[rnd.Intn(len(wideRunes))]
+			case n < 18:
+				mainc = 'a'
+				combc = []rune{combiningRunes[rnd.Intn(2)]}
+			default:
+				mainc = ' '
+			}
+			if !m.verifiable(mainc) {
+				mainc = '*'
+			}
+			cb.SetContent(x, y, mainc, combc, st)
+			if runewidth.RuneWidth(mainc) > 1 && x+1 < w {
+				x++
+				cb.SetContent(x, y, ' ', nil, st)
+			}
+		}
+	}
+	return cb
+}
+
+// changeCells changes a few cells scattered over the buffer to other
+// narrow characters, leaving wide characters alone.
+func changeCells(rnd *rand.Rand, cb *tcell.CellBuffer, m *renderModel, n int) {
+	w, h := cb.Size()
+	for i := 0; i < n; i++ {
+		x, y := rnd.Intn(w), rnd.Intn(h)
+		if _, _, _, width := cb.GetContent(x, y); width > 1 {
+			continue
+		}
+		if _, _, _, width := cb.GetContent(x-1, y); x > 0 && width > 1 {
+			continue
+		}
+		mainc := rune('a' + rnd.Intn(26))
+		if rnd.Intn(4) == 0 {
+			mainc = specialRunes[rnd.Intn(len(specialRunes))]
+		}
+		if !m.verifiable(mainc) || runewidth.RuneWidth(mainc) > 1 {
+			mainc = '*'
+		}
+		cb.SetContent(x, y, mainc, nil, randomStyle(rnd))
+	}
+}
+
+// fillRuns overwrites a few stretches of lines, some of them reaching the
+// end of the line, with blanks or a repeated letter, all in one style.
+func fillRuns(rnd *rand.Rand, cb *tcell.CellBuffer, n int) {
+	w, h := cb.Size()
+	for i := 0; i < n; i++ {
+		x, y := rnd.Intn(w), rnd.Intn(h)
+		if _, _, _, width := cb.GetContent(x-1, y); x > 0 && width > 1 {
+			continue
+		}
+		mainc := ' '
+		if rnd.Intn(2) == 0 {
+			mainc = rune('a' + rnd.Intn(26))
+		}
+		st := tcell.StyleDefault
+		switch rnd.Intn(3) {
+		case 0:
+			st = st.Background(randomColor(rnd))
+		case 1:
+			st = randomStyle(rnd)
+		}
+		end := w
+		if rnd.Intn(2) == 0 {
+			end = x + 1 + rnd.Intn(w-x)
+		}
+		for ; x < end; x++ {
+			if _, _, _, width := cb.GetContent(x, y); width > 1 {
+				break
+			}
+			cb.SetContent(x, y, mainc, nil, st)
+		}
+	}
+}
+
+func TestRenderRoundTrip(t *testing.T) {
+
+	// Each terminal is a test of its own, so that all of them are
+	// reported on, however many fail.
+	for _, name := range tcell.BuiltinTerminfos() {
+		name := name
+		t.Run(name, func(t *testing.T) {
+			ti, e := tcell.LookupTerminfo(name)
+			if e != nil {
+				t.Fatal(e)
+			}
+			if !isANSI(ti) {
+				t.Skip("not an ANSI terminal")
+			}
+			for _, charset := range []string{"UTF-8", "US-ASCII"} {
+				Convey("Rendering in "+charset+" matches the model", t, func() {
+					m := newRenderModel(ti, charset)
+					rnd := rand.New(rand.NewSource(1))
+					var lines []string
+					for i := 0; i < 4; i++ {
+						cb := randomCells(rnd, 30, 8, m)
+						fillRuns(rnd, cb, 4)
+						// Every other time, a few cells are
+						// changed afterwards, which makes the
+						// cursor jump about.
+						var change func()
+						if i%2 == 1 {
+							change = func() {
+								changeCells(rnd, cb, m, 20)
+								fillRuns(rnd, cb, 4)
+							}
+						}
+						bad, e := verifyRender(m, cb, change)
+						So(e, ShouldBeNil)
+						for _, m := range bad {
+							lines = append(lines,
+								fmt.Sprintf("frame %d: %s", i, m))
+						}
+					}
+					if len(lines) > 10 {
+						lines = append(lines[:10], fmt.Sprintf(
+							"... and %d more", len(lines)-10))
+					}
+					So(strings.Join(lines, "\n"), ShouldBeEmpty)
+				})
+			}
+		})
+	}
+}
@@ -0,0 +1,713 @@
+// Copyright 2017 The TCell Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use file except in compliance with the License.
+// You may obtain a copy of the license at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tcell
+
+import (
+	"bytes"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/transform"
+)
+
+// Renderer draws the contents of a CellBuffer on a terminal described by
+// its terminfo entry.  It is what the terminfo and quasi screens use to
+// draw, but it can be used with any transport, for example to show the
+// same cells to several remote clients, each with its own terminal type.
+//
+// Only the cells that are dirty are drawn, and each is marked clean once
+// it has been, so drawing the same CellBuffer on more than one Renderer
+// needs a separate copy of it for each.  Output is collected until Flush
+// sends it to the writer in a single write.
+//
+// A Renderer is not safe for concurrent use.
+type Renderer struct {
+	ti        *Terminfo
+	out       io.Writer
+	buf       bytes.Buffer
+	encoder   transform.Transformer
+	baud      int
+	acs       map[rune]string
+	mv        motion
+	fallback  map[rune]string
+	colors    map[Color]Color
+	palette   []Color
+	truecolor bool
+	syncdraw  bool
+	style     Style
+	curstyle  Style
+	exitul    string
+	resetfgbg string
+	cx        int
+	cy        int
+	cursorx   int
+	cursory   int
+	clear     bool
+	flashend  string
+}
+
+// NewRenderer returns a Renderer for the given terminal, which encodes
+// characters in the given encoding and writes to w.  If the encoding is
+// nil, UTF-8 is assumed.  The colors used are chosen as for SetColors(0).
+func NewRenderer(ti *Terminfo, enc encoding.Encoding, w io.Writer) *Renderer {
+	if enc == nil {
+		enc = GetEncoding("UTF-8")
+	}
+	r := &Renderer{
+		ti:       ti,
+		out:      w,
+		encoder:  enc.NewEncoder(),
+		fallback: make(map[rune]string),
+		style:    StyleDefault,
+		curstyle: Style(-1),
+		cx:       -1,
+		cy:       -1,
+		cursorx:  -1,
+		cursory:  -1,
+	}
+	for k, v := range RuneFallbacks {
+		r.fallback[k] = v
+	}
+	r.exitul = sgrOnly(ti.ExitUnderline, "24")
+	r.resetfgbg = sgrOnly(ti.ResetFgBg, "39", "49")
+	r.buildAcsMap()
+	r.prepareMotion()
+	r.SetColors(0)
+	return r
+}
+
+// SetBaud sets the speed of the line to the terminal, in bits per second,
+// which is used to work out the padding needed by slow terminals.  The
+// default is zero, which means that no padding is sent.
+func (r *Renderer) SetBaud(baud int) {
+	r.baud = baud
+	r.buildAcsMap()
+	r.prepareMotion()
+}
+
+// SetColors limits the colors used to the first n of the terminal's
+// palette; colors outside of them are replaced with the closest match.
+// If n is 1<<24 or more, colors are sent as 24-bit RGB values instead,
+// provided that the terminal supports it.  If n is zero, 24-bit color is
+// used when the terminal supports it, and its whole palette otherwise.
+func (r *Renderer) SetColors(n int) {
+	ti := r.ti
+	rgb := ti.SetFgBgRGB != "" || ti.SetFgRGB != "" || ti.SetBgRGB != ""
+	r.truecolor = rgb && (n <= 0 || n >= 1<<24)
+	r.colors = nil
+	r.palette = nil
+	if r.truecolor {
+		return
+	}
+	if n <= 0 || n >= 1<<24 {
+		n = ti.Colors
+	}
+	r.colors = make(map[Color]Color)
+	r.palette = make([]Color, n)
+	for i := range r.palette {
+		r.palette[i] = Color(i)
+		// identity map for our builtin colors
+		r.colors[Color(i)] = Color(i)
+	}
+}
+
+// Colors returns the number of colors in use, which is 1<<24 when colors
+// are sent as RGB values.
+func (r *Renderer) Colors() int {
+	if r.truecolor {
+		return 1 << 24
+	}
+	return len(r.palette)
+}
+
+// SetSynchronizedUpdates makes each frame drawn a synchronized update
+// (DEC private mode 2026), so that terminals supporting it show the whole
+// frame at once, rather than as it arrives.  Other terminals ignore it.
+func (r *Renderer) SetSynchronizedUpdates(on bool) {
+	r.syncdraw = on
+}
+
+// SetStyle sets the style used for cells in StyleDefault, and for clearing
+// the screen.
+func (r *Renderer) SetStyle(style Style) {
+	r.style = style
+}
+
+// ShowCursor sets where the cursor is shown once a frame is drawn.  If the
+// position is not on the screen, the cursor is hidden instead.
+func (r *Renderer) ShowCursor(x, y int) {
+	r.cursorx = x
+	r.cursory = y
+}
+
+// HideCursor hides the cursor once a frame is drawn.
+func (r *Renderer) HideCursor() {
+	r.ShowCursor(-1, -1)
+}
+
+// RegisterRuneFallback works like the method of the same name on Screen.
+func (r *Renderer) RegisterRuneFallback(orig rune, fallback string) {
+	r.fallback[orig] = fallback
+}
+
+// UnregisterRuneFallback works like the method of the same name on Screen.
+func (r *Renderer) UnregisterRuneFallback(orig rune) {
+	delete(r.fallback, orig)
+}
+
+// CanDisplay works like the method of the same name on Screen.
+func (r *Renderer) CanDisplay(ch rune, checkFallbacks bool) bool {
+
+	if enc := r.encoder; enc != nil {
+		nb := make([]byte, 6)
+		ob := make([]byte, 6)
+		num := utf8.EncodeRune(ob, ch)
+
+		enc.Reset()
+		dst, _, err := enc.Transform(nb, ob[:num], true)
+		if dst != 0 && err == nil && nb[0] != '\x1A' {
+			return true
+		}
+	}
+	// Terminal fallbacks always permitted, since we assume they are
+	// basically nearly perfect renditions.
+	if _, ok := r.acs[ch]; ok {
+		return true
+	}
+	if !checkFallbacks {
+		return false
+	}
+	if _, ok := r.fallback[ch]; ok {
+		return true
+	}
+	return false
+}
+
+// TPuts adds a string from the terminfo entry, such as ti.EnterCA, to the
+// output, along with any padding that it needs.
+func (r *Renderer) TPuts(s string) {
+	r.ti.TPuts(&r.buf, s, r.baud)
+}
+
+// SetTitle adds a sequence that sets the window title to the output, if the
+// terminal has one.  Control characters, which could end the sequence
+// early, are left out, and characters that the terminal cannot display are
+// replaced with question marks.
+func (r *Renderer) SetTitle(title string) {
+	ti := r.ti
+	if ti.TitleStart == "" {
+		return
+	}
+	r.TPuts(ti.TitleStart)
+	nb := make([]byte, 6)
+	ob := make([]byte, 6)
+	for _, ch := range title {
+		if unicode.IsControl(ch) {
+			continue
+		}
+		num := utf8.EncodeRune(ob, ch)
+		if enc := r.encoder; enc != nil {
+			enc.Reset()
+			dst, _, err := enc.Transform(nb, ob[:num], true)
+			if dst == 0 || err != nil || nb[0] == '\x1a' {
+				r.buf.WriteByte('?')
+			} else {
+				r.buf.Write(nb[:dst])
+			}
+		} else {
+			r.buf.Write(ob[:num])
+		}
+	}
+	r.TPuts(ti.TitleEnd)
+}
+
+// Beep adds the sequence that rings the bell to the output, or, as in
+// curses, flashes the screen if the terminal has no bell, returning what
+// Flash does.
+func (r *Renderer) Beep() time.Duration {
+	if r.ti.Bell == "" && r.ti.Flash != "" {
+		return r.Flash()
+	}
+	r.TPuts(r.ti.Bell)
+	return 0
+}
+
+// Flash flashes the screen.  That usually shows the screen in reverse
+// video for a moment, given by the padding in the middle of the flash
+// sequence.  Terminal emulators take no time at all to display padding,
+// so the flash would not be seen; instead only the part before the
+// padding is added to the output, and Flash returns how long the padding
+// lasts.  Once the output has been sent and that time has passed,
+// EndFlash adds the rest.  The waiting is left to the caller, so that
+// nothing else need be held up meanwhile.  Like Beep, it falls back to
+// the other kind of bell.
+func (r *Renderer) Flash() time.Duration {
+	r.EndFlash()
+	s := r.ti.Flash
+	if s == "" {
+		r.TPuts(r.ti.Bell)
+		return 0
+	}
+	beg := strings.Index(s, "$<")
+	if beg < 0 {
+		r.TPuts(s)
+		return 0
+	}
+	end := strings.Index(s[beg:], ">")
+	if end < 0 {
+		r.TPuts(s)
+		return 0
+	}
+	end += beg
+	val := s[beg+2 : end]
+	if i := strings.IndexFunc(val, func(ch rune) bool {
+		return ch != '.' && (ch < '0' || ch > '9')
+	}); i >= 0 {
+		val = val[:i]
+	}
+	ms, _ := strconv.ParseFloat(val, 64)
+	d := time.Duration(ms * float64(time.Millisecond))
+	if d <= 0 {
+		r.TPuts(s)
+		return 0
+	}
+	r.buf.WriteString(s[:beg])
+	r.flashend = s[end+1:]
+	return d
+}
+
+// EndFlash adds the rest of the flash sequence started by Flash to the
+// output, if there is one still waiting.
+func (r *Renderer) EndFlash() {
+	if r.flashend != "" {
+		r.TPuts(r.flashend)
+		r.flashend = ""
+	}
+}
+
+// Clear makes the next frame start by clearing the screen, and then draw
+// every cell, whether it is dirty or not.  This is needed whenever what
+// the terminal shows is not known, such as after it has been reset.
+func (r *Renderer) Clear() {
+	r.clear = true
+}
+
+// Reset discards any output that has not been flushed, and forgets what
+// is known of the terminal's state, such as the current style.  This is
+// needed when that output never reaches the terminal.
+func (r *Renderer) Reset() {
+	r.buf.Reset()
+	r.flashend = ""
+	r.curstyle = Style(-1)
+	r.cx = -1
+	r.cy = -1
+}
+
+// Flush sends everything drawn since the last flush to the writer, in a
+// single write.  Drawing a frame can produce many thousands of small
+// strings, and writing each of them separately is very expensive,
+// especially when the terminal is on the far side of a network
+// connection.  If the writer is nil, the output is discarded.
+func (r *Renderer) Flush() error {
+	var e error
+	if r.buf.Len() > 0 && r.out != nil {
+		_, e = r.out.Write(r.buf.Bytes())
+	}
+	r.buf.Reset()
+	return e
+}
+
+// Draw adds a frame showing the dirty cells of the CellBuffer to the
+// output, and marks them clean.  The buffer is assumed to cover the whole
+// screen, starting at the top left corner.
+func (r *Renderer) Draw(cb *CellBuffer) {
+	if r.syncdraw {
+		r.buf.WriteString("\x1b[?2026h")
+	}
+
+	w, h := cb.Size()
+
+	// clobber cursor position, because we're gonna change it all
+	r.cx = -1
+	r.cy = -1
+
+	// hide the cursor while we move stuff around
+	r.hideCursor(w, h)
+
+	if r.clear {
+		r.clearScreen()
+		cb.Invalidate()
+	}
+
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			if n := r.drawRun(cb, x, y, w, h); n > 0 {
+				x += n - 1
+				continue
+			}
+			width := r.drawCell(cb, x, y, w, h)
+			if width > 1 {
+				if x+1 < w {
+					// this is necessary so that if we ever
+					// go back to drawing that cell, we
+					// actually will *draw* it.
+					cb.SetDirty(x+1, y, true)
+				}
+			}
+			x += width - 1
+		}
+	}
+
+	// restore the cursor
+	r.showCursor(w, h)
+
+	if r.syncdraw {
+		r.buf.WriteString("\x1b[?2026l")
+	}
+}
+
+func (r *Renderer) encodeRune(ch rune, buf []byte) []byte {
+
+	nb := make([]byte, 6)
+	ob := make([]byte, 6)
+	num := utf8.EncodeRune(ob, ch)
+	ob = ob[:num]
+	dst := 0
+	var err error
+	if enc := r.encoder; enc != nil {
+		enc.Reset()
+		dst, _, err = enc.Transform(nb, ob, true)
+	}
+	if err != nil || dst == 0 || nb[0] == '\x1a' {
+		// Combining characters are elided
+		if len(buf) == 0 {
+			if acs, ok := r.acs[ch]; ok {
+				buf = append(buf, []byte(acs)...)
+			} else if fb, ok := r.fallback[ch]; ok {
+				buf = append(buf, []byte(fb)...)
+			} else {
+				buf = append(buf, '?')
+			}
+		}
+	} else {
+		buf = append(buf, nb[:dst]...)
+	}
+
+	return buf
+}
+
+func (r *Renderer) sendFgBg(fg Color, bg Color) {
+	ti := r.ti
+	if ti.Colors == 0 {
+		return
+	}
+	if r.truecolor {
+		if ti.SetFgBgRGB != "" &&
+			fg != ColorDefault && bg != ColorDefault {
+			r1, g1, b1 := fg.RGB()
+			r2, g2, b2 := bg.RGB()
+			r.TPuts(ti.TParm(ti.SetFgBgRGB,
+				int(r1), int(g1), int(b1),
+				int(r2), int(g2), int(b2)))
+		} else {
+			if fg != ColorDefault && ti.SetFgRGB != "" {
+				r1, g1, b1 := fg.RGB()
+				r.TPuts(ti.TParm(ti.SetFgRGB,
+					int(r1), int(g1), int(b1)))
+			}
+			if bg != ColorDefault && ti.SetBgRGB != "" {
+				r2, g2, b2 := bg.RGB()
+				r.TPuts(ti.TParm(ti.SetBgRGB,
+					int(r2), int(g2), int(b2)))
+			}
+		}
+		return
+	}
+
+	if fg != ColorDefault {
+		if v, ok := r.colors[fg]; ok {
+			fg = v
+		} else {
+			v = FindColor(fg, r.palette)
+			r.colors[fg] = v
+			fg = v
+		}
+	}
+
+	if bg != ColorDefault {
+		if v, ok := r.colors[bg]; ok {
+			bg = v
+		} else {
+			v = FindColor(bg, r.palette)
+			r.colors[bg] = v
+			bg = v
+		}
+	}
+
+	if ti.SetFgBg != "" && fg != ColorDefault && bg != ColorDefault {
+		r.TPuts(ti.TParm(ti.SetFgBg, int(fg), int(bg)))
+	} else {
+		if fg != ColorDefault && ti.SetFg != "" {
+			r.TPuts(ti.TParm(ti.SetFg, int(fg)))
+		}
+		if bg != ColorDefault && ti.SetBg != "" {
+			r.TPuts(ti.TParm(ti.SetBg, int(bg)))
+		}
+	}
+}
+
+// sgrOnly returns the sequence, if it is an ANSI SGR sequence with only
+// the given parameters, and nothing otherwise.  Plenty of terminals turn
+// off underlining by turning off everything, or go back to "original"
+// colors that are not the default ones; those are no use to sendStyle.
+func sgrOnly(s string, params ...string) string {
+	if !strings.HasPrefix(s, "\x1b[") || !strings.HasSuffix(s, "m") {
+		return ""
+	}
+	for _, p := range strings.Split(s[2:len(s)-1], ";") {
+		found := false
+		for _, q := range params {
+			if p == q {
+				found = true
+			}
+		}
+		if !found {
+			return ""
+		}
+	}
+	return s
+}
+
+// sendStyle makes the style the current one, if it is not already.  Only
+// what has changed is sent, unless something has to be turned off that
+// the terminal cannot turn off by itself, in which case everything is
+// turned off first.
+func (r *Renderer) sendStyle(style Style) {
+	ti := r.ti
+	if style == r.curstyle {
+		return
+	}
+	fg, bg, attrs := style.Decompose()
+	ofg, obg, oattrs := r.curstyle.Decompose()
+	if ti.Colors == 0 {
+		fg, bg, ofg, obg = ColorDefault, ColorDefault, ColorDefault, ColorDefault
+	}
+
+	off := oattrs &^ attrs
+	todefault := (fg == ColorDefault && ofg != ColorDefault) ||
+		(bg == ColorDefault && obg != ColorDefault)
+	switch {
+	case r.curstyle == Style(-1),
+		off != 0 && (off != AttrUnderline || r.exitul == ""),
+		todefault && r.resetfgbg == "":
+		r.TPuts(ti.AttrOff)
+		ofg, obg, oattrs = ColorDefault, ColorDefault, 0
+	default:
+		if off != 0 {
+			r.TPuts(r.exitul)
+			oattrs &^= off
+		}
+		if todefault {
+			r.TPuts(r.resetfgbg)
+			ofg, obg = ColorDefault, ColorDefault
+		}
+	}
+
+	if fg == ofg {
+		fg = ColorDefault
+	}
+	if bg == obg {
+		bg = ColorDefault
+	}
+	r.sendFgBg(fg, bg)
+
+	attrs &^= oattrs
+	if attrs&AttrBold != 0 {
+		r.TPuts(ti.Bold)
+	}
+	if attrs&AttrUnderline != 0 {
+		r.TPuts(ti.Underline)
+	}
+	if attrs&AttrReverse != 0 {
+		r.TPuts(ti.Reverse)
+	}
+	if attrs&AttrBlink != 0 {
+		r.TPuts(ti.Blink)
+	}
+	if attrs&AttrDim != 0 {
+		r.TPuts(ti.Dim)
+	}
+	r.curstyle = style
+}
+
+func (r *Renderer) drawCell(cb *CellBuffer, x, y, w, h int) int {
+
+	mainc, combc, style, width := cb.GetContent(x, y)
+	if !cb.Dirty(x, y) {
+		return width
+	}
+
+	if r.cy != y || r.cx != x {
+		r.goTo(x, y, w, h)
+	}
+
+	if style == StyleDefault {
+		style = r.style
+	}
+	r.sendStyle(style)
+
+	// now emit runes - taking care to not overrun width with a
+	// wide character, and to ensure that we emit exactly one regular
+	// character followed up by any residual combing characters
+
+	if width < 1 {
+		width = 1
+	}
+
+	var str string
+
+	buf := make([]byte, 0, 6)
+
+	buf = r.encodeRune(mainc, buf)
+	for _, ch := range combc {
+		buf = r.encodeRune(ch, buf)
+	}
+
+	str = string(buf)
+	if width > 1 && str == "?" {
+		// No FullWidth character support
+		str = "? "
+	}
+
+	// XXX: check for hazeltine not being able to display ~
+
+	if x > w-width {
+		// too wide to fit; emit a single space instead
+		width = 1
+		str = " "
+	}
+	r.buf.WriteString(str)
+	r.cx += width
+	cb.SetDirty(x, y, false)
+	if width > 1 {
+		// Terminals disagree about how far wide characters
+		// move the cursor, so we no longer know where it is.
+		r.cx = -1
+	}
+
+	return width
+}
+
+func (r *Renderer) showCursor(w, h int) {
+
+	x, y := r.cursorx, r.cursory
+	if x < 0 || y < 0 || x >= w || y >= h {
+		r.hideCursor(w, h)
+		return
+	}
+	if r.cy != y || r.cx != x {
+		r.goTo(x, y, w, h)
+	}
+	r.TPuts(r.ti.ShowCursor)
+}
+
+func (r *Renderer) hideCursor(w, h int) {
+	// does not update cursor position
+	if r.ti.HideCursor != "" {
+		r.TPuts(r.ti.HideCursor)
+	} else {
+		// No way to hide cursor, stick it
+		// at bottom right of screen
+		r.cx, r.cy = w, h
+		r.TPuts(r.ti.TGoto(r.cx, r.cy))
+	}
+}
+
+func (r *Renderer) clearScreen() {
+	fg, bg, _ := r.style.Decompose()
+	r.sendStyle(StyleDefault.Foreground(fg).Background(bg))
+	r.TPuts(r.ti.Clear)
+	r.clear = false
+}
+
+// vtACSNames is a map of bytes defined by terminfo that are used in
+// the terminals Alternate Character Set to represent other glyphs.
+// For example, the upper left corner of the box drawing set can be
+// displayed by printing "l" while in the alternate character set.
+// Its not quite that simple, since the "l" is the terminfo name,
+// and it may be necessary to use a different character based on
+// the terminal implementation (or the terminal may lack support for
+// this altogether).  See buildAcsMap below for detail.
+var vtACSNames = map[byte]rune{
+	'+': RuneRArrow,
+	',': RuneLArrow,
+	'-': RuneUArrow,
+	'.': RuneDArrow,
+	'0': RuneBlock,
+	'`': RuneDiamond,
+	'a': RuneCkBoard,
+	'b': '␉', // VT100, Not defined by terminfo
+	'c': '␌', // VT100, Not defined by terminfo
+	'd': '␋', // VT100, Not defined by terminfo
+	'e': '␊', // VT100, Not defined by terminfo
+	'f': RuneDegree,
+	'g': RunePlMinus,
+	'h': RuneBoard,
+	'i': RuneLantern,
+	'j': RuneLRCorner,
+	'k': RuneURCorner,
+	'l': RuneULCorner,
+	'm': RuneLLCorner,
+	'n': RunePlus,
+	'o': RuneS1,
+	'p': RuneS3,
+	'q': RuneHLine,
+	'r': RuneS7,
+	's': RuneS9,
+	't': RuneLTee,
+	'u': RuneRTee,
+	'v': RuneBTee,
+	'w': RuneTTee,
+	'x': RuneVLine,
+	'y': RuneLEqual,
+	'z': RuneGEqual,
+	'{': RunePi,
+	'|': RuneNEqual,
+	'}': RuneSterling,
+	'~': RuneBullet,
+}
+
+// buildAcsMap builds a map of characters that we translate from Unicode to
+// alternate character encodings.  To do this, we use the standard VT100 ACS
+// maps.  This is only done if the terminal lacks support for Unicode; we
+// always prefer to emit Unicode glyphs when we are able.
+func (r *Renderer) buildAcsMap() {
+	acsstr := r.ti.AltChars
+	r.acs = make(map[rune]string)
+	for len(acsstr) >= 2 {
+		srcv := acsstr[0]
+		dstv := acsstr[1:2]
+		if ch, ok := vtACSNames[srcv]; ok {
+			// Apply any padding now, as these are emitted directly.
+			r.acs[ch] = r.padded(r.ti.EnterAcs + dstv + r.ti.ExitAcs)
+		}
+		acsstr = acsstr[2:]
+	}
+}
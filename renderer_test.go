@@ -0,0 +1,327 @@
+// Copyright 2017 The TCell Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use file except in compliance with the License.
+// You may obtain a copy of the license at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tcell
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// failWriter is a writer that always fails.
+type failWriter struct{}
+
+func (failWriter) Write([]byte) (int, error) {
+	return 0, errors.New("broken")
+}
+
+func TestRenderer(t *testing.T) {
+
+	Convey("Rendering for xterm", t, func() {
+		ti, e := LookupTerminfo("xterm")
+		So(e, ShouldBeNil)
+		out := &bytes.Buffer{}
+		r := NewRenderer(ti, nil, out)
+		cb := &CellBuffer{}
+		cb.Resize(10, 2)
+		cb.SetContent(0, 0, 'A', nil, StyleDefault)
+		cb.SetContent(1, 0, 'B', nil, StyleDefault)
+		r.Draw(cb)
+
+		Convey("Output is only written on Flush", func() {
+			So(out.Len(), ShouldEqual, 0)
+			So(r.Flush(), ShouldBeNil)
+			So(out.String(), ShouldContainSubstring, "AB")
+		})
+
+		Convey("Only dirty cells are drawn", func() {
+			r.Flush()
+			out.Reset()
+			r.Draw(cb)
+			r.Flush()
+			So(out.String(), ShouldEqual, ti.HideCursor+ti.HideCursor)
+
+			out.Reset()
+			cb.SetContent(1, 0, 'C', nil, StyleDefault)
+			r.Draw(cb)
+			r.Flush()
+			So(out.String(), ShouldContainSubstring, ti.TGoto(1, 0)+"C")
+			So(out.String(), ShouldNotContainSubstring, "A")
+		})
+
+		Convey("Clear redraws every cell", func() {
+			r.Flush()
+			out.Reset()
+			r.Clear()
+			r.Draw(cb)
+			r.Flush()
+			So(out.String(), ShouldContainSubstring, ti.Clear)
+			So(out.String(), ShouldContainSubstring, "AB")
+		})
+
+		Convey("Reset discards output", func() {
+			r.Reset()
+			So(r.Flush(), ShouldBeNil)
+			So(out.Len(), ShouldEqual, 0)
+		})
+
+		Convey("The cursor is shown where asked", func() {
+			r.ShowCursor(3, 1)
+			r.Draw(cb)
+			r.Flush()
+			So(out.String(), ShouldEndWith,
+				ti.TGoto(3, 1)+ti.ShowCursor)
+		})
+
+		Convey("Frames can be synchronized updates", func() {
+			r.Flush()
+			out.Reset()
+			r.SetSynchronizedUpdates(true)
+			r.Draw(cb)
+			r.Flush()
+			So(out.String(), ShouldStartWith, "\x1b[?2026h")
+			So(out.String(), ShouldEndWith, "\x1b[?2026l")
+		})
+	})
+
+	Convey("Colors are fitted to the palette", t, func() {
+		ti, e := LookupTerminfo("xterm-256color")
+		So(e, ShouldBeNil)
+		r := NewRenderer(ti, nil, nil)
+		So(r.Colors(), ShouldEqual, 256)
+		r.SetColors(8)
+		So(r.Colors(), ShouldEqual, 8)
+
+		cb := &CellBuffer{}
+		cb.Resize(1, 1)
+		cb.SetContent(0, 0, 'x', nil,
+			StyleDefault.Foreground(NewRGBColor(250, 10, 10)))
+		r.Draw(cb)
+		So(r.buf.String(), ShouldContainSubstring, ti.TParm(ti.SetFg, 1))
+	})
+
+	Convey("24-bit color is used when the terminal has it", t, func() {
+		ti := &Terminfo{
+			Name:     "rgb",
+			Colors:   256,
+			SetFgRGB: "\x1b[38;2;%p1%d;%p2%d;%p3%dm",
+			SetBgRGB: "\x1b[48;2;%p1%d;%p2%d;%p3%dm",
+		}
+		r := NewRenderer(ti, nil, nil)
+		So(r.Colors(), ShouldEqual, 1<<24)
+		r.SetColors(256)
+		So(r.Colors(), ShouldEqual, 256)
+		r.SetColors(0)
+		So(r.Colors(), ShouldEqual, 1<<24)
+	})
+
+	Convey("Characters outside the encoding are replaced", t, func() {
+		ti, e := LookupTerminfo("vt100")
+		So(e, ShouldBeNil)
+		r := NewRenderer(ti, GetEncoding("US-ASCII"), nil)
+		So(r.CanDisplay('a', false), ShouldBeTrue)
+		So(r.CanDisplay(RuneHLine, false), ShouldBeTrue)
+		So(r.CanDisplay('é', false), ShouldBeFalse)
+		So(r.CanDisplay(RuneBullet, false), ShouldBeTrue)
+		So(r.CanDisplay('é', true), ShouldBeFalse)
+		r.RegisterRuneFallback('é', "e")
+		So(r.CanDisplay('é', true), ShouldBeTrue)
+
+		cb := &CellBuffer{}
+		cb.Resize(4, 1)
+		cb.SetContent(0, 0, RuneHLine, nil, StyleDefault)
+		cb.SetContent(1, 0, 'é', nil, StyleDefault)
+		cb.SetContent(2, 0, '世', nil, StyleDefault)
+		r.Draw(cb)
+		So(r.buf.String(), ShouldContainSubstring,
+			ti.EnterAcs+"q"+ti.ExitAcs+"e? ")
+
+		r.UnregisterRuneFallback('é')
+		So(r.CanDisplay('é', true), ShouldBeFalse)
+	})
+
+	Convey("Titles are cleaned up", t, func() {
+		ti, e := LookupTerminfo("xterm")
+		So(e, ShouldBeNil)
+		r := NewRenderer(ti, GetEncoding("US-ASCII"), nil)
+		r.SetTitle("a\x07b\x1b]é")
+		So(r.buf.String(), ShouldEqual, "\x1b]2;ab]?\x07")
+
+		ti, e = LookupTerminfo("vt100")
+		So(e, ShouldBeNil)
+		r = NewRenderer(ti, nil, nil)
+		r.SetTitle("a")
+		So(r.buf.String(), ShouldBeEmpty)
+	})
+
+	Convey("Bells", t, func() {
+		ti, e := LookupTerminfo("xterm")
+		So(e, ShouldBeNil)
+		out := &bytes.Buffer{}
+		r := NewRenderer(ti, nil, out)
+
+		Convey("Beep rings the bell", func() {
+			So(r.Beep(), ShouldEqual, 0)
+			So(r.buf.String(), ShouldEqual, "\a")
+		})
+
+		Convey("Flash stops at the padding", func() {
+			So(r.Flash(), ShouldEqual, 100*time.Millisecond)
+			So(r.buf.String(), ShouldEqual, "\x1b[?5h")
+			r.EndFlash()
+			So(r.buf.String(), ShouldEqual, "\x1b[?5h\x1b[?5l")
+			r.EndFlash()
+			So(r.buf.String(), ShouldEqual, "\x1b[?5h\x1b[?5l")
+		})
+
+		Convey("A second flash ends the first", func() {
+			r.Flash()
+			r.Flash()
+			So(r.buf.String(), ShouldEqual, "\x1b[?5h\x1b[?5l\x1b[?5h")
+		})
+
+		Convey("Each falls back to the other", func() {
+			nti := *ti
+			nti.Flash = ""
+			r := NewRenderer(&nti, nil, out)
+			So(r.Flash(), ShouldEqual, 0)
+			So(r.buf.String(), ShouldEqual, "\a")
+
+			nti = *ti
+			nti.Bell = ""
+			nti.Flash = "\x1b[?5h\x1b[?5l"
+			r = NewRenderer(&nti, nil, out)
+			So(r.Beep(), ShouldEqual, 0)
+			So(r.buf.String(), ShouldEqual, "\x1b[?5h\x1b[?5l")
+		})
+	})
+
+	Convey("Short cursor motions are relative", t, func() {
+		ti, e := LookupTerminfo("xterm")
+		So(e, ShouldBeNil)
+		r := NewRenderer(ti, nil, nil)
+		r.cx, r.cy = 5, 5
+
+		r.goTo(6, 5, 80, 24)
+		So(r.buf.String(), ShouldEqual, ti.CursorForward1)
+
+		r.buf.Reset()
+		r.goTo(0, 5, 80, 24)
+		So(r.buf.String(), ShouldEqual, ti.CarriageReturn)
+
+		r.buf.Reset()
+		r.goTo(0, 1, 80, 24)
+		So(r.buf.String(), ShouldEqual, ti.TParm(ti.CursorUp, 4))
+
+		r.buf.Reset()
+		r.cx, r.cy = 10, 10
+		r.goTo(0, 0, 80, 24)
+		So(r.buf.String(), ShouldEqual, ti.CursorHome)
+
+		r.buf.Reset()
+		r.goTo(40, 12, 80, 24)
+		So(r.buf.String(), ShouldEqual, ti.TGoto(40, 12))
+		So(r.cx, ShouldEqual, 40)
+		So(r.cy, ShouldEqual, 12)
+
+		Convey("But not when the cursor is lost", func() {
+			r.buf.Reset()
+			r.cx = 80
+			r.goTo(41, 12, 80, 24)
+			So(r.buf.String(), ShouldEqual, ti.TGoto(41, 12))
+		})
+	})
+
+	Convey("Runs of identical cells are drawn at once", t, func() {
+		ti, e := LookupTerminfo("xterm")
+		So(e, ShouldBeNil)
+		cb := &CellBuffer{}
+		cb.Resize(30, 1)
+		red := StyleDefault.Background(ColorRed)
+		for x := 0; x < 30; x++ {
+			switch {
+			case x < 8:
+				cb.SetContent(x, 0, 'x', nil, StyleDefault)
+			case x < 20:
+				cb.SetContent(x, 0, ' ', nil, red)
+			case x == 20:
+				cb.SetContent(x, 0, 'y', nil, StyleDefault)
+			default:
+				cb.SetContent(x, 0, ' ', nil, StyleDefault)
+			}
+		}
+
+		r := NewRenderer(ti, nil, nil)
+		r.Draw(cb)
+		So(r.buf.String(), ShouldContainSubstring, "x\x1b[7b")
+		So(r.buf.String(), ShouldContainSubstring,
+			ti.TParm(ti.EraseChars, 12)+ti.TParm(ti.CursorForward, 12))
+		So(r.buf.String(), ShouldContainSubstring, "y"+ti.ClearToEOL)
+
+		Convey("Colored blanks are not erased without bce", func() {
+			nti := *ti
+			nti.BackColorErase = false
+			r := NewRenderer(&nti, nil, nil)
+			cb.Invalidate()
+			r.Draw(cb)
+			So(r.buf.String(), ShouldContainSubstring, " \x1b[11b")
+			So(r.buf.String(), ShouldContainSubstring, "y"+ti.ClearToEOL)
+		})
+	})
+
+	Convey("Only style changes are sent", t, func() {
+		ti, e := LookupTerminfo("xterm")
+		So(e, ShouldBeNil)
+		red := StyleDefault.Foreground(ColorMaroon)
+		cb := &CellBuffer{}
+		cb.Resize(7, 1)
+		cb.SetContent(0, 0, 'a', nil, StyleDefault)
+		cb.SetContent(1, 0, 'b', nil, red)
+		cb.SetContent(2, 0, 'c', nil, red.Underline(true))
+		cb.SetContent(3, 0, 'd', nil, red)
+		cb.SetContent(4, 0, 'e', nil, StyleDefault)
+		cb.SetContent(5, 0, 'f', nil, StyleDefault.Bold(true))
+		cb.SetContent(6, 0, 'g', nil, StyleDefault)
+
+		r := NewRenderer(ti, nil, nil)
+		r.Draw(cb)
+		So(r.buf.String(), ShouldContainSubstring, ti.AttrOff+"a"+
+			ti.TParm(ti.SetFg, 1)+"b"+ti.Underline+"c"+
+			ti.ExitUnderline+"d"+ti.ResetFgBg+"e"+
+			ti.Bold+"f"+ti.AttrOff+"g")
+
+		Convey("Sequences that turn off everything are not used", func() {
+			So(sgrOnly("\x1b[24m", "24"), ShouldEqual, "\x1b[24m")
+			So(sgrOnly("\x1b[39;49m", "39", "49"), ShouldEqual,
+				"\x1b[39;49m")
+			So(sgrOnly("\x1b[m", "24"), ShouldEqual, "")
+			So(sgrOnly("\x1b[0m", "39", "49"), ShouldEqual, "")
+			So(sgrOnly("\x1b[37;40m", "39", "49"), ShouldEqual, "")
+			So(sgrOnly("\x1bG0", "24"), ShouldEqual, "")
+		})
+	})
+
+	Convey("Write failures are reported", t, func() {
+		ti, e := LookupTerminfo("xterm")
+		So(e, ShouldBeNil)
+		r := NewRenderer(ti, nil, failWriter{})
+		r.TPuts(ti.Clear)
+		So(r.Flush(), ShouldNotBeNil)
+		So(r.Flush(), ShouldBeNil)
+	})
+}
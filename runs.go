@@ -0,0 +1,121 @@
+// Copyright 2017 The TCell Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use file except in compliance with the License.
+// You may obtain a copy of the license at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tcell
+
+// drawRun draws the run of identical cells starting at x in one go, if the
+// terminal can do that more cheaply than drawing each of them: blanks that
+// reach the end of the line are cleared with el, other blanks are erased
+// with ech, and anything else plain enough is repeated with rep.  It
+// returns the number of cells drawn, or zero if the cell at x should be
+// drawn by itself.
+func (r *Renderer) drawRun(cb *CellBuffer, x, y, w, h int) int {
+	if !cb.Dirty(x, y) {
+		return 0
+	}
+	mainc, combc, style, width := cb.GetContent(x, y)
+	if len(combc) != 0 || width != 1 {
+		return 0
+	}
+	n := 1
+	for x+n < w {
+		mc, cc, st, wd := cb.GetContent(x+n, y)
+		if mc != mainc || len(cc) != 0 || st != style || wd != 1 {
+			break
+		}
+		n++
+	}
+	if n < 2 {
+		return 0
+	}
+	if style == StyleDefault {
+		style = r.style
+	}
+
+	s, move := r.eraseRun(mainc, style, n, x+n == w)
+	if s == "" {
+		s, move = r.repeatRun(mainc, n), true
+	}
+	if s == "" {
+		return 0
+	}
+
+	if r.cy != y || r.cx != x {
+		r.goTo(x, y, w, h)
+	}
+	r.sendStyle(style)
+	r.buf.WriteString(s)
+	if move {
+		r.cx += n
+	}
+	for i := 0; i < n; i++ {
+		cb.SetDirty(x+i, y, false)
+	}
+	return n
+}
+
+// eraseRun returns the cheapest way of erasing n blanks, which may run to
+// the end of the line, if that is cheaper than writing them, and whether it
+// leaves the cursor after them.  Erased cells have no attributes, and the
+// current background color on a terminal with bce, or the default one
+// otherwise; blanks that look any different cannot be erased.
+func (r *Renderer) eraseRun(ch rune, style Style, n int, eol bool) (string, bool) {
+	ti := r.ti
+	if ch != ' ' {
+		return "", false
+	}
+	fg, bg, attrs := style.Decompose()
+	if fg != ColorDefault || attrs != 0 {
+		return "", false
+	}
+	if bg != ColorDefault && !ti.BackColorErase {
+		return "", false
+	}
+
+	best, moved := "", false
+	if eol && ti.ClearToEOL != "" {
+		best = r.padded(ti.ClearToEOL)
+	}
+	if ti.EraseChars != "" {
+		s := r.padded(ti.TParm(ti.EraseChars, n))
+		mv, ok := "", true
+		if !eol {
+			// Erasing does not move the cursor, but the
+			// next cell to be drawn is past the run.
+			mv, ok = r.horizontal(0, n)
+		}
+		if ok && (best == "" || len(s)+len(mv) < len(best)) {
+			best, moved = s+mv, !eol
+		}
+	}
+	if best == "" || len(best) >= n {
+		return "", false
+	}
+	return best, moved
+}
+
+// repeatRun returns a rep sequence to draw the character n times, if that
+// is cheaper than writing them.  Only printable ASCII is repeated, as other
+// characters may need translating.
+func (r *Renderer) repeatRun(ch rune, n int) string {
+	ti := r.ti
+	if ti.RepeatChar == "" || ch < ' ' || ch > '~' {
+		return ""
+	}
+	s := r.padded(ti.TParm(ti.RepeatChar, int(ch), n))
+	if len(s) >= n {
+		return ""
+	}
+	return s
+}
@@ -104,6 +104,45 @@ type Screen interface {
 	// DisableMouse disables the mouse.
 	DisableMouse()
 
+	// EnablePaste enables bracketed paste, if the terminal supports it.
+	// Text pasted into the terminal is then delivered as an EventPaste,
+	// rather than as a key event for each character.
+	EnablePaste()
+
+	// DisablePaste disables bracketed paste.
+	DisablePaste()
+
+	// EnableFocus enables focus reporting, if the terminal supports it.
+	// An EventFocus is then delivered whenever the terminal window gains
+	// or loses the focus.
+	EnableFocus()
+
+	// DisableFocus disables focus reporting.
+	DisableFocus()
+
+	// SetClipboard puts the data on the clipboard of the user's terminal,
+	// which may be on another machine, if the terminal allows that.
+	SetClipboard(data []byte)
+
+	// SetTitle sets the title of the terminal window, if the terminal
+	// has one.  The title that was there before is put back by Fini, on
+	// terminals that can do that.
+	SetTitle(title string)
+
+	// Beep rings the terminal's bell.  It is sent between frames, and at
+	// once, rather than with the next one.  Terminals without a bell
+	// flash the screen instead.
+	Beep()
+
+	// Flash flashes the screen, as a visual bell.  Terminals that cannot
+	// flash ring the bell instead.
+	Flash()
+
+	// GetClipboard asks the terminal for the contents of its clipboard,
+	// which arrive later as an EventClipboard.  Many terminals refuse to
+	// send them, in which case nothing arrives at all.
+	GetClipboard()
+
 	// HasMouse returns true if the terminal (apparently) supports a
 	// mouse.  Note that the a return value of true doesn't guarantee that
 	// a mouse/pointing device is present; a false return definitely
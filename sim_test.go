@@ -138,6 +138,8 @@ func TestResize(t *testing.T) {
 			So(b2, ShouldNotEqual, b)
 			So(x2, ShouldEqual, 30)
 			So(y2, ShouldEqual, 10)
+			So(len(b2), ShouldEqual, x2*y2)
+			So(b2[5*30+2].Runes, ShouldResemble, []rune{'&'})
 
 			sc2 := &b[5*80+2]
 			So(len(sc2.Runes), ShouldEqual, 1)
@@ -148,3 +150,118 @@ func TestResize(t *testing.T) {
 		})
 	}))
 }
+
+func TestFiniScreen(t *testing.T) {
+	Convey("PollEvent returns nil after Fini", t, func() {
+		s := NewSimulationScreen("")
+		So(s.Init(), ShouldBeNil)
+		s.Fini()
+		So(s.PollEvent(), ShouldBeNil)
+	})
+}
+
+// injected returns the events that injecting the given bytes produces,
+// described as text for easy comparison.
+func injected(s SimulationScreen, b string) ([]string, bool) {
+	ok := s.InjectKeyBytes([]byte(b))
+	s.PostEvent(NewEventInterrupt(nil))
+	var evs []string
+	for {
+		switch ev := s.PollEvent().(type) {
+		case *EventInterrupt:
+			return evs, ok
+		default:
+			evs = append(evs, describeEvent(ev))
+		}
+	}
+}
+
+func TestInjectKeyBytes(t *testing.T) {
+
+	Convey("Plain simulation screens", t, WithScreen(t, "", func(s SimulationScreen) {
+
+		Convey("Text and control keys are delivered", func() {
+			evs, ok := injected(s, "a\x01é")
+			So(ok, ShouldBeTrue)
+			So(evs, ShouldResemble, []string{"Rune[a]", "Ctrl+A", "Rune[é]"})
+		})
+
+		Convey("Injected keys keep their modifiers", func() {
+			s.InjectKey(KeyF1, 0, ModShift)
+			ev := s.PollEvent().(*EventKey)
+			So(ev.Key(), ShouldEqual, KeyF1)
+			So(ev.Modifiers(), ShouldEqual, ModShift)
+		})
+
+		Convey("The clipboard can be read back", func() {
+			s.SetClipboard([]byte("copied"))
+			s.GetClipboard()
+			ev := s.PollEvent().(*EventClipboard)
+			So(string(ev.Data()), ShouldEqual, "copied")
+		})
+
+		Convey("Bells are counted", func() {
+			s.Beep()
+			s.Beep()
+			s.Flash()
+			beeps, flashes := s.GetBells()
+			So(beeps, ShouldEqual, 2)
+			So(flashes, ShouldEqual, 1)
+		})
+
+		Convey("The title is kept", func() {
+			So(s.GetTitle(), ShouldEqual, "")
+			s.SetTitle("title")
+			So(s.GetTitle(), ShouldEqual, "title")
+		})
+
+		Convey("Injected focus changes are delivered", func() {
+			s.InjectFocus(false)
+			s.InjectFocus(true)
+			So(s.PollEvent().(*EventFocus).Focused(), ShouldBeFalse)
+			So(s.PollEvent().(*EventFocus).Focused(), ShouldBeTrue)
+		})
+	}))
+
+	Convey("Terminfo simulation screens", t, func() {
+		s, e := NewTerminfoSimulationScreen("", "xterm")
+		So(e, ShouldBeNil)
+		So(s.Init(), ShouldBeNil)
+		defer s.Fini()
+
+		So(s.HasMouse(), ShouldBeTrue)
+		So(s.HasKey(KeyF1), ShouldBeTrue)
+		So(s.HasKey(KeyF64), ShouldBeFalse)
+
+		for _, c := range []struct {
+			in  string
+			out []string
+		}{
+			{"\x1b[A\x1bOP", []string{"Up", "F1"}},
+			{"\x1b[1;2C", []string{"Shift+Right"}},
+			{"\x1bx", []string{"Alt+Rune[x]"}},
+			{"a\x1b", []string{"Rune[a]", "Esc"}},
+			{"\r\x7f", []string{"Enter", "Backspace2"}},
+			{"\x1b[<0;5;10M\x1b[<0;5;10m",
+				[]string{"Mouse(4,9,1,0)", "Mouse(4,9,0,0)"}},
+			{"\x1b[M !!", []string{"Mouse(0,0,1,0)"}},
+			{"\x1b[O\x1b[I", []string{"Focus(false)", "Focus(true)"}},
+			{"日本", []string{"Rune[日]", "Rune[本]"}},
+		} {
+			evs, ok := injected(s, c.in)
+			So(ok, ShouldBeTrue)
+			So(evs, ShouldResemble, c.out)
+		}
+
+		// Bytes that cannot be decoded are delivered as they
+		// are, but reported.
+		evs, ok := injected(s, "\xffa")
+		So(ok, ShouldBeFalse)
+		So(evs, ShouldResemble, []string{"Rune[ÿ]", "Rune[a]"})
+		_, ok = injected(s, "a")
+		So(ok, ShouldBeTrue)
+
+		_, e = NewTerminfoSimulationScreen("", "no-such-terminal")
+		So(e, ShouldNotBeNil)
+	})
+}
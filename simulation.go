@@ -31,6 +31,22 @@ func NewSimulationScreen(charset string) SimulationScreen {
 	return s
 }
 
+// NewTerminfoSimulationScreen returns a SimulationScreen that understands
+// the input of the named terminal.  Bytes given to InjectKeyBytes are
+// decoded by the same parser that real terminfo screens use, so that
+// escape sequences for function keys, Alt-prefixed keys and mouse reports
+// are turned into the events that the terminal would produce.
+func NewTerminfoSimulationScreen(charset, term string) (SimulationScreen, error) {
+	ti, e := LookupTerminfo(term)
+	if e != nil {
+		return nil, e
+	}
+	if charset == "" {
+		charset = "UTF-8"
+	}
+	return &simscreen{charset: charset, ti: ti}, nil
+}
+
 // SimulationScreen represents a screen simulation.  This is intended to
 // be a superset of normal Screens, but also adds some important interfaces
 // for testing.
@@ -40,6 +56,14 @@ type SimulationScreen interface {
 	// set of bytes were processed and delivered as KeyEvents, false
 	// if any bytes were not fully understood.  Any bytes that are not
 	// fully converted are discarded.
+	//
+	// If the screen was made for a particular terminal, the bytes are
+	// decoded just as a real screen for that terminal would, and may
+	// produce mouse events too.  Each call is taken to hold complete
+	// input; a partial escape sequence at the end is delivered as it
+	// stands, as a real screen would once the escape delay has passed.
+	// That counts as understood, but bytes that cannot be decoded in
+	// the character set do not.
 	InjectKeyBytes(buf []byte) bool
 
 	// InjectKey injects a key event.  The rune is a UTF-8 rune, post
@@ -49,6 +73,10 @@ type SimulationScreen interface {
 	// InjectMouse injects a mouse event.
 	InjectMouse(x, y int, buttons ButtonMask, mod ModMask)
 
+	// InjectFocus injects a focus event, as if the window had gained the
+	// focus if focused is true, or lost it otherwise.
+	InjectFocus(focused bool)
+
 	// SetSize resizes the underlying physical screen.  It also causes
 	// a resize event to be injected during the next Show() or Sync().
 	// A new physical contents array will be allocated (with data from
@@ -65,6 +93,15 @@ type SimulationScreen interface {
 	// GetCursor returns the cursor details.
 	GetCursor() (x int, y int, visible bool)
 
+	// GetBells returns the number of times that Beep and Flash have been
+	// called.
+	GetBells() (beeps int, flashes int)
+
+	// GetTitle returns the window title, as last set with SetTitle.  It
+	// is empty again after Fini, which puts back the title that was
+	// there before.
+	GetTitle() string
+
 	Screen
 }
 
@@ -97,21 +134,34 @@ type simscreen struct {
 	cursory   int
 	cursorvis bool
 	mouse     bool
+	paste     bool
+	focus     bool
+	clipboard []byte
+	title     string
+	beeps     int
+	flashes   int
 	charset   string
 	encoder   transform.Transformer
 	decoder   transform.Transformer
 	fillchar  rune
 	fillstyle Style
 	fallback  map[rune]string
+	ti        *Terminfo
+	input     *InputDecoder
 
 	sync.Mutex
 }
 
 func (s *simscreen) Init() error {
 	s.evch = make(chan Event, 10)
+	s.quit = make(chan struct{})
 	s.fillchar = 'X'
 	s.fillstyle = StyleDefault
 	s.mouse = false
+	s.paste = false
+	s.focus = false
+	s.beeps = 0
+	s.flashes = 0
 	s.physw = 80
 	s.physh = 25
 	s.cursorx = -1
@@ -121,6 +171,12 @@ func (s *simscreen) Init() error {
 	if enc := GetEncoding(s.charset); enc != nil {
 		s.encoder = enc.NewEncoder()
 		s.decoder = enc.NewDecoder()
+		if s.ti != nil {
+			s.input = NewInputDecoder(s.ti, enc, func(ev Event) {
+				s.PostEvent(ev)
+			})
+			s.input.SetSize(s.physw, s.physh)
+		}
 	} else {
 		return ErrNoCharset
 	}
@@ -140,6 +196,7 @@ func (s *simscreen) Fini() {
 	s.Lock()
 	s.fini = true
 	s.back.Resize(0, 0)
+	s.title = ""
 	s.Unlock()
 	if s.quit != nil {
 		close(s.quit)
@@ -320,6 +377,72 @@ func (s *simscreen) DisableMouse() {
 	s.mouse = false
 }
 
+func (s *simscreen) EnablePaste() {
+	s.paste = true
+}
+
+func (s *simscreen) DisablePaste() {
+	s.paste = false
+}
+
+func (s *simscreen) EnableFocus() {
+	s.focus = true
+}
+
+func (s *simscreen) DisableFocus() {
+	s.focus = false
+}
+
+// SetClipboard keeps the data, so that GetClipboard can send it back, as
+// if the simulated terminal had a clipboard that could be read.
+func (s *simscreen) SetClipboard(data []byte) {
+	s.Lock()
+	// Like a real screen, one for a terminal without OSC 52 ignores it.
+	if s.ti == nil || s.ti.SetClipboard != "" {
+		s.clipboard = append([]byte(nil), data...)
+	}
+	s.Unlock()
+}
+
+func (s *simscreen) GetClipboard() {
+	s.Lock()
+	data := s.clipboard
+	s.Unlock()
+	s.PostEvent(NewEventClipboard(data))
+}
+
+func (s *simscreen) Beep() {
+	s.Lock()
+	s.beeps++
+	s.Unlock()
+}
+
+func (s *simscreen) Flash() {
+	s.Lock()
+	s.flashes++
+	s.Unlock()
+}
+
+func (s *simscreen) GetBells() (int, int) {
+	s.Lock()
+	beeps, flashes := s.beeps, s.flashes
+	s.Unlock()
+	return beeps, flashes
+}
+
+func (s *simscreen) SetTitle(title string) {
+	s.Lock()
+	s.title = title
+	s.Unlock()
+}
+
+func (s *simscreen) GetTitle() string {
+	s.Lock()
+	title := s.title
+	s.Unlock()
+	return title
+}
+
 func (s *simscreen) Size() (int, int) {
 	s.Lock()
 	w, h := s.back.Size()
@@ -368,12 +491,24 @@ func (s *simscreen) InjectMouse(x, y int, buttons ButtonMask, mod ModMask) {
 	s.PostEvent(ev)
 }
 
+func (s *simscreen) InjectFocus(focused bool) {
+	ev := NewEventFocus(focused)
+	s.PostEvent(ev)
+}
+
 func (s *simscreen) InjectKey(key Key, r rune, mod ModMask) {
-	ev := NewEventKey(KeyRune, r, ModNone)
+	ev := NewEventKey(key, r, mod)
 	s.PostEvent(ev)
 }
 
 func (s *simscreen) InjectKeyBytes(b []byte) bool {
+	if s.input != nil {
+		s.input.understood()
+		s.input.Write(b)
+		s.input.Flush()
+		return s.input.understood()
+	}
+
 	failed := false
 
 outer:
@@ -394,13 +529,17 @@ outer:
 			}
 			ev := NewEventKey(Key(b[0]), 0, mod)
 			s.PostEvent(ev)
+			b = b[1:]
 			continue
 		}
 
 		utfb := make([]byte, len(b)*4) // worst case
-		for l := 1; l < len(b); l++ {
+		for l := 1; l <= len(b); l++ {
 			s.decoder.Reset()
-			nout, nin, _ := s.decoder.Transform(utfb, b[:l], true)
+			nout, nin, e := s.decoder.Transform(utfb, b[:l], true)
+			if e == transform.ErrShortSrc {
+				continue
+			}
 
 			if nout != 0 {
 				r, _ := utf8.DecodeRune(utfb[:nout])
@@ -441,8 +580,12 @@ func (s *simscreen) SetSize(w, h int) {
 			newc[(row*w)+col] = s.front[(row*s.physw)+col]
 		}
 	}
+	s.front = newc
 	s.physw = w
 	s.physh = h
+	if s.input != nil {
+		s.input.SetSize(w, h)
+	}
 	s.Unlock()
 }
 
@@ -495,11 +638,17 @@ func (s *simscreen) CanDisplay(r rune, checkFallbacks bool) bool {
 }
 
 func (s *simscreen) HasMouse() bool {
+	if s.ti != nil {
+		return s.ti.Mouse != ""
+	}
 	return false
 }
 
 func (s *simscreen) Resize(int, int, int, int) {}
 
-func (s *simscreen) HasKey(Key) bool {
+func (s *simscreen) HasKey(k Key) bool {
+	if s.input != nil {
+		return s.input.HasKey(k)
+	}
 	return true
 }
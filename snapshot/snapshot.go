@@ -0,0 +1,275 @@
+// Copyright 2017 The TCell Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use file except in compliance with the License.
+// You may obtain a copy of the license at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package snapshot turns the contents of a SimulationScreen into text, for
+// comparing against "golden" files in tests.
+//
+// A snapshot looks like this:
+//
+//	20x3 cursor 4,1
+//	+--------------------+
+//	|Hello, world        |
+//	|[ OK ]              |
+//	|                    |
+//	+--------------------+
+//	|AAAAAA..............|
+//	|BBBBBB..............|
+//	|....................|
+//	+--------------------+
+//	A fg=yellow bg=navy bold
+//	B reverse
+//
+// The first line gives the size of the screen and the position of the
+// cursor, or "cursor hidden".  The first box holds the characters on the
+// screen; wide characters take up two columns, just as they do on the
+// screen.  The second box has a key for the style of each cell, with "."
+// standing for the default style, and the keys are described below it.
+// Keys are given out in the order that styles first appear.
+//
+// Check compares a snapshot with a file in the testdata directory, and
+// reports any differences.  Setting the TCELL_UPDATE_GOLDEN environment
+// variable (or Update) makes it write the file instead.
+package snapshot
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mattn/go-runewidth"
+
+	"github.com/thyth/tcell"
+)
+
+// Source is anything that has simulated screen contents, such as a
+// tcell.SimulationScreen or an asciicast.Player.
+type Source interface {
+	GetContents() ([]tcell.SimCell, int, int)
+	GetCursor() (int, int, bool)
+}
+
+// Update makes Check write golden files rather than compare against them.
+var Update = os.Getenv("TCELL_UPDATE_GOLDEN") != ""
+
+// styleKeys are the keys given to styles, in order.  Once they run out,
+// "#" is used for all further styles.
+const styleKeys = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789"
+
+// Take returns a snapshot of the contents of the screen.
+func Take(src Source) string {
+	cells, w, h := src.GetContents()
+	x, y, visible := src.GetCursor()
+	return Format(cells, w, h, x, y, visible)
+}
+
+// Format returns a snapshot of the given cells, which are laid out as
+// returned by GetContents, and cursor.
+func Format(cells []tcell.SimCell, w, h int, cx, cy int, visible bool) string {
+	buf := &bytes.Buffer{}
+	if visible {
+		fmt.Fprintf(buf, "%dx%d cursor %d,%d\n", w, h, cx, cy)
+	} else {
+		fmt.Fprintf(buf, "%dx%d cursor hidden\n", w, h)
+	}
+	border := "+" + strings.Repeat("-", w) + "+\n"
+
+	// The cell following a wide character is not drawn, so it shows the
+	// style of the wide character rather than whatever it held before.
+	rows := make([][]tcell.Style, h)
+	buf.WriteString(border)
+	for y := 0; y < h; y++ {
+		buf.WriteByte('|')
+		for x := 0; x < w; x++ {
+			c := &cells[y*w+x]
+			rows[y] = append(rows[y], c.Style)
+			if len(c.Runes) == 0 || c.Runes[0] < ' ' {
+				buf.WriteByte(' ')
+				continue
+			}
+			for _, r := range c.Runes {
+				buf.WriteRune(r)
+			}
+			if runewidth.RuneWidth(c.Runes[0]) == 2 && x+1 < w {
+				rows[y] = append(rows[y], c.Style)
+				x++
+			}
+		}
+		buf.WriteString("|\n")
+	}
+
+	keys := make(map[tcell.Style]byte)
+	var styles []tcell.Style
+	buf.WriteString(border)
+	for _, row := range rows {
+		buf.WriteByte('|')
+		for _, st := range row {
+			if st == tcell.StyleDefault {
+				buf.WriteByte('.')
+				continue
+			}
+			k, ok := keys[st]
+			if !ok {
+				k = '#'
+				if len(styles) < len(styleKeys) {
+					k = styleKeys[len(styles)]
+				}
+				keys[st] = k
+				styles = append(styles, st)
+			}
+			buf.WriteByte(k)
+		}
+		buf.WriteString("|\n")
+	}
+	buf.WriteString(border)
+
+	for _, st := range styles {
+		fmt.Fprintf(buf, "%c %s\n", keys[st], describe(st))
+	}
+	return buf.String()
+}
+
+// colorNames maps colors back to their names, picking the shortest name
+// (and then the first alphabetically) when a color has several.
+var colorNames = make(map[tcell.Color]string)
+
+func init() {
+	for name, c := range tcell.ColorNames {
+		if old, ok := colorNames[c]; ok {
+			if len(old) < len(name) ||
+				(len(old) == len(name) && old < name) {
+				continue
+			}
+		}
+		colorNames[c] = name
+	}
+}
+
+func colorName(c tcell.Color) string {
+	if name, ok := colorNames[c]; ok {
+		return name
+	}
+	if c&tcell.ColorIsRGB != 0 {
+		return fmt.Sprintf("#%06x", c.Hex())
+	}
+	return fmt.Sprintf("color%d", c)
+}
+
+// describe returns a description of a style, such as "fg=red bold".
+func describe(st tcell.Style) string {
+	fg, bg, attrs := st.Decompose()
+	var words []string
+	if fg != tcell.ColorDefault {
+		words = append(words, "fg="+colorName(fg))
+	}
+	if bg != tcell.ColorDefault {
+		words = append(words, "bg="+colorName(bg))
+	}
+	for _, a := range []struct {
+		attr tcell.AttrMask
+		name string
+	}{
+		{tcell.AttrBold, "bold"},
+		{tcell.AttrBlink, "blink"},
+		{tcell.AttrReverse, "reverse"},
+		{tcell.AttrUnderline, "underline"},
+		{tcell.AttrDim, "dim"},
+	} {
+		if attrs&a.attr != 0 {
+			words = append(words, a.name)
+		}
+	}
+	if len(words) == 0 {
+		return "default"
+	}
+	return strings.Join(words, " ")
+}
+
+// Diff compares two snapshots line by line.  It returns an empty string
+// if they are the same, and otherwise all of the lines of both, marked
+// with "-" for those only in want and "+" for those only in got.
+func Diff(want, got string) string {
+	if want == got {
+		return ""
+	}
+	a := strings.Split(want, "\n")
+	b := strings.Split(got, "\n")
+
+	// lcs[i][j] is the length of the longest common subsequence of
+	// a[i:] and b[j:].
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	buf := &bytes.Buffer{}
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			fmt.Fprintf(buf, "  %s\n", a[i])
+			i++
+			j++
+		case j == len(b) || (i < len(a) && lcs[i+1][j] >= lcs[i][j+1]):
+			fmt.Fprintf(buf, "- %s\n", a[i])
+			i++
+		default:
+			fmt.Fprintf(buf, "+ %s\n", b[j])
+			j++
+		}
+	}
+	return buf.String()
+}
+
+// Check compares a snapshot of the screen with the golden file
+// testdata/<name>.golden, and fails the test if they differ.  In update
+// mode, the golden file is written instead.
+func Check(t testing.TB, name string, src Source) {
+	t.Helper()
+	got := Take(src)
+	path := filepath.Join("testdata", name+".golden")
+	if Update {
+		if e := os.MkdirAll("testdata", 0755); e != nil {
+			t.Fatalf("snapshot %s: %v", name, e)
+		}
+		if e := ioutil.WriteFile(path, []byte(got), 0644); e != nil {
+			t.Fatalf("snapshot %s: %v", name, e)
+		}
+		return
+	}
+	want, e := ioutil.ReadFile(path)
+	if e != nil {
+		t.Fatalf("snapshot %s: %v (set TCELL_UPDATE_GOLDEN=1 to create it)",
+			name, e)
+		return
+	}
+	if d := Diff(string(want), got); d != "" {
+		t.Errorf("snapshot %s differs from %s "+
+			"(set TCELL_UPDATE_GOLDEN=1 to update it):\n%s", name, path, d)
+	}
+}
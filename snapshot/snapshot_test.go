@@ -0,0 +1,166 @@
+// Copyright 2017 The TCell Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use file except in compliance with the License.
+// You may obtain a copy of the license at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snapshot
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/thyth/tcell"
+)
+
+// recorder is a testing.TB that records failures instead of reporting
+// them.
+type recorder struct {
+	testing.TB
+	failures []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...interface{}) {
+	r.failures = append(r.failures, fmt.Sprintf(format, args...))
+}
+
+func (r *recorder) Fatalf(format string, args ...interface{}) {
+	r.failures = append(r.failures, fmt.Sprintf(format, args...))
+}
+
+func newScreen() tcell.SimulationScreen {
+	s := tcell.NewSimulationScreen("UTF-8")
+	s.Init()
+	s.SetSize(12, 3)
+	s.Show()
+	st := tcell.StyleDefault.Foreground(tcell.ColorYellow).
+		Background(tcell.ColorNavy).Bold(true)
+	for i, r := range "Hello" {
+		s.SetContent(1+i, 0, r, nil, st)
+	}
+	s.SetContent(1, 1, '世', nil, tcell.StyleDefault.Reverse(true))
+	s.SetContent(3, 1, 'e', []rune{'\u0301'}, tcell.StyleDefault)
+	s.SetContent(0, 2, '!', nil,
+		tcell.StyleDefault.Foreground(tcell.NewRGBColor(1, 2, 3)).
+			Background(tcell.Color(100)).Underline(true).Dim(true))
+	s.ShowCursor(4, 1)
+	s.Show()
+	return s
+}
+
+func TestFormat(t *testing.T) {
+
+	Convey("Snapshots show text, styles and cursor", t, func() {
+		s := newScreen()
+		defer s.Fini()
+
+		So(Take(s), ShouldEqual, "12x3 cursor 4,1\n"+
+			"+------------+\n"+
+			"| Hello      |\n"+
+			"| 世e\u0301        |\n"+
+			"|!           |\n"+
+			"+------------+\n"+
+			"|.AAAAA......|\n"+
+			"|.BB.........|\n"+
+			"|C...........|\n"+
+			"+------------+\n"+
+			"A fg=yellow bg=navy bold\n"+
+			"B reverse\n"+
+			"C fg=#010203 bg=color100 underline dim\n")
+
+		s.HideCursor()
+		s.Show()
+		So(Take(s), ShouldStartWith, "12x3 cursor hidden\n")
+	})
+
+	Convey("Styles beyond the keys available share a key", t, func() {
+		var cells []tcell.SimCell
+		for i := 0; i < len(styleKeys)+2; i++ {
+			cells = append(cells, tcell.SimCell{
+				Runes: []rune{'x'},
+				Style: tcell.StyleDefault.Background(tcell.Color(i)),
+			})
+		}
+		snap := Format(cells, len(cells), 1, 0, 0, false)
+		So(snap, ShouldContainSubstring, "|"+styleKeys+"##|")
+		So(snap, ShouldContainSubstring, "# bg=color62\n# bg=color63\n")
+	})
+}
+
+func TestDiff(t *testing.T) {
+
+	Convey("Identical snapshots have no differences", t, func() {
+		So(Diff("a\nb\n", "a\nb\n"), ShouldEqual, "")
+	})
+
+	Convey("Changed lines are marked", t, func() {
+		So(Diff("a\nb\nc", "a\nx\nc\nd"), ShouldEqual,
+			"  a\n- b\n+ x\n  c\n+ d\n")
+	})
+}
+
+func TestCheck(t *testing.T) {
+
+	Convey("Matching the golden file passes", t, func() {
+		s := newScreen()
+		defer s.Fini()
+		r := &recorder{TB: t}
+		Check(r, "screen", s)
+		So(r.failures, ShouldBeEmpty)
+	})
+
+	Convey("Differences from the golden file fail", t, func() {
+		s := newScreen()
+		defer s.Fini()
+		s.SetContent(1, 0, 'J', nil, tcell.StyleDefault)
+		s.Show()
+		r := &recorder{TB: t}
+		Check(r, "screen", s)
+		So(len(r.failures), ShouldEqual, 1)
+		So(r.failures[0], ShouldContainSubstring, "- | Hello      |\n")
+		So(r.failures[0], ShouldContainSubstring, "+ | Jello      |\n")
+	})
+
+	Convey("A missing golden file fails", t, func() {
+		s := newScreen()
+		defer s.Fini()
+		r := &recorder{TB: t}
+		Check(r, "missing", s)
+		So(len(r.failures), ShouldBeGreaterThan, 0)
+		So(r.failures[0], ShouldContainSubstring, "TCELL_UPDATE_GOLDEN")
+	})
+
+	Convey("Update mode writes golden files", t, func() {
+		dir, e := ioutil.TempDir("", "snapshot")
+		So(e, ShouldBeNil)
+		defer os.RemoveAll(dir)
+		wd, e := os.Getwd()
+		So(e, ShouldBeNil)
+		So(os.Chdir(dir), ShouldBeNil)
+		defer os.Chdir(wd)
+		Update = true
+		defer func() { Update = false }()
+
+		s := newScreen()
+		defer s.Fini()
+		r := &recorder{TB: t}
+		Check(r, "new", s)
+		So(r.failures, ShouldBeEmpty)
+		b, e := ioutil.ReadFile("testdata/new.golden")
+		So(e, ShouldBeNil)
+		So(string(b), ShouldEqual, Take(s))
+	})
+}
@@ -0,0 +1,314 @@
+// Copyright 2017 The TCell Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use file except in compliance with the License.
+// You may obtain a copy of the license at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package sshserve hosts tcell applications behind an SSH server.
+//
+// Each interactive session (one that requests a pseudo-terminal and a shell)
+// gets its own quasi screen, built from the terminal type, window size and
+// locale reported by the client.  Window size changes made by the client are
+// delivered to the screen, so the application sees them as ordinary
+// EventResize events.
+package sshserve
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"strings"
+	"sync"
+
+	"github.com/thyth/tcell"
+	"golang.org/x/crypto/ssh"
+)
+
+// Handler runs an application on the screen for a single session.  The
+// screen has already been initialized, and will be finalized after the
+// handler returns, at which point the session is closed.  The handler
+// should return once PollEvent returns nil, which happens when the client
+// goes away.
+type Handler func(s tcell.Screen, sess *Session)
+
+// Server accepts SSH connections, and runs the Handler for each
+// interactive session.
+type Server struct {
+	// Config is the SSH server configuration, including host keys and
+	// authentication callbacks.  It must be set.
+	Config *ssh.ServerConfig
+
+	// Handler is run for each interactive session.  It must be set.
+	Handler Handler
+
+	// Options is the template used to create each session's screen.
+	// The terminal type, window size, character set and color hint are
+	// filled in from what the client reports.  The character set and
+	// color hint from the template are only used if the client does not
+	// supply them.
+	Options tcell.QuasiScreenOptions
+}
+
+// Session describes a single interactive session.
+type Session struct {
+	// Conn is the SSH connection that the session belongs to.  It
+	// carries the user name and the client's address.
+	Conn *ssh.ServerConn
+
+	// Term is the terminal type requested by the client.
+	Term string
+
+	// Env holds the environment variables sent by the client, in the
+	// usual "NAME=value" form.
+	Env []string
+
+	ch     ssh.Channel
+	w      int
+	h      int
+	pty    bool
+	shell  bool
+	screen tcell.Screen
+	sync.Mutex
+}
+
+// Getenv returns the value of an environment variable sent by the client,
+// or the empty string if the client did not send it.
+func (sess *Session) Getenv(name string) string {
+	sess.Lock()
+	defer sess.Unlock()
+	for _, kv := range sess.Env {
+		if strings.HasPrefix(kv, name+"=") {
+			return kv[len(name)+1:]
+		}
+	}
+	return ""
+}
+
+// ErrNoHandler is returned when serving is attempted on a Server that lacks
+// a Config or a Handler.
+var ErrNoHandler = errors.New("sshserve: server has no config or handler")
+
+// Serve accepts connections on the listener, and serves each one in its
+// own goroutine.  It returns when the listener fails, for example because
+// it was closed.
+func (srv *Server) Serve(l net.Listener) error {
+	if srv.Config == nil || srv.Handler == nil {
+		return ErrNoHandler
+	}
+	for {
+		c, e := l.Accept()
+		if e != nil {
+			return e
+		}
+		go srv.ServeConn(c)
+	}
+}
+
+// ServeConn performs the SSH handshake on the connection, and then serves
+// its sessions.  It returns when the connection is closed.
+func (srv *Server) ServeConn(c net.Conn) error {
+	if srv.Config == nil || srv.Handler == nil {
+		c.Close()
+		return ErrNoHandler
+	}
+	conn, chans, reqs, e := ssh.NewServerConn(c, srv.Config)
+	if e != nil {
+		c.Close()
+		return e
+	}
+	go ssh.DiscardRequests(reqs)
+
+	for nc := range chans {
+		if nc.ChannelType() != "session" {
+			nc.Reject(ssh.UnknownChannelType, "unsupported channel type")
+			continue
+		}
+		ch, creqs, e := nc.Accept()
+		if e != nil {
+			continue
+		}
+		sess := &Session{Conn: conn, ch: ch}
+		go srv.handleRequests(sess, creqs)
+	}
+	return conn.Wait()
+}
+
+// handleRequests processes the requests made on a session channel.  The
+// application is started once the client asks for a shell.
+func (srv *Server) handleRequests(sess *Session, reqs <-chan *ssh.Request) {
+	for req := range reqs {
+		ok := false
+		switch req.Type {
+		case "env":
+			var kv struct {
+				Name  string
+				Value string
+			}
+			if ssh.Unmarshal(req.Payload, &kv) == nil {
+				sess.Lock()
+				sess.Env = append(sess.Env, kv.Name+"="+kv.Value)
+				sess.Unlock()
+				ok = true
+			}
+
+		case "pty-req":
+			if term, w, h, good := parsePtyReq(req.Payload); good {
+				sess.Lock()
+				sess.Term = term
+				sess.w, sess.h = w, h
+				sess.pty = true
+				sess.Unlock()
+				ok = true
+			}
+
+		case "window-change":
+			if w, h, good := parseWindowChange(req.Payload); good {
+				sess.Lock()
+				// RFC 4254 says that a zero dimension is to be
+				// ignored, in which case the size we have stands.
+				if w == 0 {
+					w = sess.w
+				}
+				if h == 0 {
+					h = sess.h
+				}
+				sess.w, sess.h = w, h
+				s := sess.screen
+				sess.Unlock()
+				if s != nil && w > 0 && h > 0 {
+					s.Resize(0, 0, w, h)
+				}
+			}
+			// window-change never wants a reply
+			continue
+
+		case "shell":
+			sess.Lock()
+			// A screen is useless without a terminal, and we only
+			// run one application per session.
+			if sess.pty && !sess.shell {
+				sess.shell = true
+				ok = true
+			}
+			sess.Unlock()
+			if ok {
+				req.Reply(true, nil)
+				go srv.run(sess)
+				continue
+			}
+		}
+		if req.WantReply {
+			req.Reply(ok, nil)
+		}
+	}
+}
+
+// parsePtyReq decodes the payload of a pty-req request, which consists of
+// the terminal type, the window size as for window-change, and the
+// terminal modes.  The modes are left alone: they describe the line
+// discipline of a tty, and a screen does its own input processing.
+func parsePtyReq(b []byte) (string, int, int, bool) {
+	if len(b) < 4 {
+		return "", 0, 0, false
+	}
+	n := binary.BigEndian.Uint32(b)
+	b = b[4:]
+	if uint32(len(b)) < n {
+		return "", 0, 0, false
+	}
+	w, h, ok := parseWindowChange(b[n:])
+	return string(b[:n]), w, h, ok
+}
+
+// parseWindowChange decodes the payload of a window-change request, which
+// consists of the width and height in characters, followed by the width
+// and height in pixels.
+func parseWindowChange(b []byte) (int, int, bool) {
+	if len(b) < 8 {
+		return 0, 0, false
+	}
+	w := binary.BigEndian.Uint32(b)
+	h := binary.BigEndian.Uint32(b[4:])
+	return int(w), int(h), true
+}
+
+// run creates the screen for the session and runs the application on it.
+func (srv *Server) run(sess *Session) {
+	opts := srv.Options
+	sess.Lock()
+	opts.Term = sess.Term
+	opts.Width, opts.Height = sess.w, sess.h
+	sess.Unlock()
+	// Without a locale from the client, the server's default stands.
+	for _, name := range []string{"LC_ALL", "LC_CTYPE", "LANG"} {
+		if sess.Getenv(name) != "" {
+			opts.Charset = tcell.LocaleCharset(sess.Getenv)
+			break
+		}
+	}
+	if ct := sess.Getenv("COLORTERM"); ct != "" {
+		opts.ColorTerm = ct
+	}
+	// When the client goes away, the application must find out.
+	opts.FiniOnError = true
+
+	status := uint32(0)
+	stream := channelStream{sess.ch}
+	s, e := tcell.NewQuasiScreenWithOptions(stream, stream, opts)
+	if e == nil {
+		e = s.Init()
+	}
+	if e != nil {
+		io.WriteString(sess.ch.Stderr(), e.Error()+"\r\n")
+		status = 1
+	} else {
+		sess.Lock()
+		sess.screen = s
+		// The window may have changed while we were starting up.  Any
+		// dimension that the client has not given keeps the default.
+		w, h := sess.w, sess.h
+		sw, sh := s.Size()
+		if w == 0 {
+			w = sw
+		}
+		if h == 0 {
+			h = sh
+		}
+		sess.w, sess.h = w, h
+		sess.Unlock()
+		if w != sw || h != sh {
+			s.Resize(0, 0, w, h)
+		}
+
+		srv.Handler(s, sess)
+
+		sess.Lock()
+		sess.screen = nil
+		sess.Unlock()
+		s.Fini()
+	}
+
+	sess.ch.SendRequest("exit-status", false,
+		ssh.Marshal(struct{ Status uint32 }{status}))
+	sess.ch.Close()
+}
+
+// channelStream adapts an SSH channel for use by a quasi screen.  The
+// screen closes its streams when it is finalized, but we still need the
+// channel afterwards to report the exit status, so closing is left to us.
+type channelStream struct {
+	ssh.Channel
+}
+
+func (channelStream) Close() error {
+	return nil
+}
@@ -0,0 +1,262 @@
+// Copyright 2017 The TCell Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use file except in compliance with the License.
+// You may obtain a copy of the license at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sshserve
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"io"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/thyth/tcell"
+	"github.com/thyth/tcell/encoding"
+	"golang.org/x/crypto/ssh"
+)
+
+// syncBuffer is a bytes.Buffer that can be written and read concurrently.
+type syncBuffer struct {
+	buf bytes.Buffer
+	sync.Mutex
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.Lock()
+	defer b.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.Lock()
+	defer b.Unlock()
+	return b.buf.String()
+}
+
+func waitFor(cond func() bool) bool {
+	for i := 0; i < 200; i++ {
+		if cond() {
+			return true
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	return false
+}
+
+// newTestServer creates a server with a throwaway host key, that lets
+// anyone in.
+func newTestServer(t *testing.T, h Handler) *Server {
+	_, key, e := ed25519.GenerateKey(rand.Reader)
+	if e != nil {
+		t.Fatal(e)
+	}
+	signer, e := ssh.NewSignerFromKey(key)
+	if e != nil {
+		t.Fatal(e)
+	}
+	cfg := &ssh.ServerConfig{NoClientAuth: true}
+	cfg.AddHostKey(signer)
+	return &Server{Config: cfg, Handler: h}
+}
+
+// dial connects an in-process client to the server.  We use a loopback
+// listener rather than net.Pipe, because both ends of an SSH connection
+// send their version banner at the same time, which deadlocks on a pipe
+// without any buffering.
+func dial(t *testing.T, srv *Server) *ssh.Client {
+	l, e := net.Listen("tcp", "127.0.0.1:0")
+	if e != nil {
+		t.Fatal(e)
+	}
+	go srv.Serve(l)
+	cfg := &ssh.ClientConfig{
+		User:            "tester",
+		HostKeyCallback: ssh.InsecureIgnoreHostKey(),
+	}
+	client, e := ssh.Dial("tcp", l.Addr().String(), cfg)
+	// Only one connection is needed.
+	l.Close()
+	if e != nil {
+		t.Fatal(e)
+	}
+	return client
+}
+
+func TestServer(t *testing.T) {
+
+	encoding.Register()
+
+	Convey("SSH sessions", t, func() {
+
+		type result struct {
+			user    string
+			charset string
+			resize  [2]int
+			key     rune
+		}
+		done := make(chan result, 1)
+		resized := make(chan [2]int, 1)
+
+		srv := newTestServer(t, func(s tcell.Screen, sess *Session) {
+			res := result{user: sess.Conn.User()}
+			res.charset = s.CharacterSet()
+			for _, r := range "hello" {
+				s.SetContent(res.resize[0], 0, r, nil, tcell.StyleDefault)
+				res.resize[0]++
+			}
+			s.Show()
+			res.resize[0] = 0
+			for {
+				switch ev := s.PollEvent().(type) {
+				case *tcell.EventResize:
+					w, h := ev.Size()
+					res.resize = [2]int{w, h}
+					resized <- res.resize
+				case *tcell.EventKey:
+					res.key = ev.Rune()
+					if ev.Rune() == 'q' {
+						done <- res
+						return
+					}
+				case nil:
+					done <- res
+					return
+				}
+			}
+		})
+		client := dial(t, srv)
+		Reset(func() {
+			client.Close()
+		})
+
+		sess, e := client.NewSession()
+		So(e, ShouldBeNil)
+		out := &syncBuffer{}
+		sess.Stdout = out
+		in, e := sess.StdinPipe()
+		So(e, ShouldBeNil)
+
+		So(sess.Setenv("LANG", "en_US.ISO8859-1"), ShouldBeNil)
+		So(sess.RequestPty("xterm", 24, 80, ssh.TerminalModes{}), ShouldBeNil)
+		So(sess.Shell(), ShouldBeNil)
+
+		So(waitFor(func() bool {
+			return strings.Contains(out.String(), "hello")
+		}), ShouldBeTrue)
+
+		So(sess.WindowChange(30, 100), ShouldBeNil)
+		select {
+		case <-resized:
+		case <-time.After(5 * time.Second):
+			t.Fatal("window change not delivered")
+		}
+		io.WriteString(in, "q")
+
+		var res result
+		select {
+		case res = <-done:
+		case <-time.After(5 * time.Second):
+			t.Fatal("handler did not finish")
+		}
+		So(res.user, ShouldEqual, "tester")
+		So(res.charset, ShouldEqual, "ISO8859-1")
+		So(res.resize, ShouldResemble, [2]int{100, 30})
+		So(res.key, ShouldEqual, 'q')
+
+		// The session ends after the handler returns.
+		So(sess.Wait(), ShouldBeNil)
+	})
+
+	Convey("Zero window sizes are taken as unknown", t, func() {
+		sizes := make(chan [2]int, 4)
+		srv := newTestServer(t, func(s tcell.Screen, sess *Session) {
+			w, h := s.Size()
+			sizes <- [2]int{w, h}
+			for {
+				switch ev := s.PollEvent().(type) {
+				case *tcell.EventResize:
+					w, h := ev.Size()
+					sizes <- [2]int{w, h}
+				case nil:
+					return
+				}
+			}
+		})
+		client := dial(t, srv)
+		Reset(func() {
+			client.Close()
+		})
+		next := func() [2]int {
+			select {
+			case sz := <-sizes:
+				return sz
+			case <-time.After(5 * time.Second):
+				t.Fatal("size not reported")
+			}
+			return [2]int{}
+		}
+
+		sess, e := client.NewSession()
+		So(e, ShouldBeNil)
+		// Without any input, the client would close the stream.
+		_, e = sess.StdinPipe()
+		So(e, ShouldBeNil)
+		So(sess.RequestPty("xterm", 0, 0, ssh.TerminalModes{}), ShouldBeNil)
+		So(sess.Shell(), ShouldBeNil)
+		So(next(), ShouldResemble, [2]int{80, 24})
+
+		// Only the width is known here, so the height stays.
+		So(sess.WindowChange(0, 0), ShouldBeNil)
+		So(sess.WindowChange(0, 100), ShouldBeNil)
+		So(next(), ShouldResemble, [2]int{100, 24})
+	})
+
+	Convey("Sessions without a terminal are refused", t, func() {
+		srv := newTestServer(t, func(tcell.Screen, *Session) {})
+		client := dial(t, srv)
+		Reset(func() {
+			client.Close()
+		})
+		sess, e := client.NewSession()
+		So(e, ShouldBeNil)
+		So(sess.Shell(), ShouldNotBeNil)
+	})
+}
+
+func TestParsePtyReq(t *testing.T) {
+
+	Convey("Terminal requests are decoded", t, func() {
+		req := ssh.Marshal(struct {
+			Term   string
+			Cols   uint32
+			Rows   uint32
+			Width  uint32
+			Height uint32
+			Modes  string
+		}{"xterm", 132, 43, 0, 0, "\x35\x00\x00\x00\x01\x00"})
+		term, w, h, ok := parsePtyReq(req)
+		So(ok, ShouldBeTrue)
+		So(term, ShouldEqual, "xterm")
+		So(w, ShouldEqual, 132)
+		So(h, ShouldEqual, 43)
+
+		_, _, _, ok = parsePtyReq(req[:10])
+		So(ok, ShouldBeFalse)
+	})
+}
@@ -0,0 +1,243 @@
+// Copyright 2017 The TCell Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use file except in compliance with the License.
+// You may obtain a copy of the license at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package telnetserve hosts tcell applications behind a telnet server.
+//
+// Each connection gets its own quasi screen.  Before the screen is created,
+// the server negotiates a binary, character at a time session with the
+// client, and asks it for its terminal type (TTYPE) and window size (NAWS).
+// Window size changes reported by the client later are delivered to the
+// screen, so the application sees them as ordinary EventResize events.
+//
+// Telnet has no encryption or authentication of its own, so this is only
+// suitable for trusted networks.
+package telnetserve
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/thyth/tcell"
+)
+
+// Handler runs an application on the screen for a single connection.  The
+// screen has already been initialized, and will be finalized after the
+// handler returns, at which point the connection is closed.  The handler
+// should return once PollEvent returns nil, which happens when the client
+// goes away.
+type Handler func(s tcell.Screen, sess *Session)
+
+// Server accepts telnet connections, and runs the Handler for each one.
+type Server struct {
+	// Handler is run for each connection.  It must be set.
+	Handler Handler
+
+	// Options is the template used to create each connection's screen.
+	// The terminal type and window size are filled in from what the
+	// client reports.  The terminal type from the template is used for
+	// clients that do not report one we know, and "ansi" if the template
+	// has none either.
+	Options tcell.QuasiScreenOptions
+
+	// NegotiationTimeout is how long to wait for the client to report
+	// its terminal type and window size, before going ahead without
+	// them.  Zero means two seconds.
+	NegotiationTimeout time.Duration
+}
+
+// Session describes a single connection.
+type Session struct {
+	// Conn is the client's connection.
+	Conn net.Conn
+
+	// Term is the terminal type reported by the client, in lower case.
+	// It is empty if the client did not report one.
+	Term string
+
+	w      int
+	h      int
+	sized  bool
+	screen tcell.Screen
+	sync.Mutex
+}
+
+// ErrNoHandler is returned when serving is attempted on a Server that lacks
+// a Handler.
+var ErrNoHandler = errors.New("telnetserve: server has no handler")
+
+// Serve accepts connections on the listener, and serves each one in its
+// own goroutine.  It returns when the listener fails, for example because
+// it was closed.
+func (srv *Server) Serve(l net.Listener) error {
+	if srv.Handler == nil {
+		return ErrNoHandler
+	}
+	for {
+		c, e := l.Accept()
+		if e != nil {
+			return e
+		}
+		go srv.ServeConn(c)
+	}
+}
+
+// ServeConn negotiates with the client, and then runs the Handler.  It
+// returns, closing the connection, once the Handler has returned.
+func (srv *Server) ServeConn(c net.Conn) error {
+	defer c.Close()
+	if srv.Handler == nil {
+		return ErrNoHandler
+	}
+
+	sess := &Session{Conn: c}
+	t := newTelnet(c, c)
+	t.known = known
+	named := false
+	t.onTerm = func(name string) {
+		sess.Term = name
+		named = true
+	}
+	t.onSize = func(w, h int) {
+		sess.Lock()
+		// RFC 1073 lets the client send zero for a dimension that it
+		// does not know, in which case the size we have stands.
+		if w == 0 {
+			w = sess.w
+		}
+		if h == 0 {
+			h = sess.h
+		}
+		sess.w, sess.h = w, h
+		sess.sized = true
+		s := sess.screen
+		sess.Unlock()
+		if s != nil && w > 0 && h > 0 {
+			s.Resize(0, 0, w, h)
+		}
+	}
+
+	if e := t.negotiate(); e != nil {
+		return e
+	}
+	early, e := srv.await(c, t, func() bool {
+		sess.Lock()
+		sized := sess.sized
+		sess.Unlock()
+		return named && (sized || t.refused(optNAWS))
+	})
+	if e != nil {
+		return e
+	}
+	// The screen is chosen now, so a late terminal type is of no use.
+	t.onTerm = nil
+
+	opts := srv.Options
+	if known(sess.Term) {
+		opts.Term = sess.Term
+	} else if opts.Term == "" {
+		opts.Term = "ansi"
+	}
+	sess.Lock()
+	opts.Width, opts.Height = sess.w, sess.h
+	sess.Unlock()
+	// When the client goes away, the application must find out.
+	opts.FiniOnError = true
+
+	// Any keys typed while we were negotiating go first.
+	in := struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(early), t), c}
+	out := struct {
+		io.Writer
+		io.Closer
+	}{t, c}
+
+	s, e := tcell.NewQuasiScreenWithOptions(in, out, opts)
+	if e == nil {
+		e = s.Init()
+	}
+	if e != nil {
+		io.WriteString(t, e.Error()+"\r\n")
+		return e
+	}
+
+	sess.Lock()
+	sess.screen = s
+	// The window may have changed while we were starting up.  Any
+	// dimension that the client has not given keeps the default.
+	w, h := sess.w, sess.h
+	sw, sh := s.Size()
+	if w == 0 {
+		w = sw
+	}
+	if h == 0 {
+		h = sh
+	}
+	sess.w, sess.h = w, h
+	sess.Unlock()
+	if w != sw || h != sh {
+		s.Resize(0, 0, w, h)
+	}
+
+	srv.Handler(s, sess)
+
+	sess.Lock()
+	sess.screen = nil
+	sess.Unlock()
+	s.Fini()
+	return nil
+}
+
+// await reads from the client until the negotiation is done, or until the
+// client has taken too long to answer.  Data that arrives in the meantime
+// is returned, so that it can be passed on to the screen.
+func (srv *Server) await(c net.Conn, t *telnet, done func() bool) ([]byte, error) {
+	timeout := srv.NegotiationTimeout
+	if timeout <= 0 {
+		timeout = 2 * time.Second
+	}
+	c.SetReadDeadline(time.Now().Add(timeout))
+	defer c.SetReadDeadline(time.Time{})
+
+	var data []byte
+	buf := make([]byte, 256)
+	for !done() {
+		// We decode what we read ourselves, as reading from t would
+		// keep waiting if the client sent only commands.
+		n, e := c.Read(buf)
+		n = t.decode(buf[:n])
+		data = append(data, buf[:n]...)
+		if ne, ok := e.(net.Error); ok && ne.Timeout() {
+			break
+		}
+		if e != nil {
+			return nil, e
+		}
+	}
+	return data, nil
+}
+
+// known reports whether there is a terminfo entry for the terminal type.
+func known(name string) bool {
+	if name == "" {
+		return false
+	}
+	_, e := tcell.LookupTerminfo(name)
+	return e == nil
+}
@@ -0,0 +1,265 @@
+// Copyright 2017 The TCell Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use file except in compliance with the License.
+// You may obtain a copy of the license at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package telnetserve
+
+import (
+	"bytes"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/thyth/tcell"
+	"github.com/thyth/tcell/encoding"
+)
+
+// client is a minimal telnet client, that agrees to everything and reports
+// a fixed terminal type and window size.
+type client struct {
+	net.Conn
+	term string
+	w, h int
+	data bytes.Buffer
+	sync.Mutex
+}
+
+func (c *client) String() string {
+	c.Lock()
+	defer c.Unlock()
+	return c.data.String()
+}
+
+func (c *client) naws(w, h int) {
+	c.Write([]byte{cmdIAC, cmdSB, optNAWS, byte(w >> 8), byte(w),
+		byte(h >> 8), byte(h), cmdIAC, cmdSE})
+}
+
+func (c *client) run() {
+	buf := make([]byte, 1024)
+	var pend []byte
+	for {
+		n, e := c.Read(buf)
+		if e != nil {
+			return
+		}
+		pend = append(pend, buf[:n]...)
+	parse:
+		for len(pend) > 0 {
+			switch {
+			case pend[0] != cmdIAC:
+				c.Lock()
+				c.data.WriteByte(pend[0])
+				c.Unlock()
+				pend = pend[1:]
+			case len(pend) < 2:
+				break parse
+			case pend[1] == cmdIAC:
+				c.Lock()
+				c.data.WriteByte(cmdIAC)
+				c.Unlock()
+				pend = pend[2:]
+			case pend[1] == cmdSB:
+				// The only one we get is TTYPE SEND.
+				end := bytes.Index(pend, []byte{cmdIAC, cmdSE})
+				if end < 0 {
+					break parse
+				}
+				c.Write(append(append([]byte{cmdIAC, cmdSB, optTType,
+					ttypeIS}, c.term...), cmdIAC, cmdSE))
+				pend = pend[end+2:]
+			case len(pend) < 3:
+				break parse
+			case pend[1] == cmdDO:
+				c.Write([]byte{cmdIAC, cmdWILL, pend[2]})
+				if pend[2] == optNAWS {
+					c.naws(c.w, c.h)
+				}
+				pend = pend[3:]
+			case pend[1] == cmdWILL:
+				c.Write([]byte{cmdIAC, cmdDO, pend[2]})
+				pend = pend[3:]
+			default:
+				pend = pend[3:]
+			}
+		}
+	}
+}
+
+func waitFor(cond func() bool) bool {
+	for i := 0; i < 200; i++ {
+		if cond() {
+			return true
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	return false
+}
+
+func dial(t *testing.T, srv *Server) net.Conn {
+	l, e := net.Listen("tcp", "127.0.0.1:0")
+	if e != nil {
+		t.Fatal(e)
+	}
+	go srv.Serve(l)
+	c, e := net.Dial("tcp", l.Addr().String())
+	// Only one connection is needed.
+	l.Close()
+	if e != nil {
+		t.Fatal(e)
+	}
+	return c
+}
+
+func TestServer(t *testing.T) {
+
+	encoding.Register()
+
+	Convey("Telnet sessions", t, func() {
+
+		type result struct {
+			term   string
+			size   [2]int
+			keys   string
+			resize [2]int
+		}
+		done := make(chan result, 1)
+		resized := make(chan [2]int, 1)
+
+		srv := &Server{
+			Options: tcell.QuasiScreenOptions{Charset: "ISO8859-1"},
+			Handler: func(s tcell.Screen, sess *Session) {
+				res := result{term: sess.Term}
+				w, h := s.Size()
+				res.size = [2]int{w, h}
+				for i, r := range "helloÿ" {
+					s.SetContent(i, 0, r, nil, tcell.StyleDefault)
+				}
+				s.Show()
+				for {
+					switch ev := s.PollEvent().(type) {
+					case *tcell.EventResize:
+						w, h := ev.Size()
+						res.resize = [2]int{w, h}
+						resized <- res.resize
+					case *tcell.EventKey:
+						if ev.Rune() == 'q' {
+							done <- res
+							return
+						}
+						res.keys += string(ev.Rune())
+					case nil:
+						done <- res
+						return
+					}
+				}
+			},
+		}
+		c := &client{Conn: dial(t, srv), term: "XTERM", w: 100, h: 30}
+		Reset(func() {
+			c.Close()
+		})
+		go c.run()
+
+		So(waitFor(func() bool {
+			return strings.Contains(c.String(), "hello\xff")
+		}), ShouldBeTrue)
+
+		c.naws(120, 40)
+		select {
+		case <-resized:
+		case <-time.After(5 * time.Second):
+			t.Fatal("window change not delivered")
+		}
+		c.Write([]byte("a\xff\xf1bq"))
+
+		var res result
+		select {
+		case res = <-done:
+		case <-time.After(5 * time.Second):
+			t.Fatal("handler did not finish")
+		}
+		So(res.term, ShouldEqual, "xterm")
+		So(res.size, ShouldResemble, [2]int{100, 30})
+		So(res.resize, ShouldResemble, [2]int{120, 40})
+		So(res.keys, ShouldEqual, "ab")
+	})
+
+	Convey("Zero window sizes are taken as unknown", t, func() {
+		sizes := make(chan [2]int, 2)
+		srv := &Server{
+			Handler: func(s tcell.Screen, sess *Session) {
+				w, h := s.Size()
+				sizes <- [2]int{w, h}
+				for {
+					switch ev := s.PollEvent().(type) {
+					case *tcell.EventResize:
+						w, h := ev.Size()
+						sizes <- [2]int{w, h}
+					case nil:
+						return
+					}
+				}
+			},
+		}
+		c := &client{Conn: dial(t, srv), term: "XTERM"}
+		Reset(func() {
+			c.Close()
+		})
+		go c.run()
+
+		next := func() [2]int {
+			select {
+			case size := <-sizes:
+				return size
+			case <-time.After(5 * time.Second):
+				t.Fatal("no size reported")
+			}
+			return [2]int{}
+		}
+		So(next(), ShouldResemble, [2]int{80, 24})
+		c.naws(0, 40)
+		So(next(), ShouldResemble, [2]int{80, 40})
+		c.naws(100, 0)
+		So(next(), ShouldResemble, [2]int{100, 40})
+	})
+
+	Convey("Clients that do not negotiate get defaults", t, func() {
+		type result struct {
+			term string
+			size [2]int
+		}
+		done := make(chan result, 1)
+		srv := &Server{
+			NegotiationTimeout: 50 * time.Millisecond,
+			Handler: func(s tcell.Screen, sess *Session) {
+				w, h := s.Size()
+				done <- result{sess.Term, [2]int{w, h}}
+			},
+		}
+		c := dial(t, srv)
+		Reset(func() {
+			c.Close()
+		})
+		select {
+		case res := <-done:
+			So(res.term, ShouldEqual, "")
+			So(res.size, ShouldResemble, [2]int{80, 24})
+		case <-time.After(5 * time.Second):
+			t.Fatal("handler did not run")
+		}
+	})
+}
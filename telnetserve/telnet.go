@@ -0,0 +1,368 @@
+// Copyright 2017 The TCell Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use file except in compliance with the License.
+// You may obtain a copy of the license at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package telnetserve
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"sync"
+)
+
+// Telnet commands, from RFC 854.
+const (
+	cmdSE   = 240
+	cmdNOP  = 241
+	cmdSB   = 250
+	cmdWILL = 251
+	cmdWONT = 252
+	cmdDO   = 253
+	cmdDONT = 254
+	cmdIAC  = 255
+)
+
+// Telnet options that we negotiate.
+const (
+	optBinary = 0  // RFC 856
+	optEcho   = 1  // RFC 857
+	optSGA    = 3  // RFC 858
+	optTType  = 24 // RFC 1091
+	optNAWS   = 31 // RFC 1073
+)
+
+// Sub-negotiation verbs for the terminal type option.
+const (
+	ttypeIS   = 0
+	ttypeSEND = 1
+)
+
+// maxTTypes limits how many terminal types we will ask a client for while
+// looking for one that we know.
+const maxTTypes = 8
+
+// Parser states.
+const (
+	stData = iota
+	stCR
+	stIAC
+	stVerb
+	stSB
+	stSBIAC
+)
+
+// telnet implements the telnet protocol on top of a connection.  Reading
+// from it yields the client's data with all telnet commands removed, and
+// writing to it escapes any IAC bytes in the data.  Option negotiation
+// happens as a side effect of reading.
+type telnet struct {
+	r io.Reader
+	w io.Writer
+
+	// We want the client to do the options in "want", and we are willing
+	// to do the options in "offer" ourselves.  An option in "him" or "us"
+	// is enabled on that side, and an option in "askedHim" or "askedUs"
+	// has a request of ours outstanding.
+	want     map[byte]bool
+	offer    map[byte]bool
+	him      map[byte]bool
+	us       map[byte]bool
+	askedHim map[byte]bool
+	askedUs  map[byte]bool
+
+	state  int
+	verb   byte
+	sb     []byte
+	ttypes []string
+
+	// These are called as the client reports its window size and
+	// terminal type.  The terminal type is reported once, when it is
+	// known or when the client refuses to tell us.
+	onSize func(w, h int)
+	onTerm func(name string)
+
+	// known reports whether we have a terminfo entry for a terminal
+	// type.  If it is nil, the first type reported is accepted.
+	known func(name string) bool
+
+	wlock sync.Mutex
+}
+
+func newTelnet(r io.Reader, w io.Writer) *telnet {
+	return &telnet{
+		r: r,
+		w: w,
+		want: map[byte]bool{
+			optBinary: true,
+			optSGA:    true,
+			optTType:  true,
+			optNAWS:   true,
+		},
+		offer: map[byte]bool{
+			optBinary: true,
+			optSGA:    true,
+			optEcho:   true,
+		},
+		him:      make(map[byte]bool),
+		us:       make(map[byte]bool),
+		askedHim: make(map[byte]bool),
+		askedUs:  make(map[byte]bool),
+	}
+}
+
+// negotiate sends our initial option requests.  We want a binary, full
+// duplex, character at a time session, where the client tells us its
+// window size and terminal type.  Taking over the echo keeps the client
+// from printing the keys typed over our screen.
+func (t *telnet) negotiate() error {
+	var b []byte
+	for _, o := range []byte{optBinary, optSGA, optTType, optNAWS} {
+		t.askedHim[o] = true
+		b = append(b, cmdIAC, cmdDO, o)
+	}
+	for _, o := range []byte{optBinary, optSGA, optEcho} {
+		t.askedUs[o] = true
+		b = append(b, cmdIAC, cmdWILL, o)
+	}
+	return t.send(b)
+}
+
+// send writes protocol bytes to the client, without escaping.
+func (t *telnet) send(b []byte) error {
+	t.wlock.Lock()
+	defer t.wlock.Unlock()
+	_, e := t.w.Write(b)
+	return e
+}
+
+// Write sends data to the client, doubling any IAC bytes so that they are
+// not mistaken for commands.  The data is sent with a single write on the
+// connection, so frames are not split up.
+func (t *telnet) Write(p []byte) (int, error) {
+	b := p
+	if bytes.IndexByte(p, cmdIAC) >= 0 {
+		b = bytes.Replace(p, []byte{cmdIAC}, []byte{cmdIAC, cmdIAC}, -1)
+	}
+	if e := t.send(b); e != nil {
+		return 0, e
+	}
+	return len(p), nil
+}
+
+// Read returns data from the client.  Telnet commands are processed and
+// removed from the data.  The commands never yield more bytes than they
+// occupy on the wire, so the data is decoded in place.
+func (t *telnet) Read(p []byte) (int, error) {
+	for {
+		n, e := t.r.Read(p)
+		n = t.decode(p[:n])
+		if n > 0 || e != nil {
+			return n, e
+		}
+	}
+}
+
+// decode runs the bytes through the protocol parser, leaving just the data
+// bytes at the front of b, and returns how many there are.
+func (t *telnet) decode(b []byte) int {
+	n := 0
+	for _, c := range b {
+		switch t.state {
+		case stCR:
+			// RFC 854 sends a bare carriage return as CR NUL, and
+			// the end of a line as CR LF unless in binary mode.
+			t.state = stData
+			if c == 0 || (c == '\n' && !t.him[optBinary]) {
+				continue
+			}
+			fallthrough
+		case stData:
+			switch c {
+			case cmdIAC:
+				t.state = stIAC
+			case '\r':
+				t.state = stCR
+				b[n] = c
+				n++
+			default:
+				b[n] = c
+				n++
+			}
+		case stIAC:
+			switch c {
+			case cmdIAC:
+				// An escaped 0xFF data byte.
+				b[n] = c
+				n++
+				t.state = stData
+			case cmdWILL, cmdWONT, cmdDO, cmdDONT:
+				t.verb = c
+				t.state = stVerb
+			case cmdSB:
+				t.sb = t.sb[:0]
+				t.state = stSB
+			default:
+				// NOP, GA, and the rest have no meaning for us.
+				t.state = stData
+			}
+		case stVerb:
+			t.option(t.verb, c)
+			t.state = stData
+		case stSB:
+			if c == cmdIAC {
+				t.state = stSBIAC
+			} else {
+				t.sb = append(t.sb, c)
+			}
+		case stSBIAC:
+			switch c {
+			case cmdIAC:
+				t.sb = append(t.sb, c)
+				t.state = stSB
+			case cmdSE:
+				t.subneg(t.sb)
+				t.state = stData
+			default:
+				// Malformed; give up on the sub-negotiation.
+				t.state = stData
+			}
+		}
+	}
+	return n
+}
+
+// option handles an option request from the client.  We answer only
+// requests that change the state of an option, and not the replies to our
+// own requests, which keeps the two ends from looping (RFC 854).
+func (t *telnet) option(verb, o byte) {
+	var reply []byte
+	switch verb {
+	case cmdWILL:
+		if !t.want[o] {
+			reply = []byte{cmdIAC, cmdDONT, o}
+			break
+		}
+		if !t.him[o] {
+			t.him[o] = true
+			if !t.askedHim[o] {
+				reply = []byte{cmdIAC, cmdDO, o}
+			}
+			if o == optTType {
+				reply = append(reply, cmdIAC, cmdSB, optTType,
+					ttypeSEND, cmdIAC, cmdSE)
+			}
+		}
+		t.askedHim[o] = false
+	case cmdWONT:
+		if t.him[o] && !t.askedHim[o] {
+			reply = []byte{cmdIAC, cmdDONT, o}
+		}
+		if t.him[o] || t.askedHim[o] {
+			t.him[o] = false
+			t.askedHim[o] = false
+			if o == optTType {
+				t.setTerm("")
+			}
+		}
+	case cmdDO:
+		if !t.offer[o] {
+			reply = []byte{cmdIAC, cmdWONT, o}
+			break
+		}
+		if !t.us[o] {
+			t.us[o] = true
+			if !t.askedUs[o] {
+				reply = []byte{cmdIAC, cmdWILL, o}
+			}
+		}
+		t.askedUs[o] = false
+	case cmdDONT:
+		if t.us[o] && !t.askedUs[o] {
+			reply = []byte{cmdIAC, cmdWONT, o}
+		}
+		t.us[o] = false
+		t.askedUs[o] = false
+	}
+	if reply != nil {
+		t.send(reply)
+	}
+}
+
+// refused reports whether the client has settled on not doing an option
+// we asked for.
+func (t *telnet) refused(o byte) bool {
+	return !t.him[o] && !t.askedHim[o]
+}
+
+// subneg handles the body of a sub-negotiation, which begins with the
+// option it belongs to.
+func (t *telnet) subneg(b []byte) {
+	if len(b) == 0 {
+		return
+	}
+	switch b[0] {
+	case optNAWS:
+		if len(b) >= 5 {
+			w := int(b[1])<<8 | int(b[2])
+			h := int(b[3])<<8 | int(b[4])
+			if t.onSize != nil {
+				t.onSize(w, h)
+			}
+		}
+	case optTType:
+		if len(b) >= 2 && b[1] == ttypeIS {
+			t.termType(strings.ToLower(string(b[2:])))
+		}
+	}
+}
+
+// termType records a terminal type sent by the client.  Clients that know
+// several names for their terminal report the next one each time they are
+// asked, and repeat the last one once they run out (RFC 1091).  We keep
+// asking until we find a name with a terminfo entry, and otherwise settle
+// for the first name we were given.
+func (t *telnet) termType(name string) {
+	if t.onTerm == nil {
+		return
+	}
+	switch {
+	case t.known == nil || t.known(name):
+	case len(t.ttypes) >= maxTTypes || seen(t.ttypes, name):
+		name = t.ttypes[0]
+	default:
+		t.ttypes = append(t.ttypes, name)
+		t.send([]byte{cmdIAC, cmdSB, optTType, ttypeSEND, cmdIAC, cmdSE})
+		return
+	}
+	t.setTerm(name)
+}
+
+func seen(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+// setTerm reports the terminal type, which is empty if the client would
+// not tell us.  Only the first report counts.
+func (t *telnet) setTerm(name string) {
+	if f := t.onTerm; f != nil {
+		t.onTerm = nil
+		t.ttypes = nil
+		f(name)
+	}
+}
@@ -0,0 +1,159 @@
+// Copyright 2017 The TCell Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use file except in compliance with the License.
+// You may obtain a copy of the license at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package telnetserve
+
+import (
+	"bytes"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestTelnet(t *testing.T) {
+
+	Convey("Telnet protocol", t, func() {
+		in := &bytes.Buffer{}
+		out := &bytes.Buffer{}
+		tn := newTelnet(in, out)
+
+		Convey("Commands are stripped from the input", func() {
+			in.WriteString("a\xff\xf1b\xff\xffc\xff\xfd\x63d")
+			b, e := ioutil.ReadAll(tn)
+			So(e, ShouldBeNil)
+			So(string(b), ShouldEqual, "ab\xffcd")
+			// We refuse options we don't know.
+			So(out.String(), ShouldEqual, "\xff\xfc\x63")
+		})
+
+		Convey("Carriage returns are decoded", func() {
+			in.WriteString("a\r\x00b\r\nc")
+			b, _ := ioutil.ReadAll(tn)
+			So(string(b), ShouldEqual, "a\rb\rc")
+
+			tn.him[optBinary] = true
+			in.WriteString("a\r\nb")
+			b, _ = ioutil.ReadAll(tn)
+			So(string(b), ShouldEqual, "a\r\nb")
+		})
+
+		Convey("IAC is escaped in the output", func() {
+			n, e := tn.Write([]byte("a\xffb"))
+			So(e, ShouldBeNil)
+			So(n, ShouldEqual, 3)
+			So(out.String(), ShouldEqual, "a\xff\xffb")
+		})
+
+		Convey("Window sizes are reported", func() {
+			var sizes [][2]int
+			tn.onSize = func(w, h int) {
+				sizes = append(sizes, [2]int{w, h})
+			}
+			So(tn.negotiate(), ShouldBeNil)
+			out.Reset()
+			in.WriteString("\xff\xfb\x1f")
+			in.WriteString("\xff\xfa\x1f\x00\x50\x00\x18\xff\xf0")
+			// A width of 255 has its IAC doubled.
+			in.WriteString("x\xff\xfa\x1f\x00\xff\xff\x00\x30\xff\xf0y")
+			b, _ := ioutil.ReadAll(tn)
+			So(string(b), ShouldEqual, "xy")
+			So(sizes, ShouldResemble, [][2]int{{80, 24}, {255, 48}})
+			// WILL answers our DO, so needs no reply.
+			So(out.Len(), ShouldEqual, 0)
+			So(tn.refused(optNAWS), ShouldBeFalse)
+		})
+
+		Convey("Refused options are noted", func() {
+			So(tn.negotiate(), ShouldBeNil)
+			So(tn.refused(optNAWS), ShouldBeFalse)
+			out.Reset()
+			in.WriteString("\xff\xfc\x1f")
+			ioutil.ReadAll(tn)
+			So(tn.refused(optNAWS), ShouldBeTrue)
+			So(out.Len(), ShouldEqual, 0)
+		})
+
+		Convey("Unsolicited requests are answered", func() {
+			in.WriteString("\xff\xfd\x03\xff\xfb\x00")
+			ioutil.ReadAll(tn)
+			So(out.String(), ShouldEqual, "\xff\xfb\x03\xff\xfd\x00")
+
+			// Repeating them changes nothing, so gets no reply.
+			out.Reset()
+			in.WriteString("\xff\xfd\x03\xff\xfb\x00")
+			ioutil.ReadAll(tn)
+			So(out.Len(), ShouldEqual, 0)
+		})
+
+		Convey("Terminal types are cycled until one is known", func() {
+			term := "none"
+			tn.onTerm = func(name string) {
+				term = name
+			}
+			tn.known = func(name string) bool {
+				return name == "xterm"
+			}
+			send := "\xff\xfa\x18\x01\xff\xf0"
+
+			So(tn.negotiate(), ShouldBeNil)
+			out.Reset()
+			in.WriteString("\xff\xfb\x18")
+			ioutil.ReadAll(tn)
+			So(out.String(), ShouldEqual, send)
+
+			out.Reset()
+			in.WriteString("\xff\xfa\x18\x00FANCY\xff\xf0")
+			ioutil.ReadAll(tn)
+			So(out.String(), ShouldEqual, send)
+			So(term, ShouldEqual, "none")
+
+			out.Reset()
+			in.WriteString("\xff\xfa\x18\x00XTERM\xff\xf0")
+			ioutil.ReadAll(tn)
+			So(out.Len(), ShouldEqual, 0)
+			So(term, ShouldEqual, "xterm")
+		})
+
+		Convey("The first terminal type is used if none are known", func() {
+			term := "none"
+			tn.onTerm = func(name string) {
+				term = name
+			}
+			tn.known = func(string) bool {
+				return false
+			}
+			So(tn.negotiate(), ShouldBeNil)
+			in.WriteString("\xff\xfb\x18")
+			for _, name := range []string{"ONE", "TWO", "TWO"} {
+				in.WriteString("\xff\xfa\x18\x00" + name + "\xff\xf0")
+			}
+			ioutil.ReadAll(tn)
+			So(term, ShouldEqual, "one")
+			So(strings.Count(out.String(), "\xff\xfa\x18\x01"), ShouldEqual, 3)
+		})
+
+		Convey("Refusing the terminal type reports an empty one", func() {
+			term := "none"
+			tn.onTerm = func(name string) {
+				term = name
+			}
+			So(tn.negotiate(), ShouldBeNil)
+			in.WriteString("\xff\xfc\x18")
+			ioutil.ReadAll(tn)
+			So(term, ShouldEqual, "")
+		})
+	})
+}
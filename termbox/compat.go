@@ -26,6 +26,9 @@ import (
 var screen tcell.Screen
 var outMode OutputMode
 
+// pending holds events that are yet to be returned by PollEvent.
+var pending []Event
+
 // Init initializes the screen for use.
 func Init() error {
 	outMode = OutputNormal
@@ -364,6 +367,28 @@ func makeEvent(tev tcell.Event) Event {
 	}
 }
 
+// makeEvents is like makeEvent, except that pasted text, which termbox
+// has no event for, becomes a key event for each character, just as if
+// it had been typed.
+func makeEvents(tev tcell.Event) []Event {
+	pev, ok := tev.(*tcell.EventPaste)
+	if !ok {
+		return []Event{makeEvent(tev)}
+	}
+	var evs []Event
+	for _, r := range pev.Text() {
+		k := tcell.KeyRune
+		if r < ' ' || r == 0x7f {
+			k, r = tcell.Key(r), 0
+		}
+		evs = append(evs, makeEvent(tcell.NewEventKey(k, r, tcell.ModNone)))
+	}
+	if len(evs) == 0 {
+		evs = append(evs, Event{Type: EventNone})
+	}
+	return evs
+}
+
 // ParseEvent is not supported.
 func ParseEvent(data []byte) Event {
 	// Not supported
@@ -378,8 +403,12 @@ func PollRawEvent(data []byte) Event {
 
 // PollEvent blocks until an event is ready, and then returns it.
 func PollEvent() Event {
-	ev := screen.PollEvent()
-	return makeEvent(ev)
+	if len(pending) == 0 {
+		pending = makeEvents(screen.PollEvent())
+	}
+	ev := pending[0]
+	pending = pending[1:]
+	return ev
 }
 
 // Interrupt posts an interrupt event.
@@ -36,6 +36,7 @@ type Terminfo struct {
 	Lines        int      `json:"lines,omitempty"`  // lines
 	Colors       int      `json:"colors,omitempty"` // colors
 	Bell         string   `json:"bell,omitempty"`   // bell
+	Flash        string   `json:"flash,omitempty"`  // flash
 	Clear        string   `json:"clear,omitempty"`  // clear
 	EnterCA      string   `json:"smcup,omitempty"`  // smcup
 	ExitCA       string   `json:"rmcup,omitempty"`  // rmcup
@@ -147,6 +148,61 @@ type Terminfo struct {
 	KeyShfHome   string   `json:"kHOM,omitempty"`   // kHOM
 	KeyShfEnd    string   `json:"kEND,omitempty"`   // kEND
 
+	// These are used for moving the cursor more cheaply than with
+	// SetCursor, when it only has a short distance to go.
+	CursorDown1    string `json:"cud1,omitempty"` // cud1
+	CursorForward1 string `json:"cuf1,omitempty"` // cuf1
+	CursorBack     string `json:"cub,omitempty"`  // cub
+	CursorUp       string `json:"cuu,omitempty"`  // cuu
+	CursorDown     string `json:"cud,omitempty"`  // cud
+	CursorForward  string `json:"cuf,omitempty"`  // cuf
+	CursorHome     string `json:"home,omitempty"` // home
+	CarriageReturn string `json:"cr,omitempty"`   // cr
+	ColumnAddress  string `json:"hpa,omitempty"`  // hpa
+	RowAddress     string `json:"vpa,omitempty"`  // vpa
+
+	// These are used to clear or repeat runs of identical cells, rather
+	// than drawing each of them in turn.
+	ClearToEOL     string `json:"el,omitempty"`  // el
+	EraseChars     string `json:"ech,omitempty"` // ech
+	RepeatChar     string `json:"rep,omitempty"` // rep
+	BackColorErase bool   `json:"bce,omitempty"` // bce
+
+	// These turn off underlining, and the colors, without turning off
+	// everything else, so that changing styles only costs what changes.
+	ExitUnderline string `json:"rmul,omitempty"` // rmul
+	ResetFgBg     string `json:"op,omitempty"`   // op
+
+	// These are extensions, found in the entries for XTerm and some
+	// others, for bracketed paste, which marks pasted text as such.
+	EnablePaste  string `json:"BE,omitempty"` // BE
+	DisablePaste string `json:"BD,omitempty"` // BD
+	PasteStart   string `json:"PS,omitempty"` // PS
+	PasteEnd     string `json:"PE,omitempty"` // PE
+
+	// These are also extensions, for reporting when the terminal window
+	// gains or loses the focus.
+	EnableFocus  string `json:"fe,omitempty"`    // fe
+	DisableFocus string `json:"fd,omitempty"`    // fd
+	FocusIn      string `json:"kxIN,omitempty"`  // kxIN
+	FocusOut     string `json:"kxOUT,omitempty"` // kxOUT
+
+	// This sets the terminal's clipboard, using OSC 52, from a selection
+	// name and some base64 encoded data, which may also be "?" to ask for
+	// the clipboard's contents.  It takes string parameters, unlike the
+	// others, so it must be expanded with TParmString.
+	SetClipboard string `json:"Ms,omitempty"` // Ms
+
+	// These surround the text of the window title.  The start comes from
+	// TS, an XTerm extension, or else from tsl, and the end from fsl;
+	// tsl and fsl were meant for status lines, but terminal emulators
+	// use them for the title instead.  The title can also be saved, and
+	// later restored, by terminals that keep a stack of them.
+	TitleStart string `json:"TS,omitempty"`         // TS or tsl
+	TitleEnd   string `json:"fsl,omitempty"`        // fsl
+	PushTitle  string `json:"_pushtitle,omitempty"` // push title
+	PopTitle   string `json:"_poptitle,omitempty"`  // pop title
+
 	// These are non-standard extensions to terminfo.  This includes
 	// true color support, and some additional keys.  Its kind of bizarre
 	// that shifted variants of left and right exist, but not up and down.
@@ -335,21 +391,40 @@ var pb = &paramsBuffer{}
 // evaluates the string, and returns the result with the parameter
 // applied.
 func (t *Terminfo) TParm(s string, p ...int) string {
+	// make sure we always have 9 parameters -- makes it easier
+	// later to skip checks
+	var params [9]stackElem
+	for i := range params {
+		params[i].isInt = true
+		if i < len(p) {
+			params[i].i = p[i]
+		}
+	}
+	return t.tparm(s, params)
+}
+
+// TParmString is like TParm, but for the few parameterized strings, such
+// as Ms, whose parameters are strings.
+func (t *Terminfo) TParmString(s string, p ...string) string {
+	var params [9]stackElem
+	for i := range params {
+		params[i].isStr = true
+		if i < len(p) {
+			params[i].s = p[i]
+		}
+	}
+	return t.tparm(s, params)
+}
+
+func (t *Terminfo) tparm(s string, params [9]stackElem) string {
 	var stk stack
 	var a, b string
 	var ai, bi int
 	var ab bool
 	var dvars [26]string
-	var params [9]int
 
 	pb.Start(s)
 
-	// make sure we always have 9 parameters -- makes it easier
-	// later to skip checks
-	for i := 0; i < len(params) && i < len(p); i++ {
-		params[i] = p[i]
-	}
-
 	nest := 0
 
 	for {
@@ -375,13 +450,22 @@ func (t *Terminfo) TParm(s string, p ...int) string {
 			pb.PutCh(ch)
 
 		case 'i': // increment both parameters (ANSI cup support)
-			params[0]++
-			params[1]++
+			params[0].i++
+			params[1].i++
 
-		case 'c', 's':
-			// NB: these, and 'd' below are special cased for
-			// efficiency.  They could be handled by the richer
+		case 'c':
+			// NB: this, and 's' and 'd' below are special cased
+			// for efficiency.  They could be handled by the richer
 			// format support below, less efficiently.
+			if len(stk) > 0 && stk[len(stk)-1].isInt {
+				ai, stk = stk.PopInt()
+				pb.PutCh(byte(ai))
+			} else {
+				a, stk = stk.Pop()
+				pb.PutString(a)
+			}
+
+		case 's':
 			a, stk = stk.Pop()
 			pb.PutString(a)
 
@@ -420,7 +504,7 @@ func (t *Terminfo) TParm(s string, p ...int) string {
 			ch, _ = pb.NextCh()
 			ai = int(ch - '1')
 			if ai >= 0 && ai < len(params) {
-				stk = stk.PushInt(params[ai])
+				stk = append(stk, params[ai])
 			} else {
 				stk = stk.PushInt(0)
 			}
@@ -69,6 +69,18 @@ func TestTerminfo(t *testing.T) {
 			So(s, ShouldEqual, "\x1b[38;5;200m")
 		})
 
+		// This tests characters
+		Convey("TParm characters work", func() {
+			s := ti.TParm("%p1%c\x1b[%p2%{1}%-%db", 'x', 5)
+			So(s, ShouldEqual, "x\x1b[4b")
+		})
+
+		// This tests string parameters
+		Convey("TParmString works", func() {
+			s := ti.TParmString("\x1b]52;%p1%s;%p2%s\007", "c", "aGk=")
+			So(s, ShouldEqual, "\x1b]52;c;aGk=\a")
+		})
+
 		// This tests variables
 		Convey("TParm mouse mode works", func() {
 			s := ti.TParm(ti.MouseMode, 1)
@@ -97,6 +109,23 @@ func TestTerminfo(t *testing.T) {
 	})
 }
 
+func TestTerminfoTitles(t *testing.T) {
+
+	Convey("Only XTerm has a made up title", t, func() {
+		ti, e := LookupTerminfo("xterm")
+		So(e, ShouldBeNil)
+		So(ti.TitleStart, ShouldEqual, "\x1b]2;")
+		So(ti.PushTitle, ShouldEqual, "\x1b[22;0t")
+
+		for _, name := range []string{"linux", "screen"} {
+			ti, e := LookupTerminfo(name)
+			So(e, ShouldBeNil)
+			So(ti.TitleStart, ShouldBeEmpty)
+			So(ti.PushTitle, ShouldBeEmpty)
+		}
+	})
+}
+
 func BenchmarkSetFgBg(b *testing.B) {
 	ti := testTerminfo
 
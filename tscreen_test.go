@@ -0,0 +1,36 @@
+// Copyright 2017 The TCell Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use file except in compliance with the License.
+// You may obtain a copy of the license at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tcell
+
+import (
+	"os"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestTerminfoScreenKeys(t *testing.T) {
+
+	Convey("Keys are known before Init", t, func() {
+		term := os.Getenv("TERM")
+		os.Setenv("TERM", "xterm")
+		defer os.Setenv("TERM", term)
+
+		s, e := NewTerminfoScreen()
+		So(e, ShouldBeNil)
+		So(s.HasKey(KeyF1), ShouldBeTrue)
+		So(s.HasKey(KeyF64), ShouldBeFalse)
+	})
+}
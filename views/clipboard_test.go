@@ -0,0 +1,119 @@
+// Copyright 2017 The TCell Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use file except in compliance with the License.
+// You may obtain a copy of the license at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package views
+
+import (
+	"bytes"
+	"encoding/base64"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/thyth/tcell"
+)
+
+// copied copies from the widget to the clipboard of a simulation screen
+// for the terminal, and returns what the clipboard then holds.
+func copied(t *testing.T, term string, w interface {
+	Widget
+	CopyToClipboard()
+}) string {
+	s, e := tcell.NewTerminfoSimulationScreen("UTF-8", term)
+	if e != nil {
+		t.Fatalf("cannot create screen: %v", e)
+	}
+	if e := s.Init(); e != nil {
+		t.Fatalf("cannot initialize screen: %v", e)
+	}
+	defer s.Fini()
+
+	// The copy goes through a ViewPort, as it would in an application.
+	w.SetView(NewViewPort(s, 0, 0, 20, 5))
+	w.CopyToClipboard()
+	s.GetClipboard()
+	ev, ok := s.PollEvent().(*tcell.EventClipboard)
+	if !ok {
+		t.Fatalf("no clipboard event")
+	}
+	return string(ev.Data())
+}
+
+func TestCopyToClipboard(t *testing.T) {
+	text := NewText()
+	text.SetText("hello, world")
+	if got := copied(t, "xterm", text); got != "hello, world" {
+		t.Errorf("Text copied %q", got)
+	}
+
+	area := NewTextArea()
+	area.SetContent("one\ntwo")
+	if got := copied(t, "xterm", area); got != "one\ntwo\n" {
+		t.Errorf("TextArea copied %q", got)
+	}
+
+	// Only XTerm has OSC 52.
+	if got := copied(t, "linux", text); got != "" {
+		t.Errorf("linux copied %q", got)
+	}
+}
+
+// lockedBuffer collects the output of a quasi screen.
+type lockedBuffer struct {
+	buf bytes.Buffer
+	sync.Mutex
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.Lock()
+	defer b.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *lockedBuffer) Close() error {
+	return nil
+}
+
+func (b *lockedBuffer) String() string {
+	b.Lock()
+	defer b.Unlock()
+	return b.buf.String()
+}
+
+func TestCopyToClipboardSequence(t *testing.T) {
+	pr, pw := io.Pipe()
+	defer pw.Close()
+	out := &lockedBuffer{}
+	s, e := tcell.NewQuasiScreenWithOptions(pr, out,
+		tcell.QuasiScreenOptions{Term: "xterm"})
+	if e != nil {
+		t.Fatalf("cannot create screen: %v", e)
+	}
+	if e := s.Init(); e != nil {
+		t.Fatalf("cannot initialize screen: %v", e)
+	}
+	defer s.Fini()
+
+	text := NewText()
+	text.SetText("hello, world")
+	text.SetView(s)
+	text.CopyToClipboard()
+
+	want := "\x1b]52;c;" +
+		base64.StdEncoding.EncodeToString([]byte("hello, world")) + "\x07"
+	if got := out.String(); !strings.Contains(got, want) {
+		t.Errorf("output %q lacks %q", got, want)
+	}
+}
@@ -0,0 +1,87 @@
+// Copyright 2017 The TCell Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use file except in compliance with the License.
+// You may obtain a copy of the license at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package views
+
+import (
+	"testing"
+
+	"github.com/thyth/tcell"
+	"github.com/thyth/tcell/snapshot"
+)
+
+// drawn draws a widget on a simulation screen of the given size.  The
+// golden files for these tests are in testdata; run the tests with
+// TCELL_UPDATE_GOLDEN=1 set to update them.
+func drawn(t *testing.T, w Widget, width, height int) tcell.SimulationScreen {
+	s := tcell.NewSimulationScreen("UTF-8")
+	if e := s.Init(); e != nil {
+		t.Fatalf("cannot initialize screen: %v", e)
+	}
+	s.SetSize(width, height)
+	s.Show() // the new size only takes effect here
+	w.SetView(s)
+	w.Resize()
+	w.Draw()
+	s.Show()
+	return s
+}
+
+func newText(align Alignment, s string) *Text {
+	t := NewText()
+	t.SetAlignment(align)
+	t.SetText(s)
+	t.SetStyle(tcell.StyleDefault.Foreground(tcell.ColorYellow))
+	return t
+}
+
+func newTextBar() *TextBar {
+	t := NewTextBar()
+	t.SetStyle(tcell.StyleDefault.Reverse(true))
+	t.SetLeft("left", tcell.StyleDefault.Bold(true))
+	t.SetCenter("center", tcell.StyleDefault)
+	t.SetRight("right", tcell.StyleDefault.Foreground(tcell.ColorRed))
+	return t
+}
+
+func newStyledText() *SimpleStyledText {
+	t := NewSimpleStyledText()
+	t.RegisterStyle('G', tcell.StyleDefault.Foreground(tcell.ColorGreen))
+	t.SetMarkup("%BBold%N plain\n%Ugreen%G 世界%N done")
+	return t
+}
+
+func newBoxLayout() *BoxLayout {
+	b := NewBoxLayout(Vertical)
+	b.AddWidget(newText(HAlignLeft, "top"), 0)
+	b.AddWidget(NewSpacer(), 1)
+	b.AddWidget(newTextBar(), 0)
+	return b
+}
+
+func TestSnapshotText(t *testing.T) {
+	snapshot.Check(t, "text", drawn(t, newText(AlignMiddle, "Hello\nworld!"), 12, 4))
+}
+
+func TestSnapshotTextBar(t *testing.T) {
+	snapshot.Check(t, "textbar", drawn(t, newTextBar(), 24, 1))
+}
+
+func TestSnapshotStyledText(t *testing.T) {
+	snapshot.Check(t, "styledtext", drawn(t, newStyledText(), 16, 2))
+}
+
+func TestSnapshotBoxLayout(t *testing.T) {
+	snapshot.Check(t, "boxlayout", drawn(t, newBoxLayout(), 20, 5))
+}
@@ -227,6 +227,15 @@ func (t *Text) Text() string {
 	return string(t.text)
 }
 
+// CopyToClipboard puts the whole of the text on the user's clipboard, if
+// the View that the Text is on leads to one.  There is no selection; all
+// of the text is copied.  Terminals are told with OSC 52, which only the
+// XTerm entries in the terminal database have (as Ms), so on other
+// terminals this does nothing.
+func (t *Text) CopyToClipboard() {
+	setClipboard(t.view, []byte(string(t.text)))
+}
+
 // SetStyle sets the style used.  This applies to every cell in the
 // in the text.
 func (t *Text) SetStyle(style tcell.Style) {
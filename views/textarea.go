@@ -129,6 +129,21 @@ func (ta *TextArea) SetContent(text string) {
 	ta.SetLines(lines)
 }
 
+// CopyToClipboard puts all of the lines, each ended with a newline, on the
+// user's clipboard, if the View that the TextArea is on leads to one.
+// There is no selection; the whole content is copied, not just the part
+// that is visible.  As with Text, this does nothing on terminals that
+// lack OSC 52.
+func (ta *TextArea) CopyToClipboard() {
+	ta.Init()
+	var data []byte
+	for _, l := range ta.model.lines {
+		data = append(data, l...)
+		data = append(data, '\n')
+	}
+	setClipboard(ta.view, data)
+}
+
 // Init initializes the TextArea.
 func (ta *TextArea) Init() {
 	ta.once.Do(func() {
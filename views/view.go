@@ -42,6 +42,21 @@ type View interface {
 	Clear()
 }
 
+// Clipboard is implemented by Views that lead to the user's clipboard.  A
+// tcell.Screen is one, and so is a ViewPort on one.
+type Clipboard interface {
+	// SetClipboard puts the data on the clipboard, if it can.
+	SetClipboard(data []byte)
+}
+
+// setClipboard puts the data on the clipboard that the View leads to, if
+// there is one.
+func setClipboard(view View, data []byte) {
+	if cb, ok := view.(Clipboard); ok {
+		cb.SetClipboard(data)
+	}
+}
+
 // ViewPort is an implementation of a View, that provides a smaller logical
 // view of larger content area.  For example, a scrollable window of text,
 // the visible window would be the ViewPort, on the underlying content.
@@ -279,6 +294,12 @@ func (v *ViewPort) Resize(x, y, width, height int) {
 	}
 }
 
+// SetClipboard puts the data on the clipboard that the underlying View
+// leads to, if there is one.
+func (v *ViewPort) SetClipboard(data []byte) {
+	setClipboard(v.v, data)
+}
+
 // SetView is called during setup, to provide the parent View.
 func (v *ViewPort) SetView(view View) {
 	v.v = view
@@ -0,0 +1,598 @@
+// Copyright 2017 The TCell Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use file except in compliance with the License.
+// You may obtain a copy of the license at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vt
+
+import (
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/thyth/tcell"
+)
+
+// Parser states.  These follow the DEC ANSI parser described by Paul
+// Williams, although the states that only collect parameters are merged.
+const (
+	stGround = iota
+	stEscape
+	stEscInter
+	stCSI
+	stCSIIgnore
+	stOSC
+	stDCS
+	stString // SOS, PM and APC, which are ignored
+)
+
+// maxString limits the length of the OSC strings that are kept.
+const maxString = 1 << 20
+
+// Write interprets output sent to the terminal.  It never fails.
+// Sequences may be split across writes.
+func (t *Terminal) Write(b []byte) (int, error) {
+	for _, c := range b {
+		t.input(c)
+	}
+	return len(b), nil
+}
+
+func (t *Terminal) input(c byte) {
+	// These interrupt any sequence, just as they would on a real
+	// terminal.
+	switch c {
+	case 0x18, 0x1a: // CAN, SUB
+		t.flushUTF()
+		t.state = stGround
+		return
+	case 0x1b:
+		t.flushUTF()
+		if t.state == stOSC {
+			t.osc()
+		}
+		t.state = stEscape
+		t.inter = t.inter[:0]
+		return
+	}
+
+	switch t.state {
+	case stGround:
+		if len(t.utf) != 0 || c >= 0x80 {
+			t.decode(c)
+			return
+		}
+		if c < 0x20 || c == 0x7f {
+			t.control(c)
+			return
+		}
+		t.put(rune(c))
+
+	case stEscape, stEscInter:
+		switch {
+		case c < 0x20:
+			t.control(c)
+		case c < 0x30:
+			t.inter = append(t.inter, c)
+			t.state = stEscInter
+		case c == 0x7f:
+		case t.state == stEscInter:
+			t.state = stGround
+			t.escape(c)
+		case c == '[':
+			t.state = stCSI
+			t.params = t.params[:0]
+		case c == ']':
+			t.state = stOSC
+			t.str = t.str[:0]
+		case c == 'P':
+			t.state = stDCS
+		case c == 'X', c == '^', c == '_':
+			t.state = stString
+		default:
+			t.state = stGround
+			t.escape(c)
+		}
+
+	case stCSI, stCSIIgnore:
+		switch {
+		case c < 0x20:
+			t.control(c)
+		case c < 0x30:
+			t.inter = append(t.inter, c)
+		case c < 0x40:
+			if len(t.inter) != 0 {
+				t.state = stCSIIgnore
+			}
+			t.params = append(t.params, c)
+		case c < 0x7f:
+			if t.state == stCSI {
+				t.csi(c)
+			}
+			t.state = stGround
+		}
+
+	case stOSC:
+		switch {
+		case c == 0x07:
+			t.osc()
+			t.state = stGround
+		case c < 0x20:
+		case len(t.str) < maxString:
+			t.str = append(t.str, c)
+		}
+
+	case stDCS, stString:
+		// Terminated by ST, which starts with ESC.
+	}
+}
+
+// decode collects the bytes of a UTF-8 sequence, and displays the
+// character once it is complete.  Invalid sequences are shown as the
+// replacement character.
+func (t *Terminal) decode(c byte) {
+	t.utf = append(t.utf, c)
+	if !utf8.FullRune(t.utf) {
+		return
+	}
+	r, n := utf8.DecodeRune(t.utf)
+	rest := append([]byte(nil), t.utf[n:]...)
+	t.utf = t.utf[:0]
+	t.put(r)
+	for _, c := range rest {
+		t.input(c)
+	}
+}
+
+// flushUTF shows an incomplete UTF-8 sequence, which has been cut short
+// by a control character, as the replacement character.
+func (t *Terminal) flushUTF() {
+	if len(t.utf) != 0 {
+		t.utf = t.utf[:0]
+		t.put(utf8.RuneError)
+	}
+}
+
+// control executes a C0 control character.
+func (t *Terminal) control(c byte) {
+	switch c {
+	case 0x07:
+		t.bells++
+	case '\b':
+		if t.wrap {
+			t.wrap = false
+		} else if t.x > 0 {
+			t.x--
+		}
+	case '\t':
+		t.tab(1)
+	case '\n', '\v', '\f':
+		if t.ansi[ModeNewline] {
+			t.x = 0
+		}
+		t.index()
+	case '\r':
+		t.x = 0
+		t.wrap = false
+	case 0x0e: // SO
+		t.gl = 1
+	case 0x0f: // SI
+		t.gl = 0
+	default:
+		return
+	}
+	t.lastx, t.lasty = -1, -1
+}
+
+// escape executes an escape sequence.
+func (t *Terminal) escape(final byte) {
+	t.lastx, t.lasty = -1, -1
+	if len(t.inter) == 1 {
+		switch t.inter[0] {
+		case '(', ')', '*', '+':
+			t.g[t.inter[0]-'('] = final
+		case '#':
+			if final == '8' {
+				t.alignment()
+			}
+		}
+		return
+	}
+	if len(t.inter) != 0 {
+		return
+	}
+	switch final {
+	case '7':
+		t.saveCursor()
+	case '8':
+		t.restoreCursor()
+	case 'D':
+		t.index()
+	case 'E':
+		t.x = 0
+		t.index()
+	case 'H':
+		t.tabs[t.x] = true
+	case 'M':
+		t.reverseIndex()
+	case 'c':
+		t.Reset()
+	case 'n':
+		t.gl = 2
+	case 'o':
+		t.gl = 3
+	case '=':
+		t.dec[ModeKeypad] = true
+	case '>':
+		t.dec[ModeKeypad] = false
+	}
+}
+
+// alignment fills the screen with E's, for the DEC alignment test.
+func (t *Terminal) alignment() {
+	t.top, t.bottom = 0, t.h-1
+	for i := range t.cells {
+		t.cells[i] = cell{mainc: 'E', width: 1}
+	}
+	t.moveTo(0, 0)
+}
+
+// csiParams parses the parameters of a control sequence, returning any
+// private marker separately.  Each parameter is a list of the
+// sub-parameters separated by colons.  Missing values are -1.
+func (t *Terminal) csiParams() (byte, [][]int) {
+	p := t.params
+	var priv byte
+	if len(p) > 0 && p[0] >= '<' {
+		priv = p[0]
+		p = p[1:]
+	}
+	args := [][]int{nil}
+	n := -1
+	for _, c := range p {
+		last := len(args) - 1
+		switch {
+		case c >= '0' && c <= '9':
+			if n < 0 {
+				n = 0
+			}
+			if n < 1<<16 {
+				n = n*10 + int(c-'0')
+			}
+		case c == ':':
+			args[last] = append(args[last], n)
+			n = -1
+		case c == ';':
+			args[last] = append(args[last], n)
+			args = append(args, nil)
+			n = -1
+		}
+	}
+	last := len(args) - 1
+	args[last] = append(args[last], n)
+	return priv, args
+}
+
+// csi executes a control sequence.
+func (t *Terminal) csi(final byte) {
+	if final != 'b' {
+		t.lastx, t.lasty = -1, -1
+	}
+	priv, args := t.csiParams()
+	arg := func(i, def int) int {
+		if i < len(args) && args[i][0] > 0 {
+			return args[i][0]
+		}
+		return def
+	}
+
+	switch {
+	case priv == '?' && len(t.inter) == 0:
+		if final == 'h' || final == 'l' {
+			for _, a := range args {
+				t.privateMode(a[0], final == 'h')
+			}
+		}
+		return
+	case priv != 0:
+		return
+	case len(t.inter) == 1 && t.inter[0] == '!' && final == 'p':
+		t.softReset()
+		return
+	case len(t.inter) != 0:
+		return
+	}
+
+	switch final {
+	case '@':
+		t.insertBlanks(arg(0, 1))
+	case 'A':
+		t.moveVert(-arg(0, 1))
+	case 'B', 'e':
+		t.moveVert(arg(0, 1))
+	case 'C', 'a':
+		t.x = t.clampx(t.x + arg(0, 1))
+		t.wrap = false
+	case 'D':
+		t.x = t.clampx(t.x - arg(0, 1))
+		t.wrap = false
+	case 'E':
+		t.moveVert(arg(0, 1))
+		t.x = 0
+	case 'F':
+		t.moveVert(-arg(0, 1))
+		t.x = 0
+	case 'G', '`':
+		t.x = t.clampx(arg(0, 1) - 1)
+		t.wrap = false
+	case 'H', 'f':
+		t.moveTo(arg(1, 1)-1, arg(0, 1)-1)
+	case 'I':
+		t.tab(arg(0, 1))
+	case 'J':
+		switch arg(0, 0) {
+		case 0:
+			t.erase(t.y*t.w+t.x, len(t.cells))
+		case 1:
+			t.erase(0, t.y*t.w+t.x+1)
+		case 2, 3:
+			t.erase(0, len(t.cells))
+		}
+	case 'K':
+		switch arg(0, 0) {
+		case 0:
+			t.erase(t.y*t.w+t.x, (t.y+1)*t.w)
+		case 1:
+			t.erase(t.y*t.w, t.y*t.w+t.x+1)
+		case 2:
+			t.erase(t.y*t.w, (t.y+1)*t.w)
+		}
+	case 'L':
+		if t.y >= t.top && t.y <= t.bottom {
+			t.scrollDown(t.y, t.bottom, arg(0, 1))
+			t.x = 0
+		}
+	case 'M':
+		if t.y >= t.top && t.y <= t.bottom {
+			t.scrollUp(t.y, t.bottom, arg(0, 1))
+			t.x = 0
+		}
+	case 'P':
+		t.deleteChars(arg(0, 1))
+	case 'S':
+		t.scrollUp(t.top, t.bottom, arg(0, 1))
+	case 'T':
+		t.scrollDown(t.top, t.bottom, arg(0, 1))
+	case 'X':
+		n := arg(0, 1)
+		if n > t.w-t.x {
+			n = t.w - t.x
+		}
+		t.erase(t.y*t.w+t.x, t.y*t.w+t.x+n)
+	case 'Z':
+		t.tab(-arg(0, 1))
+	case 'b':
+		if t.lastx >= 0 {
+			c := t.cells[t.lasty*t.w+t.lastx]
+			for n := arg(0, 1); n > 0; n-- {
+				t.put(c.mainc)
+			}
+		}
+	case 'd':
+		t.moveTo(t.x, arg(0, 1)-1)
+	case 'g':
+		switch arg(0, 0) {
+		case 0:
+			t.tabs[t.x] = false
+		case 3:
+			for i := range t.tabs {
+				t.tabs[i] = false
+			}
+		}
+	case 'h', 'l':
+		for _, a := range args {
+			if a[0] > 0 {
+				t.ansi[a[0]] = final == 'h'
+			}
+		}
+	case 'm':
+		t.sgr(args)
+	case 'r':
+		top, bottom := arg(0, 1)-1, arg(1, t.h)-1
+		if bottom >= t.h {
+			bottom = t.h - 1
+		}
+		if top < bottom {
+			t.top, t.bottom = top, bottom
+			t.moveTo(0, 0)
+		}
+	case 's':
+		t.saveCursor()
+	case 'u':
+		t.restoreCursor()
+	case 't':
+		switch arg(0, 0) {
+		case 22:
+			t.titles = append(t.titles, t.title)
+		case 23:
+			if n := len(t.titles); n > 0 {
+				t.title = t.titles[n-1]
+				t.titles = t.titles[:n-1]
+			}
+		}
+	}
+}
+
+// privateMode sets or resets a DEC private mode.
+func (t *Terminal) privateMode(m int, on bool) {
+	switch m {
+	case ModeOrigin:
+		t.dec[m] = on
+		t.moveTo(0, 0)
+	case 47, 1047:
+		t.altScreen(on)
+	case 1048:
+		if on {
+			t.saveCursor()
+		} else {
+			t.restoreCursor()
+		}
+	case ModeAltScreen:
+		if on {
+			t.saveCursor()
+			t.altScreen(true)
+		} else {
+			t.altScreen(false)
+			t.restoreCursor()
+		}
+	}
+	if m > 0 {
+		t.dec[m] = on
+	}
+}
+
+// softReset implements DECSTR.
+func (t *Terminal) softReset() {
+	t.dec[ModeCursorVisible] = true
+	t.dec[ModeOrigin] = false
+	t.dec[ModeAutoWrap] = true
+	t.dec[ModeCursorKeys] = false
+	t.dec[ModeKeypad] = false
+	t.ansi[ModeInsert] = false
+	t.top, t.bottom = 0, t.h-1
+	t.style = tcell.StyleDefault
+	t.g = [4]byte{'B', 'B', 'B', 'B'}
+	t.gl = 0
+	t.saved = [2]cursor{{g: t.g}, {g: t.g}}
+}
+
+// osc executes an operating system command.
+func (t *Terminal) osc() {
+	s := string(t.str)
+	t.str = t.str[:0]
+	i := strings.IndexByte(s, ';')
+	if i < 0 {
+		return
+	}
+	cmd, e := strconv.Atoi(s[:i])
+	if e != nil {
+		return
+	}
+	switch cmd {
+	case 0, 2:
+		t.title = s[i+1:]
+	}
+}
+
+// sgr handles Select Graphic Rendition.
+func (t *Terminal) sgr(args [][]int) {
+	st := t.style
+	for i := 0; i < len(args); i++ {
+		a := args[i]
+		switch a[0] {
+		case -1, 0:
+			st = tcell.StyleDefault
+		case 1:
+			st = st.Bold(true)
+		case 2:
+			st = st.Dim(true)
+		case 4:
+			st = st.Underline(len(a) < 2 || a[1] != 0)
+		case 5, 6:
+			st = st.Blink(true)
+		case 7:
+			st = st.Reverse(true)
+		case 21:
+			st = st.Underline(true)
+		case 22:
+			st = st.Bold(false).Dim(false)
+		case 24:
+			st = st.Underline(false)
+		case 25:
+			st = st.Blink(false)
+		case 27:
+			st = st.Reverse(false)
+		case 39:
+			st = st.Foreground(tcell.ColorDefault)
+		case 49:
+			st = st.Background(tcell.ColorDefault)
+		case 38, 48, 58:
+			var c tcell.Color
+			if len(a) > 1 {
+				c = subColor(a)
+			} else {
+				c, i = extColor(args, i)
+			}
+			switch {
+			case c == tcell.ColorDefault:
+			case a[0] == 38:
+				st = st.Foreground(c)
+			case a[0] == 48:
+				st = st.Background(c)
+			}
+		default:
+			switch n := a[0]; {
+			case n >= 30 && n <= 37:
+				st = st.Foreground(tcell.Color(n - 30))
+			case n >= 40 && n <= 47:
+				st = st.Background(tcell.Color(n - 40))
+			case n >= 90 && n <= 97:
+				st = st.Foreground(tcell.Color(n - 90 + 8))
+			case n >= 100 && n <= 107:
+				st = st.Background(tcell.Color(n - 100 + 8))
+			}
+		}
+	}
+	t.style = st
+}
+
+// extColor decodes an extended color given as separate parameters,
+// either "5;n" for a palette index or "2;r;g;b" for an RGB value,
+// following the parameter at i.  It returns the color, or ColorDefault if
+// it is invalid, and the index of the last parameter used.
+func extColor(args [][]int, i int) (tcell.Color, int) {
+	if i+2 < len(args) && args[i+1][0] == 5 {
+		return paletteColor(args[i+2][0]), i + 2
+	}
+	if i+4 < len(args) && args[i+1][0] == 2 {
+		return rgbColor(args[i+2][0], args[i+3][0], args[i+4][0]), i + 4
+	}
+	return tcell.ColorDefault, len(args)
+}
+
+// subColor decodes an extended color given as sub-parameters, such as
+// "38:5:n", or "38:2::r:g:b" where the empty value is the color space.
+func subColor(a []int) tcell.Color {
+	switch {
+	case a[1] == 5 && len(a) == 3:
+		return paletteColor(a[2])
+	case a[1] == 2 && len(a) == 5:
+		return rgbColor(a[2], a[3], a[4])
+	case a[1] == 2 && len(a) >= 6:
+		return rgbColor(a[3], a[4], a[5])
+	}
+	return tcell.ColorDefault
+}
+
+func paletteColor(n int) tcell.Color {
+	if n < 0 || n > 255 {
+		return tcell.ColorDefault
+	}
+	return tcell.Color(n)
+}
+
+func rgbColor(r, g, b int) tcell.Color {
+	if r < 0 || g < 0 || b < 0 || r > 255 || g > 255 || b > 255 {
+		return tcell.ColorDefault
+	}
+	return tcell.NewRGBColor(int32(r), int32(g), int32(b))
+}
@@ -0,0 +1,605 @@
+// Copyright 2017 The TCell Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use file except in compliance with the License.
+// You may obtain a copy of the license at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package vt is a terminal emulator, written in pure Go, which interprets
+// the output sent to a VT100 or XTerm style terminal, and keeps track of
+// what the terminal would display.
+//
+// It understands the control sequences emitted by tcell for ANSI
+// terminals: cursor addressing and motion, erasing, insertion and
+// deletion, scrolling regions, colors (including 256 color and 24-bit
+// RGB) and attributes, the alternate screen, the line drawing character
+// set and window titles.  Sequences it does not implement are parsed
+// fully, so that they never end up on the screen, and then ignored.
+//
+// The main use is for testing what the escape sequences sent by a Screen
+// actually look like on a terminal, without needing one.  The contents
+// can be retrieved cell by cell, copied into a tcell.CellBuffer, or
+// returned in the same form as a tcell.SimulationScreen uses.
+package vt
+
+import (
+	"github.com/mattn/go-runewidth"
+	"github.com/thyth/tcell"
+)
+
+// Terminal is an emulated terminal.  Output for it is written with Write,
+// and its contents are examined with GetContent and friends.  To create
+// one, use NewTerminal.
+//
+// Terminal is not thread safe.
+type Terminal struct {
+	w      int
+	h      int
+	cells  []cell
+	other  []cell // the inactive one of the main and alternate screens
+	alt    bool
+	x      int
+	y      int
+	wrap   bool // the next character goes on the next line
+	style  tcell.Style
+	top    int // scrolling region
+	bottom int
+	tabs   []bool
+	g      [4]byte // designated character sets
+	gl     int     // the set invoked into GL
+	saved  [2]cursor
+	ansi   map[int]bool
+	dec    map[int]bool
+	title  string
+	titles []string // saved with XTerm's window operations
+	bells  int
+	lastx  int // the cell most recently written, for combining marks
+	lasty  int
+
+	state  int
+	params []byte
+	inter  []byte
+	str    []byte
+	utf    []byte
+}
+
+// cell is a character cell.  The second half of a wide character has a
+// width of zero.
+type cell struct {
+	mainc rune
+	combc []rune
+	style tcell.Style
+	width int
+}
+
+// cursor is the state saved by DECSC.
+type cursor struct {
+	x      int
+	y      int
+	wrap   bool
+	style  tcell.Style
+	g      [4]byte
+	gl     int
+	origin bool
+}
+
+// ANSI modes, which can be examined with Mode.
+const (
+	ModeInsert  = 4  // IRM
+	ModeNewline = 20 // LNM
+)
+
+// DEC private modes, which can be examined with PrivateMode.
+const (
+	ModeCursorKeys      = 1  // DECCKM
+	ModeReverseVideo    = 5  // DECSCNM
+	ModeOrigin          = 6  // DECOM
+	ModeAutoWrap        = 7  // DECAWM
+	ModeMouseX10        = 9  // X10 mouse reporting
+	ModeCursorVisible   = 25 // DECTCEM
+	ModeKeypad          = 66 // DECNKM, also set by DECKPAM
+	ModeMouseButton     = 1000
+	ModeMouseDrag       = 1002
+	ModeMouseMotion     = 1003
+	ModeFocus           = 1004
+	ModeMouseSGR        = 1006
+	ModeAltScreen       = 1049
+	ModeBracketedPaste  = 2004
+	ModeSynchronizedOut = 2026
+)
+
+// NewTerminal returns a terminal of the given size, in its initial state.
+func NewTerminal(w, h int) *Terminal {
+	t := &Terminal{}
+	t.resize(w, h)
+	t.Reset()
+	return t
+}
+
+// Reset returns the terminal to its initial state, clearing the screen.
+// The size is not changed.
+func (t *Terminal) Reset() {
+	if t.alt {
+		t.cells, t.other = t.other, t.cells
+		t.alt = false
+	}
+	t.style = tcell.StyleDefault
+	t.g = [4]byte{'B', 'B', 'B', 'B'}
+	t.gl = 0
+	t.top, t.bottom = 0, t.h-1
+	t.x, t.y = 0, 0
+	t.wrap = false
+	t.saved = [2]cursor{{g: t.g}, {g: t.g}}
+	t.ansi = make(map[int]bool)
+	t.dec = map[int]bool{
+		ModeAutoWrap:      true,
+		ModeCursorVisible: true,
+	}
+	t.title = ""
+	t.titles = nil
+	t.lastx, t.lasty = -1, -1
+	t.state = stGround
+	t.utf = t.utf[:0]
+	for i := range t.tabs {
+		t.tabs[i] = i%8 == 0
+	}
+	t.eraseCells(t.cells, 0, len(t.cells))
+	t.eraseCells(t.other, 0, len(t.other))
+}
+
+// Resize changes the size of the terminal.  Content at the top left is
+// kept, and the rest is cleared.  The scrolling region is reset.
+func (t *Terminal) Resize(w, h int) {
+	if w != t.w || h != t.h {
+		t.resize(w, h)
+	}
+}
+
+func (t *Terminal) resize(w, h int) {
+	if w < 1 {
+		w = 1
+	}
+	if h < 1 {
+		h = 1
+	}
+	t.cells = resizeCells(t.cells, t.w, t.h, w, h)
+	t.other = resizeCells(t.other, t.w, t.h, w, h)
+	tabs := make([]bool, w)
+	for i := range tabs {
+		if i < len(t.tabs) {
+			tabs[i] = t.tabs[i]
+		} else {
+			tabs[i] = i%8 == 0
+		}
+	}
+	t.tabs = tabs
+	t.w, t.h = w, h
+	t.top, t.bottom = 0, h-1
+	t.x, t.y = t.clampx(t.x), t.clampy(t.y)
+	t.wrap = false
+	t.lastx, t.lasty = -1, -1
+	for y := 0; y < h; y++ {
+		t.fixup(y, w-1, w-1)
+	}
+}
+
+func resizeCells(old []cell, ow, oh, w, h int) []cell {
+	cells := make([]cell, w*h)
+	for i := range cells {
+		cells[i] = blank
+	}
+	for y := 0; y < h && y < oh; y++ {
+		copy(cells[y*w:y*w+w], old[y*ow:y*ow+ow])
+	}
+	return cells
+}
+
+var blank = cell{mainc: ' ', width: 1}
+
+// Size returns the width and height of the terminal.
+func (t *Terminal) Size() (int, int) {
+	return t.w, t.h
+}
+
+// GetContent returns the contents of a cell, in the same way as
+// Screen.GetContent.  For the second half of a wide character, the
+// width is zero and the rune is a space.
+func (t *Terminal) GetContent(x, y int) (rune, []rune, tcell.Style, int) {
+	if x < 0 || y < 0 || x >= t.w || y >= t.h {
+		return ' ', nil, tcell.StyleDefault, 1
+	}
+	c := &t.cells[y*t.w+x]
+	if c.width == 0 {
+		return ' ', nil, c.style, 0
+	}
+	return c.mainc, c.combc, c.style, c.width
+}
+
+// GetContents returns the contents of the terminal in the form used by
+// SimulationScreen.  The second half of a wide character has no runes.
+func (t *Terminal) GetContents() ([]tcell.SimCell, int, int) {
+	sc := make([]tcell.SimCell, len(t.cells))
+	for i := range t.cells {
+		c := &t.cells[i]
+		sc[i].Style = c.style
+		if c.width == 0 {
+			continue
+		}
+		sc[i].Runes = append([]rune{c.mainc}, c.combc...)
+		sc[i].Bytes = []byte(string(sc[i].Runes))
+	}
+	return sc, t.w, t.h
+}
+
+// Render copies the contents of the terminal into a CellBuffer, which is
+// resized to match.
+func (t *Terminal) Render(cb *tcell.CellBuffer) {
+	cb.Resize(t.w, t.h)
+	for y := 0; y < t.h; y++ {
+		for x := 0; x < t.w; x++ {
+			c := &t.cells[y*t.w+x]
+			if c.width != 0 {
+				cb.SetContent(x, y, c.mainc, c.combc, c.style)
+			}
+		}
+	}
+}
+
+// Cursor returns the position of the cursor, and whether it is visible.
+func (t *Terminal) Cursor() (int, int, bool) {
+	return t.x, t.y, t.dec[ModeCursorVisible]
+}
+
+// Mode reports whether an ANSI mode, set with SM, is on.
+func (t *Terminal) Mode(m int) bool {
+	return t.ansi[m]
+}
+
+// PrivateMode reports whether a DEC private mode, set with DECSET, is on.
+func (t *Terminal) PrivateMode(m int) bool {
+	return t.dec[m]
+}
+
+// AltScreen reports whether the alternate screen is being shown.
+func (t *Terminal) AltScreen() bool {
+	return t.alt
+}
+
+// Title returns the window title, as last set with an OSC sequence, or
+// restored from the stack of saved titles.
+func (t *Terminal) Title() string {
+	return t.title
+}
+
+// Bells returns the number of times the bell has been rung.
+func (t *Terminal) Bells() int {
+	return t.bells
+}
+
+func (t *Terminal) clampx(x int) int {
+	if x >= t.w {
+		x = t.w - 1
+	}
+	if x < 0 {
+		x = 0
+	}
+	return x
+}
+
+func (t *Terminal) clampy(y int) int {
+	if y >= t.h {
+		y = t.h - 1
+	}
+	if y < 0 {
+		y = 0
+	}
+	return y
+}
+
+// moveTo moves the cursor, keeping it within the scrolling region if
+// origin mode is on.
+func (t *Terminal) moveTo(x, y int) {
+	t.x = t.clampx(x)
+	if t.dec[ModeOrigin] {
+		y += t.top
+		if y < t.top {
+			y = t.top
+		}
+		if y > t.bottom {
+			y = t.bottom
+		}
+	}
+	t.y = t.clampy(y)
+	t.wrap = false
+}
+
+// moveVert moves the cursor up or down, stopping at the margins if it
+// starts within the scrolling region.
+func (t *Terminal) moveVert(n int) {
+	y := t.y + n
+	if t.y >= t.top && y < t.top {
+		y = t.top
+	}
+	if t.y <= t.bottom && y > t.bottom {
+		y = t.bottom
+	}
+	t.y = t.clampy(y)
+	t.wrap = false
+}
+
+// put displays a character at the cursor position.
+func (t *Terminal) put(r rune) {
+	if r >= 0x20 && r < 0x7f {
+		switch t.g[t.gl] {
+		case '0':
+			if ld, ok := lineDrawing[r]; ok {
+				r = ld
+			}
+		case 'A':
+			if r == '#' {
+				r = tcell.RuneSterling
+			}
+		}
+	}
+
+	width := runewidth.RuneWidth(r)
+	if width == 0 {
+		// A combining mark goes with the character before it.
+		if t.lastx >= 0 {
+			c := &t.cells[t.lasty*t.w+t.lastx]
+			c.combc = append(c.combc, r)
+		}
+		return
+	}
+
+	if t.wrap && t.dec[ModeAutoWrap] {
+		t.x = 0
+		t.index()
+	}
+	t.wrap = false
+	if t.x+width > t.w {
+		if !t.dec[ModeAutoWrap] || width > t.w {
+			t.x = t.w - width
+			if t.x < 0 {
+				return
+			}
+		} else {
+			t.x = 0
+			t.index()
+		}
+	}
+	row := t.cells[t.y*t.w : (t.y+1)*t.w]
+	if t.ansi[ModeInsert] {
+		copy(row[t.x+width:], row[t.x:])
+	}
+	row[t.x] = cell{mainc: r, style: t.style, width: width}
+	if width > 1 {
+		row[t.x+1] = cell{style: t.style}
+	}
+	t.fixup(t.y, t.x, t.x+width-1)
+	if t.ansi[ModeInsert] {
+		t.fixup(t.y, t.w-1, t.w-1)
+	}
+	t.lastx, t.lasty = t.x, t.y
+	if t.x+width >= t.w {
+		t.x = t.w - 1
+		t.wrap = true
+	} else {
+		t.x += width
+	}
+}
+
+// fixup repairs wide characters around the cells from x0 to x1 of a line,
+// which have just been modified, by blanking any halves that have become
+// separated.
+func (t *Terminal) fixup(y, x0, x1 int) {
+	row := t.cells[y*t.w : (y+1)*t.w]
+	if x0 > 0 {
+		x0--
+	}
+	if x1 < t.w-1 {
+		x1++
+	}
+	for x := x0; x <= x1; x++ {
+		c := &row[x]
+		switch {
+		case c.width == 0 && (x == 0 || row[x-1].width != 2):
+			*c = cell{mainc: ' ', width: 1, style: c.style}
+		case c.width == 2 && (x == t.w-1 || row[x+1].width != 0):
+			*c = cell{mainc: ' ', width: 1, style: c.style}
+		}
+	}
+}
+
+// index moves the cursor down, scrolling at the bottom of the scrolling
+// region.
+func (t *Terminal) index() {
+	t.wrap = false
+	if t.y == t.bottom {
+		t.scrollUp(t.top, t.bottom, 1)
+	} else if t.y < t.h-1 {
+		t.y++
+	}
+}
+
+// reverseIndex moves the cursor up, scrolling at the top of the scrolling
+// region.
+func (t *Terminal) reverseIndex() {
+	t.wrap = false
+	if t.y == t.top {
+		t.scrollDown(t.top, t.bottom, 1)
+	} else if t.y > 0 {
+		t.y--
+	}
+}
+
+// scrollUp moves the lines from top to bottom up by n, clearing the lines
+// at the bottom.
+func (t *Terminal) scrollUp(top, bottom, n int) {
+	if n > bottom-top+1 {
+		n = bottom - top + 1
+	}
+	copy(t.cells[top*t.w:(bottom+1)*t.w], t.cells[(top+n)*t.w:(bottom+1)*t.w])
+	t.erase((bottom+1-n)*t.w, (bottom+1)*t.w)
+}
+
+// scrollDown moves the lines from top to bottom down by n, clearing the
+// lines at the top.
+func (t *Terminal) scrollDown(top, bottom, n int) {
+	if n > bottom-top+1 {
+		n = bottom - top + 1
+	}
+	copy(t.cells[(top+n)*t.w:(bottom+1)*t.w], t.cells[top*t.w:(bottom+1-n)*t.w])
+	t.erase(top*t.w, (top+n)*t.w)
+}
+
+// erase blanks the cells from start up to, but not including, end, which
+// are offsets into the screen.  Like XTerm, the blanks have the current
+// background color.
+func (t *Terminal) erase(start, end int) {
+	t.eraseCells(t.cells, start, end)
+	if start < end {
+		t.fixup(start/t.w, start%t.w, start%t.w)
+		t.fixup((end-1)/t.w, (end-1)%t.w, (end-1)%t.w)
+	}
+}
+
+func (t *Terminal) eraseCells(cells []cell, start, end int) {
+	_, bg, _ := t.style.Decompose()
+	c := cell{mainc: ' ', width: 1, style: tcell.StyleDefault.Background(bg)}
+	if end > len(cells) {
+		end = len(cells)
+	}
+	for i := start; i < end; i++ {
+		cells[i] = c
+	}
+}
+
+// insertBlanks inserts n blanks at the cursor, shifting the rest of the
+// line right.
+func (t *Terminal) insertBlanks(n int) {
+	if n > t.w-t.x {
+		n = t.w - t.x
+	}
+	row := t.cells[t.y*t.w : (t.y+1)*t.w]
+	copy(row[t.x+n:], row[t.x:])
+	t.erase(t.y*t.w+t.x, t.y*t.w+t.x+n)
+	t.fixup(t.y, t.w-1, t.w-1)
+}
+
+// deleteChars deletes n characters at the cursor, shifting the rest of
+// the line left.
+func (t *Terminal) deleteChars(n int) {
+	if n > t.w-t.x {
+		n = t.w - t.x
+	}
+	row := t.cells[t.y*t.w : (t.y+1)*t.w]
+	copy(row[t.x:], row[t.x+n:])
+	t.erase((t.y+1)*t.w-n, (t.y+1)*t.w)
+	t.fixup(t.y, t.x, t.x)
+}
+
+// tab moves the cursor n tab stops forward, or backward if n is
+// negative.
+func (t *Terminal) tab(n int) {
+	for ; n > 0 && t.x < t.w-1; n-- {
+		for t.x++; t.x < t.w-1 && !t.tabs[t.x]; t.x++ {
+		}
+	}
+	for ; n < 0 && t.x > 0; n++ {
+		for t.x--; t.x > 0 && !t.tabs[t.x]; t.x-- {
+		}
+	}
+	t.wrap = false
+}
+
+// altScreen switches between the main and alternate screens.  The
+// alternate screen is cleared when it is entered.
+func (t *Terminal) altScreen(on bool) {
+	if on == t.alt {
+		return
+	}
+	t.cells, t.other = t.other, t.cells
+	t.alt = on
+	if on {
+		t.erase(0, len(t.cells))
+	}
+	t.lastx, t.lasty = -1, -1
+}
+
+func (t *Terminal) saveCursor() {
+	i := 0
+	if t.alt {
+		i = 1
+	}
+	t.saved[i] = cursor{
+		x:      t.x,
+		y:      t.y,
+		wrap:   t.wrap,
+		style:  t.style,
+		g:      t.g,
+		gl:     t.gl,
+		origin: t.dec[ModeOrigin],
+	}
+}
+
+func (t *Terminal) restoreCursor() {
+	i := 0
+	if t.alt {
+		i = 1
+	}
+	c := &t.saved[i]
+	t.x, t.y = t.clampx(c.x), t.clampy(c.y)
+	t.wrap = c.wrap
+	t.style = c.style
+	t.g = c.g
+	t.gl = c.gl
+	t.dec[ModeOrigin] = c.origin
+}
+
+// lineDrawing maps the VT100 line drawing character set to Unicode.
+var lineDrawing = map[rune]rune{
+	'+': tcell.RuneRArrow,
+	',': tcell.RuneLArrow,
+	'-': tcell.RuneUArrow,
+	'.': tcell.RuneDArrow,
+	'0': tcell.RuneBlock,
+	'`': tcell.RuneDiamond,
+	'a': tcell.RuneCkBoard,
+	'b': '␉',
+	'c': '␌',
+	'd': '␋',
+	'e': '␊',
+	'f': tcell.RuneDegree,
+	'g': tcell.RunePlMinus,
+	'h': tcell.RuneBoard,
+	'i': tcell.RuneLantern,
+	'j': tcell.RuneLRCorner,
+	'k': tcell.RuneURCorner,
+	'l': tcell.RuneULCorner,
+	'm': tcell.RuneLLCorner,
+	'n': tcell.RunePlus,
+	'o': tcell.RuneS1,
+	'p': tcell.RuneS3,
+	'q': tcell.RuneHLine,
+	'r': tcell.RuneS7,
+	's': tcell.RuneS9,
+	't': tcell.RuneLTee,
+	'u': tcell.RuneRTee,
+	'v': tcell.RuneBTee,
+	'w': tcell.RuneTTee,
+	'x': tcell.RuneVLine,
+	'y': tcell.RuneLEqual,
+	'z': tcell.RuneGEqual,
+	'{': tcell.RunePi,
+	'|': tcell.RuneNEqual,
+	'}': tcell.RuneSterling,
+	'~': tcell.RuneBullet,
+}
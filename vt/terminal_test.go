@@ -0,0 +1,330 @@
+// Copyright 2017 The TCell Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use file except in compliance with the License.
+// You may obtain a copy of the license at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vt
+
+import (
+	"io"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/thyth/tcell"
+)
+
+// line returns the text of a line of the terminal, skipping the second
+// halves of wide characters.
+func line(t *Terminal, y int) string {
+	w, _ := t.Size()
+	s := ""
+	for x := 0; x < w; x++ {
+		mainc, combc, _, width := t.GetContent(x, y)
+		if width != 0 {
+			s += string(append([]rune{mainc}, combc...))
+		}
+	}
+	return s
+}
+
+func play(w, h int, s string) *Terminal {
+	t := NewTerminal(w, h)
+	io.WriteString(t, s)
+	return t
+}
+
+func TestText(t *testing.T) {
+
+	Convey("Printing text", t, func() {
+		Convey("Long lines wrap", func() {
+			vt := play(6, 3, "abcdefgh")
+			So(line(vt, 0), ShouldEqual, "abcdef")
+			So(line(vt, 1), ShouldEqual, "gh    ")
+		})
+		Convey("The last column does not wrap early", func() {
+			vt := play(6, 3, "abcdef\x1b[1;1HX")
+			So(line(vt, 0), ShouldEqual, "Xbcdef")
+			So(line(vt, 1), ShouldEqual, "      ")
+			vt = play(6, 3, "abcdef\r\n")
+			x, y, _ := vt.Cursor()
+			So(x, ShouldEqual, 0)
+			So(y, ShouldEqual, 1)
+		})
+		Convey("Wrapping can be disabled", func() {
+			vt := play(6, 3, "\x1b[?7labcdefgh")
+			So(line(vt, 0), ShouldEqual, "abcdeh")
+			So(vt.PrivateMode(ModeAutoWrap), ShouldBeFalse)
+		})
+		Convey("The screen scrolls", func() {
+			vt := play(3, 3, "1\r\n2\r\n3\r\n4")
+			So(line(vt, 0), ShouldEqual, "2  ")
+			So(line(vt, 2), ShouldEqual, "4  ")
+		})
+		Convey("Wide characters take two cells", func() {
+			vt := play(5, 2, "a世b")
+			So(line(vt, 0), ShouldEqual, "a世b ")
+			_, _, _, width := vt.GetContent(1, 0)
+			So(width, ShouldEqual, 2)
+			_, _, _, width = vt.GetContent(2, 0)
+			So(width, ShouldEqual, 0)
+
+			cells, w, _ := vt.GetContents()
+			So(w, ShouldEqual, 5)
+			So(string(cells[1].Bytes), ShouldEqual, "世")
+			So(cells[2].Runes, ShouldBeNil)
+		})
+		Convey("Wide characters that do not fit wrap", func() {
+			vt := play(4, 2, "abc世")
+			So(line(vt, 0), ShouldEqual, "abc ")
+			So(line(vt, 1), ShouldEqual, "世  ")
+		})
+		Convey("Overwriting half a wide character blanks it", func() {
+			vt := play(4, 2, "世界\x1b[1;2Hx")
+			So(line(vt, 0), ShouldEqual, " x界")
+			vt = play(4, 2, "世界\x1b[1;3Hx")
+			So(line(vt, 0), ShouldEqual, "世x ")
+		})
+		Convey("Combining characters join the cell before", func() {
+			vt := play(4, 2, "e\u0301x")
+			mainc, combc, _, _ := vt.GetContent(0, 0)
+			So(mainc, ShouldEqual, 'e')
+			So(combc, ShouldResemble, []rune{'\u0301'})
+			So(line(vt, 0), ShouldEqual, "e\u0301x  ")
+		})
+		Convey("Characters may be split across writes", func() {
+			vt := NewTerminal(4, 2)
+			vt.Write([]byte{0xe4, 0xb8})
+			vt.Write([]byte{0x96, 0x1b, '['})
+			vt.Write([]byte("2;2Hz"))
+			So(line(vt, 0), ShouldEqual, "世  ")
+			So(line(vt, 1), ShouldEqual, " z  ")
+		})
+		Convey("Invalid UTF-8 is replaced", func() {
+			vt := play(4, 2, "\xffa\xe4\x1b[Cb")
+			So(line(vt, 0), ShouldEqual, "�a�b")
+		})
+		Convey("Insert mode shifts the line", func() {
+			vt := play(5, 2, "abc\r\x1b[4hxy")
+			So(line(vt, 0), ShouldEqual, "xyabc")
+			So(vt.Mode(ModeInsert), ShouldBeTrue)
+		})
+		Convey("Tabs stop every eight columns", func() {
+			vt := play(20, 2, "a\tb\tc\x1b[2Zd")
+			So(line(vt, 0), ShouldEqual, "a       d       c   ")
+		})
+	})
+}
+
+func TestControl(t *testing.T) {
+
+	Convey("Control sequences", t, func() {
+		Convey("Cursor addressing", func() {
+			vt := play(10, 5, "\x1b[3;4H")
+			x, y, visible := vt.Cursor()
+			So(x, ShouldEqual, 3)
+			So(y, ShouldEqual, 2)
+			So(visible, ShouldBeTrue)
+
+			io.WriteString(vt, "\x1b[A\x1b[2C\x1b[99B\x1b[?25l")
+			x, y, visible = vt.Cursor()
+			So(x, ShouldEqual, 5)
+			So(y, ShouldEqual, 4)
+			So(visible, ShouldBeFalse)
+
+			io.WriteString(vt, "\x1b[H\x1b[5G\x1b[2d")
+			x, y, _ = vt.Cursor()
+			So(x, ShouldEqual, 4)
+			So(y, ShouldEqual, 1)
+		})
+		Convey("Erasing", func() {
+			vt := play(4, 3, "abcd\r\nefgh\r\nijkl\x1b[2;2H\x1b[K")
+			So(line(vt, 1), ShouldEqual, "e   ")
+			io.WriteString(vt, "\x1b[1K")
+			So(line(vt, 1), ShouldEqual, "    ")
+			io.WriteString(vt, "\x1b[1;3H\x1b[1J")
+			So(line(vt, 0), ShouldEqual, "   d")
+			io.WriteString(vt, "\x1b[J")
+			So(line(vt, 0), ShouldEqual, "    ")
+			So(line(vt, 2), ShouldEqual, "    ")
+
+			vt = play(4, 1, "abcd\x1b[2G\x1b[2X")
+			So(line(vt, 0), ShouldEqual, "a  d")
+		})
+		Convey("Erasing uses the background color", func() {
+			vt := play(6, 3, "abc\x1b[1;44m\x1b[1;2H\x1b[K")
+			So(line(vt, 0), ShouldEqual, "a     ")
+			_, _, st, _ := vt.GetContent(1, 0)
+			So(st, ShouldEqual, tcell.StyleDefault.Background(tcell.ColorNavy))
+		})
+		Convey("Inserting and deleting", func() {
+			vt := play(5, 1, "abcde\x1b[1;2H\x1b[2@")
+			So(line(vt, 0), ShouldEqual, "a  bc")
+			io.WriteString(vt, "\x1b[3P")
+			So(line(vt, 0), ShouldEqual, "ac   ")
+
+			vt = play(2, 4, "1\r\n2\r\n3\r\n4\x1b[2H\x1b[L")
+			So(line(vt, 1), ShouldEqual, "  ")
+			So(line(vt, 2), ShouldEqual, "2 ")
+			So(line(vt, 3), ShouldEqual, "3 ")
+			io.WriteString(vt, "\x1b[2M")
+			So(line(vt, 1), ShouldEqual, "3 ")
+			So(line(vt, 2), ShouldEqual, "  ")
+		})
+		Convey("Repeating", func() {
+			vt := play(6, 1, "a\x1b[3bc")
+			So(line(vt, 0), ShouldEqual, "aaaac ")
+		})
+		Convey("Scrolling regions", func() {
+			vt := play(2, 5, "1\r\n2\r\n3\r\n4\r\n5\x1b[2;4r")
+			x, y, _ := vt.Cursor()
+			So(x, ShouldEqual, 0)
+			So(y, ShouldEqual, 0)
+			io.WriteString(vt, "\x1b[4H\nx")
+			So(line(vt, 0), ShouldEqual, "1 ")
+			So(line(vt, 1), ShouldEqual, "3 ")
+			So(line(vt, 2), ShouldEqual, "4 ")
+			So(line(vt, 3), ShouldEqual, "x ")
+			So(line(vt, 4), ShouldEqual, "5 ")
+			io.WriteString(vt, "\x1b[2H\x1bM")
+			So(line(vt, 1), ShouldEqual, "  ")
+			So(line(vt, 2), ShouldEqual, "3 ")
+			io.WriteString(vt, "\x1b[S")
+			So(line(vt, 1), ShouldEqual, "3 ")
+			So(line(vt, 4), ShouldEqual, "5 ")
+		})
+		Convey("Origin mode", func() {
+			vt := play(4, 5, "\x1b[2;4r\x1b[?6h\x1b[1;1Hx\x1b[9;1Hy")
+			So(line(vt, 1), ShouldEqual, "x   ")
+			So(line(vt, 3), ShouldEqual, "y   ")
+		})
+		Convey("Saving the cursor", func() {
+			vt := play(4, 3, "\x1b[2;2H\x1b[31m\x1b7\x1b[m\x1b[H\x1b8x")
+			So(line(vt, 1), ShouldEqual, " x  ")
+			_, _, st, _ := vt.GetContent(1, 1)
+			So(st, ShouldEqual, tcell.StyleDefault.Foreground(tcell.ColorMaroon))
+		})
+		Convey("The alternate screen", func() {
+			vt := play(4, 2, "main\x1b[?1049h")
+			So(vt.AltScreen(), ShouldBeTrue)
+			So(line(vt, 0), ShouldEqual, "    ")
+			io.WriteString(vt, "\x1b[Halt\x1b[?1049l")
+			So(vt.AltScreen(), ShouldBeFalse)
+			So(line(vt, 0), ShouldEqual, "main")
+			x, y, _ := vt.Cursor()
+			So(x, ShouldEqual, 3)
+			So(y, ShouldEqual, 0)
+		})
+		Convey("Line drawing", func() {
+			vt := play(5, 1, "\x1b(0lqk\x1b(Bq")
+			So(line(vt, 0), ShouldEqual, "┌─┐q ")
+			vt = play(5, 1, "\x1b)0a\x0eqx\x0fq")
+			So(line(vt, 0), ShouldEqual, "a─│q ")
+		})
+		Convey("Strings are skipped", func() {
+			vt := play(4, 1, "\x1b]0;title\x07a\x1bP1$r\x1b\\b\x1b_x\x1b\\c")
+			So(line(vt, 0), ShouldEqual, "abc ")
+			So(vt.Title(), ShouldEqual, "title")
+			io.WriteString(vt, "\x1b]2;über\x1b\\")
+			So(vt.Title(), ShouldEqual, "über")
+		})
+		Convey("Titles can be saved and restored", func() {
+			vt := play(4, 1, "\x1b]2;a\x07\x1b[22;0t\x1b]2;b\x07")
+			So(vt.Title(), ShouldEqual, "b")
+			io.WriteString(vt, "\x1b[23;0t")
+			So(vt.Title(), ShouldEqual, "a")
+			io.WriteString(vt, "\x1b[23;0t")
+			So(vt.Title(), ShouldEqual, "a")
+		})
+		Convey("Unknown sequences are ignored", func() {
+			vt := play(4, 1, "\x1b[>4;1m\x1b[?1000;1006h\x1b[1 q\x1b%Ga\x1b[5y")
+			So(line(vt, 0), ShouldEqual, "a   ")
+			So(vt.PrivateMode(ModeMouseButton), ShouldBeTrue)
+			So(vt.PrivateMode(ModeMouseSGR), ShouldBeTrue)
+		})
+		Convey("Bells are counted", func() {
+			vt := play(4, 1, "\a\x1b]0;x\a\a")
+			So(vt.Bells(), ShouldEqual, 2)
+		})
+		Convey("Reset", func() {
+			vt := play(4, 2, "ab\x1b[31m\x1b[?25l\x1bc")
+			So(line(vt, 0), ShouldEqual, "    ")
+			_, _, visible := vt.Cursor()
+			So(visible, ShouldBeTrue)
+			io.WriteString(vt, "x")
+			_, _, st, _ := vt.GetContent(0, 0)
+			So(st, ShouldEqual, tcell.StyleDefault)
+		})
+	})
+}
+
+func TestAttributes(t *testing.T) {
+
+	Convey("Colors and attributes", t, func() {
+		style := func(s string) tcell.Style {
+			vt := play(4, 1, s+"x")
+			_, _, st, _ := vt.GetContent(0, 0)
+			return st
+		}
+		def := tcell.StyleDefault
+
+		So(style("\x1b[1;4;5;7m"), ShouldEqual,
+			def.Bold(true).Underline(true).Blink(true).Reverse(true))
+		So(style("\x1b[1;2m\x1b[22m"), ShouldEqual, def)
+		So(style("\x1b[4:3m"), ShouldEqual, def.Underline(true))
+		So(style("\x1b[4m\x1b[4:0m"), ShouldEqual, def)
+		So(style("\x1b[31;42m"), ShouldEqual,
+			def.Foreground(tcell.ColorMaroon).Background(tcell.ColorGreen))
+		So(style("\x1b[91;102m"), ShouldEqual,
+			def.Foreground(tcell.ColorRed).Background(tcell.ColorLime))
+		So(style("\x1b[31m\x1b[39m"), ShouldEqual, def)
+		So(style("\x1b[38;5;200;48;5;17m"), ShouldEqual,
+			def.Foreground(tcell.Color(200)).Background(tcell.Color(17)))
+		So(style("\x1b[38;2;1;2;3m"), ShouldEqual,
+			def.Foreground(tcell.NewRGBColor(1, 2, 3)))
+		So(style("\x1b[48:2::10:20:30m"), ShouldEqual,
+			def.Background(tcell.NewRGBColor(10, 20, 30)))
+		So(style("\x1b[38:5:9m"), ShouldEqual,
+			def.Foreground(tcell.ColorRed))
+		// Parameters after an extended color still apply.
+		So(style("\x1b[38;5;1;1m"), ShouldEqual,
+			def.Foreground(tcell.ColorMaroon).Bold(true))
+	})
+}
+
+func TestBuffers(t *testing.T) {
+
+	Convey("Copying the contents", t, func() {
+		vt := play(4, 2, "\x1b[31mab\x1b[m世")
+		cb := &tcell.CellBuffer{}
+		vt.Render(cb)
+		w, h := cb.Size()
+		So(w, ShouldEqual, 4)
+		So(h, ShouldEqual, 2)
+		mainc, _, st, _ := cb.GetContent(1, 0)
+		So(mainc, ShouldEqual, 'b')
+		So(st, ShouldEqual, tcell.StyleDefault.Foreground(tcell.ColorMaroon))
+		mainc, _, _, width := cb.GetContent(2, 0)
+		So(mainc, ShouldEqual, '世')
+		So(width, ShouldEqual, 2)
+	})
+
+	Convey("Resizing keeps the contents", t, func() {
+		vt := play(4, 2, "abcd\r\nefgh")
+		vt.Resize(2, 3)
+		So(line(vt, 0), ShouldEqual, "ab")
+		So(line(vt, 1), ShouldEqual, "ef")
+		So(line(vt, 2), ShouldEqual, "  ")
+		x, y, _ := vt.Cursor()
+		So(x, ShouldEqual, 1)
+		So(y, ShouldEqual, 1)
+	})
+}